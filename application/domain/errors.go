@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrReadOnly is returned when a write hits a database in recovery or read-only mode
+var ErrReadOnly = errors.New("database temporarily read-only")
+
+// ErrTotalMismatch is returned when a client-provided total does not match the sum of the items
+var ErrTotalMismatch = errors.New("total mismatch")
+
+// ErrTotalOutOfRange is returned when an order total is not finite or exceeds the configured maximum
+var ErrTotalOutOfRange = errors.New("order total out of range")
+
+// FieldError ties a validation error to the request field that caused it, e.g. "items[2]"
+type FieldError struct {
+	Field string
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %v", e.Field, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// ErrInvalidStatusTransition is returned when an order cannot move from its current status to the requested one
+var ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+// ErrOrderModified is returned when an update was made against a version of the order that is no
+// longer current, either named by If-Match or replaced by a concurrent write
+var ErrOrderModified = errors.New("order has been modified")
+
+// ErrNotOrderOwner is returned when a write that is limited to the owner targets another user's order
+var ErrNotOrderOwner = errors.New("order belongs to another user")
+
+// ErrOrderNotCancellable is returned when cancelling an order that is completed or already partially shipped
+var ErrOrderNotCancellable = errors.New("order cannot be cancelled")
+
+// ErrInvalidPatch is returned when a patch touches a read-only field or produces an invalid order
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// ErrItemsUnavailable is returned with a loaded order when its items could not be read
+var ErrItemsUnavailable = errors.New("order items could not be loaded")
+
+// ErrEmptyFilter is returned when a bulk delete has no filter and would remove every order
+var ErrEmptyFilter = errors.New("at least one filter is required")
+
+// ErrInvalidItemStatus is returned when an item status update names an unknown status
+var ErrInvalidItemStatus = errors.New("invalid item status")
+
+// ErrInvalidShipment is returned when a shipment is empty, repeats an item, ships more than was
+// ordered or targets a cancelled order
+var ErrInvalidShipment = errors.New("invalid shipment")
+
+// ErrInvalidOrderNumber is returned when an order number does not have the ORD-YYYYMMDD-XXXXXX format
+var ErrInvalidOrderNumber = errors.New("invalid order number")
+
+// ErrInvalidNote is returned when a note is empty or its text or author is too long
+var ErrInvalidNote = errors.New("invalid note")
+
+// ErrBatchInvalid is returned by an atomic batch create when any order fails validation
+var ErrBatchInvalid = errors.New("batch contains invalid orders")
+
+// ErrQuotaExceeded is returned when a customer has created the maximum number of orders allowed in the quota window
+var ErrQuotaExceeded = errors.New("order quota exceeded")
+
+// ErrDuplicateItems is returned when an order repeats a product name and duplicates are rejected
+var ErrDuplicateItems = errors.New("duplicate items")
+
+// DuplicateItemsError lists the product names that appear more than once in an order
+type DuplicateItemsError struct {
+	ProductNames []string
+}
+
+func (e *DuplicateItemsError) Error() string {
+	return fmt.Sprintf("%v: %s", ErrDuplicateItems, strings.Join(e.ProductNames, ", "))
+}
+
+func (e *DuplicateItemsError) Unwrap() error {
+	return ErrDuplicateItems
+}
@@ -2,22 +2,58 @@ package domain
 
 import (
 	"context"
+	"time"
 
 	"github.com/Testzyler/order-management-go/application/models"
 )
 
 type OrderService interface {
-	CreateOrder(ctx context.Context, order models.CreateOrderInput) error
+	CreateOrder(ctx context.Context, order models.CreateOrderInput) (models.OrderWithItems, error)
+	CreateOrders(ctx context.Context, orders []models.CreateOrderInput, atomic bool) (models.BatchCreateResult, error)
+	BulkCreateOrders(ctx context.Context, orders []models.CreateOrderInput, atomic bool) ([]models.BulkOrderResult, error)
+	UpsertOrderByNumber(ctx context.Context, orderNumber string, input models.CreateOrderInput) (models.OrderWithItems, bool, error)
 	GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error)
+	GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error)
+	GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error)
+	GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error)
 	UpdateOrder(ctx context.Context, order models.UpdateOrderInput) error
 	DeleteOrder(ctx context.Context, id int) error
 	ListOrders(ctx context.Context, input models.ListInput) (models.ListPaginatedOrders, error)
+	RecentOrders(ctx context.Context, limit int) ([]models.Order, error)
+	ExpirePendingOrders(ctx context.Context, maxAge time.Duration) (int64, error)
+	GetDailyTotals(ctx context.Context, input models.DailyTotalsInput) ([]models.DailyOrderTotal, error)
+	PatchOrder(ctx context.Context, id int, input models.PatchOrderInput) (models.OrderWithItems, error)
+	CancelOrder(ctx context.Context, id int) (models.OrderWithItems, error)
+	MergePatchOrder(ctx context.Context, id int, patch []byte, ifMatch string) (models.OrderWithItems, error)
+	UpdateItemStatus(ctx context.Context, input models.UpdateItemStatusInput) (models.OrderWithItems, error)
+	ShipOrder(ctx context.Context, input models.ShipOrderInput) (models.ShipOrderResult, error)
+	DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter) (int64, error)
+	AddOrderNote(ctx context.Context, input models.CreateNoteInput) (models.OrderNote, error)
+	ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error)
 }
 
 type OrderRepository interface {
-	CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) error
+	CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) (models.OrderWithItems, error)
+	CreateOrders(ctx context.Context, orders []models.OrderWithItems) ([]models.OrderWithItems, error)
+	BulkCreateOrders(ctx context.Context, orders []models.OrderWithItems) ([]models.OrderWithItems, error)
+	UpsertOrderByNumber(ctx context.Context, order models.Order, items []models.OrderItem, statusFrom []models.Status, ownerOnly bool) (models.OrderWithItems, bool, error)
 	GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error)
-	UpdateOrder(ctx context.Context, order models.Order) error
+	GetOrdersByIds(ctx context.Context, ids []int) (map[int]models.OrderWithItems, error)
+	GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error)
+	GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error)
+	GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error)
+	UpdateOrder(ctx context.Context, order models.Order, precondition models.OrderPrecondition) error
+	CancelOrder(ctx context.Context, id int, cancelledAt time.Time) (models.Status, error)
+	UpdateOrderItemStatus(ctx context.Context, orderID int, itemID int, status models.ItemStatus, updatedAt time.Time) error
+	ShipOrderItems(ctx context.Context, orderID int, items []models.ShipItem, shippedAt time.Time) (models.Fulfillment, error)
 	DeleteOrder(ctx context.Context, id int) error
+	DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter, batchSize int) (int64, error)
 	ListOrders(ctx context.Context, input models.ListInput) (*models.ListPaginatedOrders, error)
+	StreamOrders(ctx context.Context, filter models.OrderFilter) (<-chan models.OrderWithItems, <-chan error)
+	CountOrders(ctx context.Context, input models.ListInput) (int, error)
+	RecentOrders(ctx context.Context, limit int) ([]models.Order, error)
+	ExpirePendingOrders(ctx context.Context, createdBefore time.Time, expiredAt time.Time) (int64, error)
+	GetDailyTotals(ctx context.Context, from time.Time, to time.Time) ([]models.DailyOrderTotal, error)
+	CreateOrderNote(ctx context.Context, note models.OrderNote) (models.OrderNote, error)
+	ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error)
 }
@@ -0,0 +1,21 @@
+package models
+
+// BatchOrderError reports why the order at Index of a batch was not created
+type BatchOrderError struct {
+	Index   int    `json:"index"`
+	Message string `json:"message"`
+}
+
+// BatchCreateResult lists the created orders, in request order, and the per-index failures
+type BatchCreateResult struct {
+	Created []OrderWithItems  `json:"created"`
+	Errors  []BatchOrderError `json:"errors,omitempty"`
+}
+
+// BulkOrderResult reports the outcome of the order at Index of a bulk create: the created order,
+// or why it was not created
+type BulkOrderResult struct {
+	Index int             `json:"index"`
+	Order *OrderWithItems `json:"order,omitempty"`
+	Error string          `json:"error,omitempty"`
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// DeleteOrdersFilter selects the orders removed by a bulk delete; set fields are combined with AND
+type DeleteOrdersFilter struct {
+	Status        Status     `json:"status,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
+
+// IsEmpty reports whether the filter would match every order
+func (f DeleteOrdersFilter) IsEmpty() bool {
+	return f.Status == "" && f.CreatedBefore == nil
+}
@@ -0,0 +1,142 @@
+package models
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// List sort orders; the cursor records which one produced it
+const (
+	SortCreatedDesc = "created_at_desc"
+	SortUpdatedAsc  = "updated_at_asc"
+)
+
+// ErrInvalidCursor is returned when a cursor token cannot be decoded
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// cursorAEAD seals list cursors so clients can neither read the serial order ID they hold nor
+// forge one. Until SetCursorKey configures a shared key it uses a random key, so cursors are only
+// accepted by the instance that issued them and until it restarts.
+var cursorAEAD = mustCursorAEAD(randomCursorKey())
+
+// SetCursorKey configures the base64 encoded 32-byte AES key list cursors are sealed with. Every
+// instance behind a load balancer must share it. An empty key keeps the random per-process key.
+func SetCursorKey(encoded string) error {
+	if encoded == "" {
+		return nil
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return fmt.Errorf("cursor key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("cursor key must be 32 bytes, got %d", len(key))
+	}
+	cursorAEAD = mustCursorAEAD(key)
+	return nil
+}
+
+func randomCursorKey() []byte {
+	key := make([]byte, 32)
+	_, _ = rand.Read(key)
+	return key
+}
+
+func mustCursorAEAD(key []byte) cipher.AEAD {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		panic(err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+	return aead
+}
+
+// ListCursor is the keyset position after the last order of a page together with the filters and
+// sort that produced it. Clients receive it as an opaque, encrypted token.
+type ListCursor struct {
+	Sort         string     `json:"sort"`
+	UpdatedSince *time.Time `json:"updated_since,omitempty"`
+	UserID       string     `json:"user_id,omitempty"`
+	Statuses     []Status   `json:"statuses,omitempty"`
+	LastTime     time.Time  `json:"last_time"`
+	LastID       int        `json:"last_id"`
+}
+
+// SortOrder reports the sort implied by the list filters
+func (in ListInput) SortOrder() string {
+	if in.UpdatedSince != nil {
+		return SortUpdatedAsc
+	}
+	return SortCreatedDesc
+}
+
+// NewListCursor returns the cursor that continues a listing after last
+func NewListCursor(in ListInput, last Order) ListCursor {
+	cursor := ListCursor{
+		Sort:         in.SortOrder(),
+		UpdatedSince: in.UpdatedSince,
+		UserID:       in.UserID,
+		Statuses:     in.Statuses,
+		LastTime:     last.CreatedAt,
+		LastID:       last.ID,
+	}
+	if cursor.Sort == SortUpdatedAsc {
+		cursor.LastTime = last.UpdatedAt
+	}
+	return cursor
+}
+
+// Matches reports whether the cursor was produced by the same filters and sort as in
+func (c ListCursor) Matches(in ListInput) bool {
+	if c.Sort != in.SortOrder() || c.UserID != in.UserID || !slices.Equal(c.Statuses, in.Statuses) {
+		return false
+	}
+	if c.UpdatedSince == nil || in.UpdatedSince == nil {
+		return c.UpdatedSince == nil && in.UpdatedSince == nil
+	}
+	return c.UpdatedSince.Equal(*in.UpdatedSince)
+}
+
+// Encode returns the opaque token handed to clients: the cursor sealed with the cursor key
+func (c ListCursor) Encode() string {
+	data, _ := json.Marshal(c)
+	nonce := make([]byte, cursorAEAD.NonceSize())
+	_, _ = rand.Read(nonce)
+	return base64.RawURLEncoding.EncodeToString(cursorAEAD.Seal(nonce, nonce, data, nil))
+}
+
+// DecodeCursor parses a token produced by ListCursor.Encode, rejecting tokens that were not
+// sealed with the cursor key or were altered
+func DecodeCursor(token string) (ListCursor, error) {
+	nonceSize := cursorAEAD.NonceSize()
+	sealed, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil || len(sealed) < nonceSize {
+		return ListCursor{}, ErrInvalidCursor
+	}
+	data, err := cursorAEAD.Open(nil, sealed[:nonceSize], sealed[nonceSize:], nil)
+	if err != nil {
+		return ListCursor{}, ErrInvalidCursor
+	}
+
+	var cursor ListCursor
+	if err := json.Unmarshal(data, &cursor); err != nil {
+		return ListCursor{}, ErrInvalidCursor
+	}
+	if (cursor.Sort != SortCreatedDesc && cursor.Sort != SortUpdatedAsc) || cursor.LastID <= 0 {
+		return ListCursor{}, ErrInvalidCursor
+	}
+	if (cursor.Sort == SortUpdatedAsc) != (cursor.UpdatedSince != nil) {
+		return ListCursor{}, ErrInvalidCursor
+	}
+	return cursor, nil
+}
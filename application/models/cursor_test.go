@@ -0,0 +1,107 @@
+package models
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestListCursor_RoundTrip(t *testing.T) {
+	// Arrange
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	input := ListInput{Page: 1, Size: 10, UpdatedSince: &since}
+	last := Order{ID: 42, CreatedAt: since.Add(-time.Hour), UpdatedAt: since.Add(time.Hour)}
+
+	// Act
+	cursor, err := DecodeCursor(NewListCursor(input, last).Encode())
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, SortUpdatedAsc, cursor.Sort)
+	assert.True(t, cursor.UpdatedSince.Equal(since))
+	assert.True(t, cursor.LastTime.Equal(last.UpdatedAt))
+	assert.Equal(t, 42, cursor.LastID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{name: "not base64", token: "%%%"},
+		{name: "not sealed", token: base64.RawURLEncoding.EncodeToString([]byte(`{"sort":"created_at_desc","last_time":"2025-01-02T03:04:05Z","last_id":1}`))},
+		{name: "tampered", token: tamperedCursor()},
+		{name: "unknown sort", token: ListCursor{Sort: "price_desc", LastID: 1}.Encode()},
+		{name: "missing id", token: ListCursor{Sort: SortCreatedDesc}.Encode()},
+		{name: "sort disagrees with filters", token: ListCursor{Sort: SortUpdatedAsc, LastID: 1}.Encode()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			_, err := DecodeCursor(tt.token)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrInvalidCursor)
+		})
+	}
+}
+
+// tamperedCursor returns a valid cursor token with one byte of its ciphertext flipped
+func tamperedCursor() string {
+	sealed, _ := base64.RawURLEncoding.DecodeString(ListCursor{Sort: SortCreatedDesc, LastID: 1}.Encode())
+	sealed[len(sealed)-1] ^= 0xff
+	return base64.RawURLEncoding.EncodeToString(sealed)
+}
+
+func TestListCursor_EncodeHidesTheOrderID(t *testing.T) {
+	// Arrange
+	cursor := NewListCursor(ListInput{}, Order{ID: 424242, CreatedAt: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)})
+
+	// Act
+	token := cursor.Encode()
+	data, err := base64.RawURLEncoding.DecodeString(token)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, strings.Contains(string(data), "424242"))
+	assert.NotEqual(t, token, cursor.Encode(), "tokens use a fresh nonce")
+}
+
+func TestSetCursorKey(t *testing.T) {
+	// Arrange
+	previous := cursorAEAD
+	t.Cleanup(func() { cursorAEAD = previous })
+	token := ListCursor{Sort: SortCreatedDesc, LastID: 1}.Encode()
+
+	// Act
+	badLengthErr := SetCursorKey(base64.StdEncoding.EncodeToString([]byte("short")))
+	err := SetCursorKey(base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32))))
+	_, decodeErr := DecodeCursor(token)
+
+	// Assert
+	assert.Error(t, badLengthErr)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, decodeErr, ErrInvalidCursor, "tokens sealed with another key are rejected")
+}
+
+func TestListCursor_Matches(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	other := since.Add(time.Minute)
+	withSince := NewListCursor(ListInput{UpdatedSince: &since}, Order{ID: 1})
+	withoutFilters := NewListCursor(ListInput{}, Order{ID: 1})
+
+	assert.True(t, withSince.Matches(ListInput{UpdatedSince: &since}))
+	assert.True(t, withoutFilters.Matches(ListInput{}))
+	assert.False(t, withSince.Matches(ListInput{UpdatedSince: &other}))
+	assert.False(t, withSince.Matches(ListInput{}))
+	assert.False(t, withoutFilters.Matches(ListInput{UpdatedSince: &since}))
+
+	mine := NewListCursor(ListInput{UserID: "user-42"}, Order{ID: 1})
+	assert.True(t, mine.Matches(ListInput{UserID: "user-42"}))
+	assert.False(t, mine.Matches(ListInput{UserID: "user-7"}))
+	assert.False(t, mine.Matches(ListInput{}))
+}
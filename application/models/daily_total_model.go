@@ -0,0 +1,35 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// MaxDailyTotalsDays caps the date range of a daily totals query
+const MaxDailyTotalsDays = 366
+
+// DailyTotalsDateLayout is the format of the from/to parameters and of each returned date
+const DailyTotalsDateLayout = "2006-01-02"
+
+type DailyTotalsInput struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"` // inclusive
+}
+
+type DailyOrderTotal struct {
+	Date  time.Time `json:"date"`
+	Count int       `json:"count"`
+	Total float64   `json:"total"`
+}
+
+func (d DailyOrderTotal) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		Date  string          `json:"date"`
+		Count int             `json:"count"`
+		Total json.RawMessage `json:"total"`
+	}{
+		Date:  d.Date.Format(DailyTotalsDateLayout),
+		Count: d.Count,
+		Total: marshalMoney(d.Total),
+	})
+}
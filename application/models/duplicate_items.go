@@ -0,0 +1,18 @@
+package models
+
+// DuplicateItemStrategy selects how an order with several items of the same product_name is created
+type DuplicateItemStrategy string
+
+const (
+	// DuplicateItemsAllow keeps every item as sent
+	DuplicateItemsAllow DuplicateItemStrategy = "allow"
+	// DuplicateItemsMerge combines items with the same product name and price into one item
+	DuplicateItemsMerge DuplicateItemStrategy = "merge"
+	// DuplicateItemsReject fails the order, for clients that consider duplicates a bug
+	DuplicateItemsReject DuplicateItemStrategy = "reject"
+)
+
+// IsValid reports whether s is one of the known duplicate item strategies
+func (s DuplicateItemStrategy) IsValid() bool {
+	return s == DuplicateItemsAllow || s == DuplicateItemsMerge || s == DuplicateItemsReject
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// OrderPrecondition restricts an order update to the version of the order the caller read.
+// Zero fields are not checked.
+type OrderPrecondition struct {
+	// UpdatedAt is the updated_at the order must still have
+	UpdatedAt time.Time
+	// Status is the status the order must still have, so a checked status transition cannot race
+	Status Status
+}
+
+// ETag returns a strong ETag for the order and its items. It changes whenever the order or one
+// of its items is updated, since every write bumps their updated_at. Timestamps are cut to the
+// microseconds Postgres stores so an order fresh from a write has the same tag as when read.
+func (o OrderWithItems) ETag() string {
+	hash := sha256.New()
+	writeVersion := func(id int, updatedAt time.Time) {
+		var buf [16]byte
+		binary.BigEndian.PutUint64(buf[:8], uint64(id))
+		binary.BigEndian.PutUint64(buf[8:], uint64(updatedAt.Truncate(time.Microsecond).UnixMicro()))
+		hash.Write(buf[:])
+	}
+
+	writeVersion(o.ID, o.UpdatedAt)
+	for _, item := range o.Items {
+		writeVersion(item.ID, item.UpdatedAt)
+	}
+	return `"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// ETagMatches reports whether an If-Match header value names etag. Weak tags never match, as
+// If-Match requires strong comparison, and "*" matches any current version.
+func ETagMatches(ifMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || candidate == etag {
+			return true
+		}
+	}
+	return false
+}
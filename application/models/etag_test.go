@@ -0,0 +1,38 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func etagTestOrder(updatedAt time.Time) OrderWithItems {
+	return OrderWithItems{
+		Order: Order{ID: 42, Status: StatusPending, UpdatedAt: updatedAt},
+		Items: []OrderItem{{ID: 7, OrderID: 42, ProductName: "Product 1", Quantity: 1, Price: 5, UpdatedAt: updatedAt}},
+	}
+}
+
+func TestOrderWithItems_ETag(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 12, 0, 0, 123456789, time.UTC)
+	order := etagTestOrder(updatedAt)
+
+	itemChanged := etagTestOrder(updatedAt)
+	itemChanged.Items[0].UpdatedAt = updatedAt.Add(time.Second)
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, order.ETag())
+	assert.Equal(t, order.ETag(), etagTestOrder(updatedAt.Truncate(time.Microsecond)).ETag())
+	assert.NotEqual(t, order.ETag(), etagTestOrder(updatedAt.Add(time.Second)).ETag())
+	assert.NotEqual(t, order.ETag(), itemChanged.ETag())
+}
+
+func TestETagMatches(t *testing.T) {
+	etag := `"abc"`
+
+	assert.True(t, ETagMatches(etag, etag))
+	assert.True(t, ETagMatches(`"old", `+etag, etag))
+	assert.True(t, ETagMatches("*", etag))
+	assert.False(t, ETagMatches(`"old"`, etag))
+	assert.False(t, ETagMatches("W/"+etag, etag))
+}
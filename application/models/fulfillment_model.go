@@ -0,0 +1,83 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// ShipItem is a quantity of one order item shipped in a fulfillment
+type ShipItem struct {
+	ItemID   int `json:"item_id"`
+	Quantity int `json:"quantity"`
+}
+
+type ShipOrderInput struct {
+	OrderID int        `json:"-"`
+	Items   []ShipItem `json:"items"`
+}
+
+// Fulfillment records one shipment against an order
+type Fulfillment struct {
+	ID        int        `json:"id"`
+	OrderID   int        `json:"order_id"`
+	Items     []ShipItem `json:"items"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type fulfillmentAlias Fulfillment
+
+// MarshalJSON omits order_id with the uuid ID strategy, as it would expose the serial order ID
+func (f Fulfillment) MarshalJSON() ([]byte, error) {
+	var orderID any = f.OrderID
+	if idStrategy == IDStrategyUUID {
+		orderID = nil
+	}
+	return json.Marshal(struct {
+		fulfillmentAlias
+		OrderID any `json:"order_id,omitempty"`
+	}{
+		fulfillmentAlias: fulfillmentAlias(f),
+		OrderID:          orderID,
+	})
+}
+
+type ShipOrderResult struct {
+	Order       OrderWithItems `json:"order"`
+	Fulfillment Fulfillment    `json:"fulfillment"`
+}
+
+// IsFullyShipped reports whether every unit of the item has shipped
+func (i OrderItem) IsFullyShipped() bool {
+	return i.ShippedQuantity >= i.Quantity
+}
+
+// DeriveOrderStatus returns the order status implied by its items: completed once every item has
+// fully shipped and partially_shipped while only some have. Cancelled orders keep their status, and
+// so do orders with nothing shipped unless they were completed or partially_shipped, which fall
+// back to processing.
+func DeriveOrderStatus(current Status, items []OrderItem) Status {
+	if current == StatusCancelled || len(items) == 0 {
+		return current
+	}
+
+	fullyShipped, started := 0, 0
+	for _, item := range items {
+		if item.IsFullyShipped() {
+			fullyShipped++
+		}
+		if item.ShippedQuantity > 0 {
+			started++
+		}
+	}
+
+	switch {
+	case fullyShipped == len(items):
+		return StatusCompleted
+	case started > 0:
+		return StatusPartiallyShipped
+	case current == StatusCompleted, current == StatusPartiallyShipped:
+		return StatusProcessing
+	default:
+		return current
+	}
+}
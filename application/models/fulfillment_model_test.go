@@ -0,0 +1,35 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeriveOrderStatus(t *testing.T) {
+	item := func(status ItemStatus, quantity, shipped int) OrderItem {
+		return OrderItem{Status: status, Quantity: quantity, ShippedQuantity: shipped}
+	}
+
+	tests := []struct {
+		name     string
+		current  Status
+		items    []OrderItem
+		expected Status
+	}{
+		{name: "all quantities shipped completes", current: StatusPartiallyShipped, items: []OrderItem{item(ItemStatusShipped, 3, 3), item(ItemStatusShipped, 1, 1)}, expected: StatusCompleted},
+		{name: "shipped status without quantities is not shipped", current: StatusProcessing, items: []OrderItem{item(ItemStatusShipped, 2, 0)}, expected: StatusProcessing},
+		{name: "some items shipped is partially shipped", current: StatusPending, items: []OrderItem{item(ItemStatusShipped, 1, 1), item(ItemStatusBackordered, 1, 0)}, expected: StatusPartiallyShipped},
+		{name: "some units shipped is partially shipped", current: StatusProcessing, items: []OrderItem{item(ItemStatusPending, 3, 1)}, expected: StatusPartiallyShipped},
+		{name: "nothing shipped keeps status", current: StatusPending, items: []OrderItem{item(ItemStatusPending, 1, 0), item(ItemStatusBackordered, 1, 0)}, expected: StatusPending},
+		{name: "completed reopens when nothing shipped", current: StatusCompleted, items: []OrderItem{item(ItemStatusBackordered, 1, 0)}, expected: StatusProcessing},
+		{name: "cancelled is final", current: StatusCancelled, items: []OrderItem{item(ItemStatusShipped, 1, 1)}, expected: StatusCancelled},
+		{name: "no items keeps status", current: StatusProcessing, items: nil, expected: StatusProcessing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, DeriveOrderStatus(tt.current, tt.items))
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// MergePatchContentType is the media type of an RFC 7386 JSON Merge Patch document
+const MergePatchContentType = "application/merge-patch+json"
+
+// ApplyMergePatch applies an RFC 7386 JSON Merge Patch to a JSON document.
+// Members set to null are removed, objects are merged recursively and any other value replaces the target.
+func ApplyMergePatch(document []byte, patch []byte) ([]byte, error) {
+	var target any
+	if len(bytes.TrimSpace(document)) > 0 {
+		if err := decodeJSONNumber(document, &target); err != nil {
+			return nil, err
+		}
+	}
+
+	var patchValue any
+	if err := decodeJSONNumber(patch, &patchValue); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(mergePatch(target, patchValue))
+}
+
+func mergePatch(target any, patch any) any {
+	patchObject, ok := patch.(map[string]any)
+	if !ok {
+		return patch
+	}
+
+	targetObject, ok := target.(map[string]any)
+	if !ok {
+		targetObject = make(map[string]any, len(patchObject))
+	}
+
+	for key, value := range patchObject {
+		if value == nil {
+			delete(targetObject, key)
+			continue
+		}
+		targetObject[key] = mergePatch(targetObject[key], value)
+	}
+	return targetObject
+}
+
+// decodeJSONNumber decodes with UseNumber so numbers round-trip without float conversion
+func decodeJSONNumber(data []byte, v any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	return decoder.Decode(v)
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestApplyMergePatch_SetField(t *testing.T) {
+	// Arrange
+	document := []byte(`{"customer_name":"John Doe","status":"pending"}`)
+
+	// Act
+	merged, err := ApplyMergePatch(document, []byte(`{"status":"processing"}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"customer_name":"John Doe","status":"processing"}`, string(merged))
+}
+
+func TestApplyMergePatch_UnsetField(t *testing.T) {
+	// Arrange
+	document := []byte(`{"customer_name":"John Doe","status":"pending"}`)
+
+	// Act
+	merged, err := ApplyMergePatch(document, []byte(`{"customer_name":null}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"pending"}`, string(merged))
+}
+
+func TestApplyMergePatch_NoOp(t *testing.T) {
+	// Arrange
+	document := []byte(`{"customer_name":"John Doe","total_amount":100.50}`)
+
+	// Act
+	merged, err := ApplyMergePatch(document, []byte(`{}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, string(document), string(merged))
+}
+
+func TestApplyMergePatch_NestedAndReplace(t *testing.T) {
+	// Arrange
+	document := []byte(`{"a":{"b":"c","d":"e"},"list":[1,2]}`)
+
+	// Act
+	merged, err := ApplyMergePatch(document, []byte(`{"a":{"d":null,"f":"g"},"list":[3]}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"a":{"b":"c","f":"g"},"list":[3]}`, string(merged))
+}
+
+func TestApplyMergePatch_InvalidPatch(t *testing.T) {
+	// Act
+	_, err := ApplyMergePatch([]byte(`{}`), []byte(`{"status":`))
+
+	// Assert
+	assert.Error(t, err)
+}
@@ -0,0 +1,98 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+)
+
+type MoneyFormat string
+
+const (
+	MoneyFormatNumber MoneyFormat = "number"
+	MoneyFormatString MoneyFormat = "string"
+)
+
+// moneyFormat controls how monetary fields are written to JSON
+var moneyFormat = MoneyFormatNumber
+
+// SetMoneyFormat configures monetary fields to be serialized as a JSON number or string.
+// Unknown values fall back to number.
+func SetMoneyFormat(format MoneyFormat) {
+	switch format {
+	case MoneyFormatString:
+		moneyFormat = MoneyFormatString
+	default:
+		moneyFormat = MoneyFormatNumber
+	}
+}
+
+// marshalMoney formats a monetary value with exactly 2 decimal places
+func marshalMoney(value float64) json.RawMessage {
+	formatted := strconv.FormatFloat(value, 'f', 2, 64)
+	if moneyFormat == MoneyFormatString {
+		return json.RawMessage(strconv.Quote(formatted))
+	}
+	return json.RawMessage(formatted)
+}
+
+// orderAlias and orderItemAlias drop the custom marshalers to avoid recursion
+type orderAlias Order
+type orderItemAlias OrderItem
+
+// apiID returns the identifier written as the order's "id" under the configured ID strategy
+func (o Order) apiID() any {
+	if idStrategy == IDStrategyUUID {
+		return o.PublicID
+	}
+	return o.ID
+}
+
+func (o Order) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		orderAlias
+		ID          any             `json:"id"`
+		TotalAmount json.RawMessage `json:"total_amount"`
+	}{
+		orderAlias:  orderAlias(o),
+		ID:          o.apiID(),
+		TotalAmount: marshalMoney(o.TotalAmount),
+	})
+}
+
+// MarshalJSON omits order_id with the uuid ID strategy, as it would expose the serial order ID
+func (i OrderItem) MarshalJSON() ([]byte, error) {
+	var orderID any = i.OrderID
+	if idStrategy == IDStrategyUUID {
+		orderID = nil
+	}
+	return json.Marshal(struct {
+		orderItemAlias
+		OrderID any             `json:"order_id,omitempty"`
+		Price   json.RawMessage `json:"price"`
+	}{
+		orderItemAlias: orderItemAlias(i),
+		OrderID:        orderID,
+		Price:          marshalMoney(i.Price),
+	})
+}
+
+// MarshalJSON is required because the promoted Order.MarshalJSON would otherwise drop Items
+func (o OrderWithItems) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		orderAlias
+		ID          any             `json:"id"`
+		TotalAmount json.RawMessage `json:"total_amount"`
+		Items       []OrderItem     `json:"items"`
+		MoreItems   bool            `json:"more_items,omitempty"`
+		ItemCount   int             `json:"item_count,omitempty"`
+		Warnings    []string        `json:"warnings,omitempty"`
+	}{
+		orderAlias:  orderAlias(o.Order),
+		ID:          o.apiID(),
+		TotalAmount: marshalMoney(o.TotalAmount),
+		Items:       o.Items,
+		MoreItems:   o.MoreItems,
+		ItemCount:   o.ItemCount,
+		Warnings:    o.Warnings,
+	})
+}
@@ -0,0 +1,51 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrder_MarshalJSON_RoundsTotalAmount(t *testing.T) {
+	// 0.1 + 0.2 is represented as 0.30000000000000004
+	order := Order{ID: 1, TotalAmount: 0.1 + 0.2, Status: StatusPending}
+
+	data, err := json.Marshal(order)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"total_amount":0.30`)
+	assert.NotContains(t, string(data), "0.30000000000000004")
+}
+
+func TestOrderWithItems_MarshalJSON_KeepsItems(t *testing.T) {
+	order := OrderWithItems{
+		Order: Order{ID: 1, TotalAmount: 100.50000000000001},
+		Items: []OrderItem{
+			{ID: 1, OrderID: 1, ProductName: "Product 1", Quantity: 3, Price: 33.5},
+		},
+	}
+
+	data, err := json.Marshal(order)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"total_amount":100.50`)
+	assert.Contains(t, string(data), `"price":33.50`)
+	assert.Contains(t, string(data), `"product_name":"Product 1"`)
+}
+
+func TestOrder_MarshalJSON_StringFormat(t *testing.T) {
+	SetMoneyFormat(MoneyFormatString)
+	defer SetMoneyFormat(MoneyFormatNumber)
+
+	order := OrderWithItems{
+		Order: Order{ID: 1, TotalAmount: 19.99 * 3},
+		Items: []OrderItem{{Price: 1.1 * 1.1}},
+	}
+
+	data, err := json.Marshal(order)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"total_amount":"59.97"`)
+	assert.Contains(t, string(data), `"price":"1.21"`)
+}
@@ -0,0 +1,49 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+const (
+	// MaxNoteLength is the most characters a note's text may have
+	MaxNoteLength = 2000
+	// MaxNoteAuthorLength is the most characters a note's author may have
+	MaxNoteAuthorLength = 100
+	// DefaultNoteAuthor is recorded when a note is added without an author
+	DefaultNoteAuthor = "anonymous"
+)
+
+// OrderNote is a free-text comment attached to an order
+type OrderNote struct {
+	ID        int       `json:"id"`
+	OrderID   int       `json:"order_id"`
+	Author    string    `json:"author"`
+	Text      string    `json:"text"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type orderNoteAlias OrderNote
+
+// MarshalJSON omits order_id with the uuid ID strategy, as it would expose the serial order ID
+func (n OrderNote) MarshalJSON() ([]byte, error) {
+	var orderID any = n.OrderID
+	if idStrategy == IDStrategyUUID {
+		orderID = nil
+	}
+	return json.Marshal(struct {
+		orderNoteAlias
+		OrderID any `json:"order_id,omitempty"`
+	}{
+		orderNoteAlias: orderNoteAlias(n),
+		OrderID:        orderID,
+	})
+}
+
+// CreateNoteInput adds a note to an order. Author is the authenticated user when there is one and
+// is otherwise taken from the request body.
+type CreateNoteInput struct {
+	OrderID int    `json:"-"`
+	Author  string `json:"author"`
+	Text    string `json:"text"`
+}
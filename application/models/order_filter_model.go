@@ -0,0 +1,10 @@
+package models
+
+import "time"
+
+// OrderFilter narrows a scan over orders; zero fields match everything and set fields are combined with AND
+type OrderFilter struct {
+	Status        Status     `json:"status,omitempty"`
+	CreatedAfter  *time.Time `json:"created_after,omitempty"`
+	CreatedBefore *time.Time `json:"created_before,omitempty"`
+}
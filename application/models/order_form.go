@@ -0,0 +1,105 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ParseCreateOrderForm builds a CreateOrderInput from an application/x-www-form-urlencoded body.
+// Items are sent as indexed fields, e.g. items[0][product_name]=Widget&items[0][quantity]=2.
+func ParseCreateOrderForm(values url.Values) (CreateOrderInput, error) {
+	var input CreateOrderInput
+	items := map[int]*OrderItem{}
+
+	for key, vals := range values {
+		value := ""
+		if len(vals) > 0 {
+			value = vals[len(vals)-1]
+		}
+
+		switch key {
+		case "customer_name":
+			input.CustomerName = value
+			continue
+		case "status":
+			input.Status = Status(value)
+			continue
+		case "total_amount":
+			if value == "" {
+				continue
+			}
+			total, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return CreateOrderInput{}, fmt.Errorf("invalid total_amount %q", value)
+			}
+			input.TotalAmount = total
+			continue
+		}
+
+		index, field, ok := parseItemFormKey(key)
+		if !ok {
+			continue
+		}
+		item, exists := items[index]
+		if !exists {
+			item = &OrderItem{}
+			items[index] = item
+		}
+		if err := setItemFormField(item, field, value); err != nil {
+			return CreateOrderInput{}, fmt.Errorf("items[%d][%s]: %w", index, field, err)
+		}
+	}
+
+	indexes := make([]int, 0, len(items))
+	for index := range items {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		input.Items = append(input.Items, *items[index])
+	}
+
+	return input, nil
+}
+
+// parseItemFormKey splits "items[3][price]" into 3 and "price"
+func parseItemFormKey(key string) (int, string, bool) {
+	rest, ok := strings.CutPrefix(key, "items[")
+	if !ok {
+		return 0, "", false
+	}
+	indexPart, field, ok := strings.Cut(rest, "][")
+	if !ok || !strings.HasSuffix(field, "]") {
+		return 0, "", false
+	}
+	index, err := strconv.Atoi(indexPart)
+	if err != nil || index < 0 {
+		return 0, "", false
+	}
+	return index, strings.TrimSuffix(field, "]"), true
+}
+
+func setItemFormField(item *OrderItem, field, value string) error {
+	switch field {
+	case "product_name":
+		item.ProductName = value
+	case "quantity":
+		quantity, err := strconv.Atoi(value)
+		if err != nil {
+			return fmt.Errorf("invalid quantity %q", value)
+		}
+		item.Quantity = quantity
+	case "price":
+		price, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return fmt.Errorf("invalid price %q", value)
+		}
+		item.Price = price
+	default:
+		return fmt.Errorf("unknown item field")
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCreateOrderForm_Items(t *testing.T) {
+	// Arrange
+	values := url.Values{
+		"customer_name":          {"John Doe"},
+		"status":                 {"pending"},
+		"total_amount":           {"31.5"},
+		"items[1][product_name]": {"Gadget"},
+		"items[1][quantity]":     {"1"},
+		"items[1][price]":        {"10.5"},
+		"items[0][product_name]": {"Widget"},
+		"items[0][quantity]":     {"2"},
+		"items[0][price]":        {"10.5"},
+		"ignored":                {"value"},
+	}
+
+	// Act
+	input, err := ParseCreateOrderForm(values)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", input.CustomerName)
+	assert.Equal(t, StatusPending, input.Status)
+	assert.Equal(t, 31.5, input.TotalAmount)
+	assert.Equal(t, []OrderItem{
+		{ProductName: "Widget", Quantity: 2, Price: 10.5},
+		{ProductName: "Gadget", Quantity: 1, Price: 10.5},
+	}, input.Items)
+}
+
+func TestParseCreateOrderForm_NoItems(t *testing.T) {
+	// Act
+	input, err := ParseCreateOrderForm(url.Values{"customer_name": {"John Doe"}})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Nil(t, input.Items)
+}
+
+func TestParseCreateOrderForm_InvalidValues(t *testing.T) {
+	tests := map[string]url.Values{
+		"quantity":      {"items[0][quantity]": {"two"}},
+		"price":         {"items[0][price]": {"cheap"}},
+		"total_amount":  {"total_amount": {"lots"}},
+		"unknown field": {"items[0][colour]": {"red"}},
+	}
+
+	for name, values := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Act
+			_, err := ParseCreateOrderForm(values)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package models
+
+import (
+	"github.com/google/uuid"
+)
+
+// IDStrategy selects which identifier the API exposes for orders
+type IDStrategy string
+
+const (
+	// IDStrategySerial exposes the auto-increment primary key
+	IDStrategySerial IDStrategy = "serial"
+	// IDStrategyUUID exposes the random public_id, so order IDs cannot be guessed or enumerated
+	IDStrategyUUID IDStrategy = "uuid"
+)
+
+// IsValid reports whether s is one of the known ID strategies
+func (s IDStrategy) IsValid() bool {
+	return s == IDStrategySerial || s == IDStrategyUUID
+}
+
+// idStrategy is the strategy applied to JSON output and order path parameters
+var idStrategy = IDStrategySerial
+
+// SetIDStrategy configures the order ID exposed by the API. Unknown values fall back to serial.
+func SetIDStrategy(strategy IDStrategy) {
+	if !strategy.IsValid() {
+		strategy = IDStrategySerial
+	}
+	idStrategy = strategy
+}
+
+// CurrentIDStrategy returns the configured order ID strategy
+func CurrentIDStrategy() IDStrategy {
+	return idStrategy
+}
+
+// NewOrderPublicID returns a random public ID for a new order
+func NewOrderPublicID() string {
+	return uuid.NewString()
+}
+
+// ParseOrderPublicID returns the canonical form of a public order ID, or false when s is not a UUID
+func ParseOrderPublicID(s string) (string, bool) {
+	id, err := uuid.Parse(s)
+	if err != nil {
+		return "", false
+	}
+	return id.String(), true
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testPublicID = "3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b"
+
+func TestOrderWithItems_MarshalJSON_SerialID(t *testing.T) {
+	order := OrderWithItems{
+		Order: Order{ID: 42, PublicID: testPublicID},
+		Items: []OrderItem{{ID: 7, OrderID: 42}},
+	}
+
+	data, err := json.Marshal(order)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"id":42`)
+	assert.Contains(t, string(data), `"order_id":42`)
+	assert.NotContains(t, string(data), testPublicID)
+}
+
+func TestOrderWithItems_MarshalJSON_UUIDStrategy(t *testing.T) {
+	SetIDStrategy(IDStrategyUUID)
+	defer SetIDStrategy(IDStrategySerial)
+	order := OrderWithItems{
+		Order: Order{ID: 42, PublicID: testPublicID},
+		Items: []OrderItem{{ID: 7, OrderID: 42}},
+	}
+
+	data, err := json.Marshal(order)
+
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"id":"`+testPublicID+`"`)
+	assert.NotContains(t, string(data), `"order_id"`)
+	assert.NotContains(t, string(data), `"id":42`)
+}
+
+func TestFulfillmentAndNote_MarshalJSON_OrderID(t *testing.T) {
+	fulfillment := Fulfillment{ID: 3, OrderID: 42, Items: []ShipItem{{ItemID: 7, Quantity: 1}}}
+	note := OrderNote{ID: 5, OrderID: 42, Author: "support", Text: "hi"}
+
+	serialFulfillment, err := json.Marshal(fulfillment)
+	assert.NoError(t, err)
+	serialNote, err := json.Marshal(note)
+	assert.NoError(t, err)
+
+	SetIDStrategy(IDStrategyUUID)
+	defer SetIDStrategy(IDStrategySerial)
+	uuidFulfillment, err := json.Marshal(fulfillment)
+	assert.NoError(t, err)
+	uuidNote, err := json.Marshal(note)
+	assert.NoError(t, err)
+
+	assert.Contains(t, string(serialFulfillment), `"order_id":42`)
+	assert.Contains(t, string(serialNote), `"order_id":42`)
+	assert.NotContains(t, string(uuidFulfillment), `"order_id"`)
+	assert.NotContains(t, string(uuidNote), `"order_id"`)
+	assert.Contains(t, string(uuidNote), `"id":5`)
+}
+
+func TestSetIDStrategy_UnknownFallsBackToSerial(t *testing.T) {
+	SetIDStrategy(IDStrategyUUID)
+	SetIDStrategy("random")
+
+	assert.Equal(t, IDStrategySerial, CurrentIDStrategy())
+}
+
+func TestParseOrderPublicID(t *testing.T) {
+	canonical, ok := ParseOrderPublicID("3F2B8C1E-9A4D-4E5F-8B6A-1C2D3E4F5A6B")
+	assert.True(t, ok)
+	assert.Equal(t, testPublicID, canonical)
+
+	for _, ref := range []string{"", "42", "ORD-20250601-K7QX2M", "3f2b8c1e-9a4d"} {
+		_, ok := ParseOrderPublicID(ref)
+		assert.False(t, ok, ref)
+	}
+}
@@ -0,0 +1,52 @@
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+)
+
+// ErrItemsNotArray is returned when items is sent as a single object and lenient parsing is off
+var ErrItemsNotArray = errors.New("items must be an array, e.g. \"items\": [{...}]")
+
+// acceptSingleItemObject wraps a single "items" object into a one element slice when enabled
+var acceptSingleItemObject = false
+
+// SetAcceptSingleItemObject configures whether "items": {...} is accepted as a single item
+func SetAcceptSingleItemObject(accept bool) {
+	acceptSingleItemObject = accept
+}
+
+func (in *CreateOrderInput) UnmarshalJSON(data []byte) error {
+	type createOrderInputAlias CreateOrderInput
+	var raw struct {
+		createOrderInputAlias
+		Items json.RawMessage `json:"items"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	*in = CreateOrderInput(raw.createOrderInputAlias)
+
+	items := bytes.TrimSpace(raw.Items)
+	switch {
+	case len(items) == 0 || bytes.Equal(items, []byte("null")):
+		in.Items = nil
+	case items[0] == '{':
+		if !acceptSingleItemObject {
+			return ErrItemsNotArray
+		}
+		var item OrderItem
+		if err := json.Unmarshal(items, &item); err != nil {
+			return err
+		}
+		in.Items = []OrderItem{item}
+	default:
+		if err := json.Unmarshal(items, &in.Items); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
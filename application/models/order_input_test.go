@@ -0,0 +1,50 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOrderInput_UnmarshalJSON_Array(t *testing.T) {
+	var input CreateOrderInput
+	body := `{"customer_name":"John Doe","items":[{"product_name":"Widget","quantity":2,"price":10.5}]}`
+
+	err := json.Unmarshal([]byte(body), &input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John Doe", input.CustomerName)
+	assert.Equal(t, []OrderItem{{ProductName: "Widget", Quantity: 2, Price: 10.5}}, input.Items)
+}
+
+func TestCreateOrderInput_UnmarshalJSON_SingleObjectRejected(t *testing.T) {
+	var input CreateOrderInput
+	body := `{"customer_name":"John Doe","items":{"product_name":"Widget","quantity":2,"price":10.5}}`
+
+	err := json.Unmarshal([]byte(body), &input)
+
+	assert.ErrorIs(t, err, ErrItemsNotArray)
+}
+
+func TestCreateOrderInput_UnmarshalJSON_SingleObjectAccepted(t *testing.T) {
+	SetAcceptSingleItemObject(true)
+	defer SetAcceptSingleItemObject(false)
+
+	var input CreateOrderInput
+	body := `{"customer_name":"John Doe","items":{"product_name":"Widget","quantity":2,"price":10.5}}`
+
+	err := json.Unmarshal([]byte(body), &input)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []OrderItem{{ProductName: "Widget", Quantity: 2, Price: 10.5}}, input.Items)
+}
+
+func TestCreateOrderInput_UnmarshalJSON_MissingItems(t *testing.T) {
+	var input CreateOrderInput
+
+	err := json.Unmarshal([]byte(`{"customer_name":"John Doe"}`), &input)
+
+	assert.NoError(t, err)
+	assert.Nil(t, input.Items)
+}
@@ -11,42 +11,101 @@ const (
 	StatusProcessing Status = "processing"
 	StatusCompleted  Status = "completed"
 	StatusCancelled  Status = "cancelled"
+	// StatusPartiallyShipped is set while some, but not all, item quantities have shipped
+	StatusPartiallyShipped Status = "partially_shipped"
 )
 
+// IsValid reports whether s is one of the known order statuses
+func (s Status) IsValid() bool {
+	switch s {
+	case StatusPending, StatusProcessing, StatusCompleted, StatusCancelled, StatusPartiallyShipped:
+		return true
+	default:
+		return false
+	}
+}
+
+// ItemStatus tracks fulfillment of a single order item
+type ItemStatus string
+
+const (
+	ItemStatusPending     ItemStatus = "pending"
+	ItemStatusShipped     ItemStatus = "shipped"
+	ItemStatusBackordered ItemStatus = "backordered"
+)
+
+// IsValid reports whether s is one of the known item statuses
+func (s ItemStatus) IsValid() bool {
+	switch s {
+	case ItemStatusPending, ItemStatusShipped, ItemStatusBackordered:
+		return true
+	default:
+		return false
+	}
+}
+
 type Order struct {
 	ID           int       `json:"id"`
+	PublicID     string    `json:"-"` // Random UUID, written as "id" instead of ID with the uuid ID strategy
+	OrderNumber  string    `json:"order_number"`
 	CustomerName string    `json:"customer_name"`
 	TotalAmount  float64   `json:"total_amount"`
 	Status       Status    `json:"status"`
 	CreatedAt    time.Time `json:"created_at"`
 	UpdatedAt    time.Time `json:"updated_at"`
+	UserID       string    `json:"user_id,omitempty"` // Authenticated user who created the order, empty when created without auth
 }
 
 type CreateOrderInput struct {
 	CustomerName string      `json:"customer_name"`
+	UserID       string      `json:"-"` // Owner of the order, taken from the request context when empty
+	OwnerOnly    bool        `json:"-"` // An upsert may only replace an existing order owned by UserID
 	Status       Status      `json:"status"`
+	TotalAmount  float64     `json:"total_amount,omitempty"` // Optional client-computed total, checked when validation is enabled
 	Items        []OrderItem `json:"items"`
 }
 
+// PatchOrderInput is a partial update sent as application/json to PATCH /orders/:id.
+// Nil fields were not sent and are left unchanged.
+type PatchOrderInput struct {
+	CustomerName *string `json:"customer_name"`
+	Status       *Status `json:"status"`
+	IfMatch      string  `json:"-"` // If-Match header the order's ETag must match, empty when not sent
+}
+
 type UpdateOrderInput struct {
 	ID        int       `json:"id"`
 	Status    Status    `json:"status"`
 	UpdatedAt time.Time `json:"updated_at"`
+	IfMatch   string    `json:"-"` // If-Match header the order's ETag must match, empty when not sent
+}
+
+type UpdateItemStatusInput struct {
+	OrderID int        `json:"-"`
+	ItemID  int        `json:"-"`
+	Status  ItemStatus `json:"status"`
 }
 
 type OrderItem struct {
-	ID          int       `json:"id,omitempty"`
-	OrderID     int       `json:"order_id"`
-	ProductName string    `json:"product_name"`
-	Quantity    int       `json:"quantity"`
-	Price       float64   `json:"price"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID          int        `json:"id,omitempty"`
+	OrderID     int        `json:"order_id"`
+	ProductName string     `json:"product_name"`
+	Quantity    int        `json:"quantity"`
+	Price       float64    `json:"price"`
+	Status      ItemStatus `json:"status"`
+	// ShippedQuantity counts units already shipped through fulfillments
+	ShippedQuantity int       `json:"shipped_quantity"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
 }
 
 type OrderWithItems struct {
 	Order
 	Items []OrderItem `json:"items"`
+	// MoreItems and ItemCount are set when a list response returns only the first items of the order
+	MoreItems bool     `json:"more_items,omitempty"`
+	ItemCount int      `json:"item_count,omitempty"`
+	Warnings  []string `json:"warnings,omitempty"` // Set when a partial result is returned instead of an error
 }
 
 type ListPaginatedOrders = ListPaginated[OrderWithItems]
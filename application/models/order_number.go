@@ -0,0 +1,30 @@
+package models
+
+import (
+	"crypto/rand"
+	"regexp"
+	"time"
+)
+
+// orderNumberAlphabet omits 0/O and 1/I so numbers can be read out over the phone
+const orderNumberAlphabet = "ABCDEFGHJKLMNPQRSTUVWXYZ23456789"
+
+// orderNumberPattern matches numbers produced by NewOrderNumber, e.g. ORD-20250601-K7QX2M
+var orderNumberPattern = regexp.MustCompile(`^ORD-\d{8}-[A-HJ-NP-Z2-9]{6}$`)
+
+// NewOrderNumber returns a human-friendly order number for an order created at t
+func NewOrderNumber(t time.Time) string {
+	suffix := make([]byte, 6)
+	if _, err := rand.Read(suffix); err != nil {
+		panic(err)
+	}
+	for i, b := range suffix {
+		suffix[i] = orderNumberAlphabet[int(b)%len(orderNumberAlphabet)]
+	}
+	return "ORD-" + t.UTC().Format("20060102") + "-" + string(suffix)
+}
+
+// IsOrderNumber reports whether s has the format of an order number
+func IsOrderNumber(s string) bool {
+	return orderNumberPattern.MatchString(s)
+}
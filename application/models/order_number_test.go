@@ -0,0 +1,29 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOrderNumber(t *testing.T) {
+	// Arrange
+	createdAt := time.Date(2025, 6, 1, 23, 30, 0, 0, time.UTC)
+
+	// Act
+	number := NewOrderNumber(createdAt)
+
+	// Assert
+	assert.Regexp(t, `^ORD-20250601-[A-Z2-9]{6}$`, number)
+	assert.True(t, IsOrderNumber(number))
+	assert.NotEqual(t, number, NewOrderNumber(createdAt))
+}
+
+func TestIsOrderNumber(t *testing.T) {
+	assert.True(t, IsOrderNumber("ORD-20250601-K7QX2M"))
+	assert.False(t, IsOrderNumber("ORD-20250601-k7qx2m"))
+	assert.False(t, IsOrderNumber("ORD-2025061-K7QX2M"))
+	assert.False(t, IsOrderNumber("42"))
+	assert.False(t, IsOrderNumber("garbage"))
+}
@@ -1,10 +1,26 @@
 package models
 
+import "time"
+
 type ListInput struct {
 	Page int `json:"page"`
 	Size int `json:"size"`
+	// UpdatedSince switches to incremental sync: only orders updated after it, oldest first
+	UpdatedSince *time.Time `json:"updated_since,omitempty"`
+	// After continues from a cursor instead of an offset; Page is ignored when set
+	After *ListCursor `json:"-"`
+	// UserID lists only the orders owned by that user, empty lists every order
+	UserID string `json:"-"`
+	// Statuses lists only orders in one of these statuses, empty lists every status
+	Statuses []Status `json:"-"`
 }
 
+// Bounds of the recent orders limit
+const (
+	DefaultRecentOrdersLimit = 10
+	MaxRecentOrdersLimit     = 100
+)
+
 // make generic type with `Data` field as a slice of any type
 type ListPaginated[T any] struct {
 	Data       []T `json:"data"`
@@ -12,4 +28,6 @@ type ListPaginated[T any] struct {
 	Page       int `json:"page"`
 	Size       int `json:"size"`
 	TotalPages int `json:"total_pages"`
+	// NextCursor continues after the last returned item, set when the page is full
+	NextCursor string `json:"next_cursor,omitempty"`
 }
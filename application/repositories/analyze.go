@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+)
+
+// analyzeQuery refreshes the planner statistics of the tables touched by bulk operations
+const analyzeQuery = "ANALYZE orders, order_items"
+
+// analyzeTimeout bounds a background ANALYZE so a stuck run cannot block the next one forever
+const analyzeTimeout = 5 * time.Minute
+
+// bulkAnalyzer runs ANALYZE in the background after bulk writes, at most once per interval and
+// never more than one at a time
+type bulkAnalyzer struct {
+	mu       sync.Mutex
+	enabled  bool
+	minRows  int64
+	interval time.Duration
+	lastRun  time.Time
+	running  bool
+	now      func() time.Time
+}
+
+var analyzer = &bulkAnalyzer{now: time.Now}
+
+// SetAnalyzeAfterBulk enables a background ANALYZE of orders and order_items after bulk operations
+// affecting at least minRows rows. Runs are at least interval apart; skipped runs are not queued.
+func SetAnalyzeAfterBulk(enabled bool, minRows int64, interval time.Duration) {
+	analyzer.mu.Lock()
+	defer analyzer.mu.Unlock()
+	analyzer.enabled = enabled
+	analyzer.minRows = minRows
+	analyzer.interval = interval
+}
+
+// claim reports whether a run for a bulk operation affecting rows rows should start now, and if
+// so marks it as running
+func (a *bulkAnalyzer) claim(rows int64) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.enabled || rows == 0 || rows < a.minRows || a.running {
+		return false
+	}
+	now := a.now()
+	if !a.lastRun.IsZero() && now.Sub(a.lastRun) < a.interval {
+		return false
+	}
+	a.lastRun = now
+	a.running = true
+	return true
+}
+
+func (a *bulkAnalyzer) done() {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.running = false
+}
+
+// analyzeAfterBulk starts a background ANALYZE when the analyzer allows it. The run outlives ctx
+// but keeps its request ID for logging.
+func (r *OrderRepository) analyzeAfterBulk(ctx context.Context, operation string, rows int64) {
+	if !analyzer.claim(rows) {
+		return
+	}
+
+	analyzeLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", operation)
+	go func() {
+		defer analyzer.done()
+		analyzeCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), analyzeTimeout)
+		defer cancel()
+
+		start := time.Now()
+		if _, err := r.db.Exec(analyzeCtx, analyzeQuery); err != nil {
+			analyzeLogger.WithError(err).Warn("Failed to analyze tables after bulk operation", "rows", rows)
+			return
+		}
+		analyzeLogger.Info("Analyzed tables after bulk operation", "rows", rows, "duration", time.Since(start))
+	}()
+}
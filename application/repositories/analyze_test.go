@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// useAnalyzeAfterBulk configures the background analyzer with a fixed clock for the duration of the test
+func useAnalyzeAfterBulk(t *testing.T, minRows int64, interval time.Duration, now *time.Time) {
+	SetAnalyzeAfterBulk(true, minRows, interval)
+	analyzer.mu.Lock()
+	analyzer.now = func() time.Time { return *now }
+	analyzer.mu.Unlock()
+	t.Cleanup(func() {
+		SetAnalyzeAfterBulk(false, 0, 0)
+		analyzer.mu.Lock()
+		analyzer.now, analyzer.lastRun, analyzer.running = time.Now, time.Time{}, false
+		analyzer.mu.Unlock()
+	})
+}
+
+// expectAnalyze registers the background ANALYZE on mockDB and returns a channel closed when it runs
+func expectAnalyze(mockDB *MockDatabase) <-chan struct{} {
+	analyzed := make(chan struct{})
+	mockDB.On("Exec", mock.Anything, analyzeQuery, []any(nil)).
+		Run(func(mock.Arguments) { close(analyzed) }).
+		Return(pgconn.NewCommandTag("ANALYZE"), nil).Once()
+	return analyzed
+}
+
+// waitForAnalyzer blocks until the background run has finished and released the analyzer
+func waitForAnalyzer(t *testing.T) {
+	assert.Eventually(t, func() bool {
+		analyzer.mu.Lock()
+		defer analyzer.mu.Unlock()
+		return !analyzer.running
+	}, time.Second, time.Millisecond)
+}
+
+// expectDeleteBatch makes the next bulk delete batch remove deleted orders
+func expectDeleteBatch(mockDB *MockDatabase, ctx context.Context, deleted string) {
+	mockTx := &MockTx{}
+	mockTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag(deleted), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+	mockDB.On("Begin", ctx).Return(mockTx, nil).Once()
+}
+
+func TestOrderRepository_DeleteOrdersByFilter_AnalyzesInBackground(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	useAnalyzeAfterBulk(t, 1, time.Hour, &now)
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	expectDeleteBatch(mockDB, ctx, "DELETE 1")
+	analyzed := expectAnalyze(mockDB)
+
+	// Act
+	deleted, err := repo.DeleteOrdersByFilter(ctx, models.DeleteOrdersFilter{Status: models.StatusCancelled}, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), deleted)
+	select {
+	case <-analyzed:
+	case <-time.After(time.Second):
+		t.Fatal("ANALYZE was not run after the bulk delete")
+	}
+	waitForAnalyzer(t)
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_DeleteOrdersByFilter_AnalyzeRateLimited(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	useAnalyzeAfterBulk(t, 1, time.Hour, &now)
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	filter := models.DeleteOrdersFilter{Status: models.StatusCancelled}
+	expectDeleteBatch(mockDB, ctx, "DELETE 1")
+	expectDeleteBatch(mockDB, ctx, "DELETE 1")
+	expectDeleteBatch(mockDB, ctx, "DELETE 1")
+	analyzed := expectAnalyze(mockDB)
+
+	// Act
+	_, err := repo.DeleteOrdersByFilter(ctx, filter, 10)
+	assert.NoError(t, err)
+	<-analyzed
+	waitForAnalyzer(t)
+
+	now = now.Add(30 * time.Minute)
+	_, err = repo.DeleteOrdersByFilter(ctx, filter, 10)
+	assert.NoError(t, err)
+	waitForAnalyzer(t)
+
+	now = now.Add(time.Hour)
+	analyzedAgain := expectAnalyze(mockDB)
+	_, err = repo.DeleteOrdersByFilter(ctx, filter, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	<-analyzedAgain
+	waitForAnalyzer(t)
+	mockDB.AssertNumberOfCalls(t, "Exec", 2)
+}
+
+func TestOrderRepository_DeleteOrdersByFilter_SkipsAnalyzeBelowMinRows(t *testing.T) {
+	// Arrange
+	now := time.Date(2024, 1, 15, 10, 0, 0, 0, time.UTC)
+	useAnalyzeAfterBulk(t, 100, time.Hour, &now)
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	expectDeleteBatch(mockDB, ctx, "DELETE 5")
+
+	// Act
+	deleted, err := repo.DeleteOrdersByFilter(ctx, models.DeleteOrdersFilter{Status: models.StatusCancelled}, 10)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+	waitForAnalyzer(t)
+	mockDB.AssertNotCalled(t, "Exec", mock.Anything, analyzeQuery, mock.Anything)
+}
+
+func TestBulkAnalyzer_DisabledByDefault(t *testing.T) {
+	// Arrange
+	a := &bulkAnalyzer{now: time.Now}
+
+	// Act
+	claimed := a.claim(1_000_000)
+
+	// Assert
+	assert.False(t, claimed)
+}
+
+func TestBulkAnalyzer_DoesNotOverlapRuns(t *testing.T) {
+	// Arrange
+	a := &bulkAnalyzer{enabled: true, now: time.Now}
+
+	// Act
+	first := a.claim(10)
+	second := a.claim(10)
+	a.done()
+
+	// Assert
+	assert.True(t, first)
+	assert.False(t, second)
+}
@@ -0,0 +1,125 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+)
+
+// bulkInsertOrdersQuery inserts a list of orders in one round trip and returns each new ID with
+// its order number, which is unique, so IDs are matched to orders whatever order RETURNING uses
+const bulkInsertOrdersQuery = `INSERT INTO orders (order_number, customer_name, total_amount, status, created_at, updated_at, public_id, user_id)
+	SELECT order_number, customer_name, total_amount, status, created_at, updated_at, public_id, NULLIF(user_id, '')
+	FROM unnest($1::varchar[], $2::text[], $3::numeric[], $4::varchar[], $5::timestamp[], $6::timestamp[], $7::uuid[], $8::varchar[])
+		WITH ORDINALITY AS new_order(order_number, customer_name, total_amount, status, created_at, updated_at, public_id, user_id, position)
+	ORDER BY position
+	RETURNING id, order_number`
+
+// BulkCreateOrders inserts every order in one statement and all their items in a second one,
+// inside a single transaction, so the round trips do not grow with the number of orders. If any
+// insert fails none of the orders are created.
+func (r *OrderRepository) BulkCreateOrders(ctx context.Context, orders []models.OrderWithItems) (created []models.OrderWithItems, err error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	if len(orders) == 0 {
+		return []models.OrderWithItems{}, nil
+	}
+
+	tx, err := r.beginTx(ctx, "bulk_create_orders")
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to begin transaction")
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction")
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	orderNumbers := make([]string, len(orders))
+	customerNames := make([]string, len(orders))
+	totals := make([]float64, len(orders))
+	statuses := make([]string, len(orders))
+	createdAts := make([]time.Time, len(orders))
+	updatedAts := make([]time.Time, len(orders))
+	publicIDs := make([]string, len(orders))
+	userIDs := make([]string, len(orders))
+	created = make([]models.OrderWithItems, len(orders))
+	for i, order := range orders {
+		storedName, err := encryptCustomerName(order.CustomerName)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: failed to encrypt customer name: %w", i, err)
+		}
+		// The public ID is always written so switching to the uuid ID strategy needs no backfill
+		order.PublicID = models.NewOrderPublicID()
+		created[i] = order
+
+		orderNumbers[i] = order.OrderNumber
+		customerNames[i] = storedName
+		totals[i] = order.TotalAmount
+		statuses[i] = string(order.Status)
+		createdAts[i] = order.CreatedAt
+		updatedAts[i] = order.UpdatedAt
+		publicIDs[i] = order.PublicID
+		userIDs[i] = order.UserID
+	}
+
+	rows, err := tx.Query(ctx, bulkInsertOrdersQuery, orderNumbers, customerNames, totals, statuses, createdAts, updatedAts, publicIDs, userIDs)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to insert orders", "orders", len(orders))
+		return nil, fmt.Errorf("failed to insert orders: %w", err)
+	}
+	idsByNumber := make(map[string]int, len(orders))
+	for rows.Next() {
+		var id int
+		var orderNumber string
+		if err = rows.Scan(&id, &orderNumber); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to insert orders: %w", err)
+		}
+		idsByNumber[orderNumber] = id
+	}
+	rows.Close()
+	if err = rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Failed to insert orders", "orders", len(orders))
+		return nil, fmt.Errorf("failed to insert orders: %w", err)
+	}
+
+	var items []models.OrderItem
+	for i := range created {
+		id, ok := idsByNumber[created[i].OrderNumber]
+		if !ok {
+			return nil, fmt.Errorf("failed to insert orders: no ID returned for order %d", i)
+		}
+		created[i].ID = id
+		for _, item := range created[i].Items {
+			item.OrderID = id
+			items = append(items, item)
+		}
+	}
+
+	insertedItems, err := insertItems(ctx, tx, items)
+	if err != nil {
+		return nil, err
+	}
+	// Items come back in the order they were sent, which is grouped by order
+	offset := 0
+	for i := range created {
+		count := len(created[i].Items)
+		created[i].Items = insertedItems[offset : offset+count : offset+count]
+		offset += count
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "orders", len(orders))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.analyzeAfterBulk(ctx, "bulk_create_orders", int64(len(created)))
+	return created, nil
+}
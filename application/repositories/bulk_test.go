@@ -0,0 +1,84 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func bulkOrders() []models.OrderWithItems {
+	return []models.OrderWithItems{
+		{
+			Order: models.Order{OrderNumber: "ORD-20250601-AAAAAA", CustomerName: "Alice", TotalAmount: 15},
+			Items: []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 10}, {ProductName: "Gadget", Quantity: 1, Price: 5}},
+		},
+		{
+			Order: models.Order{OrderNumber: "ORD-20250601-BBBBBB", CustomerName: "Bob", TotalAmount: 7},
+			Items: []models.OrderItem{{ProductName: "Gizmo", Quantity: 1, Price: 7}},
+		},
+	}
+}
+
+func TestOrderRepository_BulkCreateOrders(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	// RETURNING rows may come back in any order, they are matched by order number
+	orderRows := &fakeRows{rows: [][]any{{12, "ORD-20250601-BBBBBB"}, {11, "ORD-20250601-AAAAAA"}}}
+	mockTx.On("Query", ctx, bulkInsertOrdersQuery, mock.MatchedBy(func(args []any) bool {
+		return assert.ObjectsAreEqual([]string{"ORD-20250601-AAAAAA", "ORD-20250601-BBBBBB"}, args[0])
+	})).Return(orderRows, nil).Once()
+	mockTx.On("Query", ctx, insertOrderItemsQuery, mock.MatchedBy(func(args []any) bool {
+		return assert.ObjectsAreEqual([]int{11, 11, 12}, args[0])
+	})).Return(idRows(102, 100, 101), nil).Once()
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	created, err := repo.BulkCreateOrders(ctx, bulkOrders())
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, created, 2) {
+		assert.Equal(t, 11, created[0].ID)
+		assert.NotEmpty(t, created[0].PublicID)
+		assert.Equal(t, []int{100, 101}, []int{created[0].Items[0].ID, created[0].Items[1].ID})
+		assert.Equal(t, "Gadget", created[0].Items[1].ProductName)
+		assert.Equal(t, 12, created[1].ID)
+		if assert.Len(t, created[1].Items, 1) {
+			assert.Equal(t, 102, created[1].Items[0].ID)
+			assert.Equal(t, 12, created[1].Items[0].OrderID)
+		}
+	}
+	mockTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_BulkCreateOrders_RollsBackWhenItemsFail(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	orderRows := &fakeRows{rows: [][]any{{11, "ORD-20250601-AAAAAA"}, {12, "ORD-20250601-BBBBBB"}}}
+	mockTx.On("Query", ctx, bulkInsertOrdersQuery, mock.Anything).Return(orderRows, nil)
+	mockTx.On("Query", ctx, insertOrderItemsQuery, mock.Anything).Return(nil, errors.New("value too long for type character varying(100)"))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	created, err := repo.BulkCreateOrders(ctx, bulkOrders())
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to insert order items")
+	assert.Nil(t, created)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+}
@@ -0,0 +1,54 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// cancelOrderQuery cancels a pending or processing order in one statement, so the status check
+// and the write cannot be split by a concurrent update, and returns the status it had
+const cancelOrderQuery = `UPDATE orders
+	SET status = $2, updated_at = $3
+	FROM (SELECT id, status FROM orders WHERE id = $1 FOR UPDATE) AS previous
+	WHERE orders.id = previous.id AND orders.status = ANY($4::varchar[])
+	RETURNING previous.status`
+
+// CancelOrder cancels a pending or processing order and returns the status it had. An order that
+// is already cancelled is left as is and models.StatusCancelled is returned. It returns
+// pgx.ErrNoRows when the order does not exist and domain.ErrOrderNotCancellable for other statuses.
+func (r *OrderRepository) CancelOrder(ctx context.Context, id int, cancelledAt time.Time) (models.Status, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	cancellable := []models.Status{models.StatusPending, models.StatusProcessing}
+	var previous models.Status
+	err := r.db.QueryRow(ctx, cancelOrderQuery, id, models.StatusCancelled, cancelledAt, statusStrings(cancellable)).Scan(&previous)
+	if err == nil {
+		recordWrite(nil)
+		return previous, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		recordWrite(err)
+		repoLogger.WithError(err).Error("Failed to cancel order", "order_id", id)
+		return "", translateWriteError(fmt.Errorf("failed to cancel order: %w", err))
+	}
+
+	// Nothing was cancelled, so the order is missing or in a status that cannot be cancelled
+	var current models.Status
+	if err := r.db.QueryRow(ctx, "SELECT status FROM orders WHERE id = $1", id).Scan(&current); err != nil {
+		if !errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.WithError(err).Error("Failed to read order status", "order_id", id)
+		}
+		return "", err
+	}
+	if current == models.StatusCancelled {
+		return current, nil
+	}
+	return "", fmt.Errorf("%w: order is %s", domain.ErrOrderNotCancellable, current)
+}
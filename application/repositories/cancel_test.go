@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderRepository_CancelOrder(t *testing.T) {
+	cancelledAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	cancelArgs := []any{4, models.StatusCancelled, cancelledAt, []string{"pending", "processing"}}
+
+	tests := []struct {
+		name         string
+		current      models.Status // Status found when nothing was cancelled, empty for a missing order
+		cancelled    bool
+		wantPrevious models.Status
+		wantErr      error
+	}{
+		{name: "cancellable", cancelled: true, wantPrevious: models.StatusProcessing},
+		{name: "already cancelled", current: models.StatusCancelled, wantPrevious: models.StatusCancelled},
+		{name: "not cancellable", current: models.StatusCompleted, wantErr: domain.ErrOrderNotCancellable},
+		{name: "missing", wantErr: pgx.ErrNoRows},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockDB := &MockDatabase{}
+			repo := NewOrderRepository(mockDB)
+			ctx := context.Background()
+
+			if tt.cancelled {
+				mockDB.On("QueryRow", ctx, cancelOrderQuery, cancelArgs).Return(row(models.StatusProcessing))
+			} else {
+				mockDB.On("QueryRow", ctx, cancelOrderQuery, cancelArgs).Return(errRow{err: pgx.ErrNoRows})
+				if tt.current != "" {
+					mockDB.On("QueryRow", ctx, sqlContaining("SELECT status FROM orders"), []any{4}).Return(row(tt.current))
+				} else {
+					mockDB.On("QueryRow", ctx, sqlContaining("SELECT status FROM orders"), []any{4}).Return(errRow{err: pgx.ErrNoRows})
+				}
+			}
+
+			// Act
+			previous, err := repo.CancelOrder(ctx, 4, cancelledAt)
+
+			// Assert
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+			} else {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, tt.wantPrevious, previous)
+			mockDB.AssertExpectations(t)
+		})
+	}
+}
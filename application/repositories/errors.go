@@ -0,0 +1,25 @@
+package repositories
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgReadOnlySQLTransaction is raised for writes against a read-only transaction or a standby in recovery
+const pgReadOnlySQLTransaction = "25006"
+
+// translateWriteError wraps known pg error codes in typed domain errors
+func translateWriteError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == pgReadOnlySQLTransaction {
+		return fmt.Errorf("%w: %v", domain.ErrReadOnly, err)
+	}
+	return err
+}
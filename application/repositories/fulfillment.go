@@ -0,0 +1,98 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// ShipOrderItems adds the shipped quantities to the order's items, records them as one
+// fulfillment and re-derives the order status, all in a single transaction. Items become shipped
+// once their full quantity has shipped. It returns pgx.ErrNoRows when the order or an item does not exist and
+// domain.ErrInvalidShipment when the order is cancelled or an item would ship more than ordered.
+func (r *OrderRepository) ShipOrderItems(ctx context.Context, orderID int, items []models.ShipItem, shippedAt time.Time) (fulfillment models.Fulfillment, err error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	tx, err := r.beginTx(ctx, "ship_order")
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to begin transaction", "order_id", orderID)
+		return models.Fulfillment{}, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction", "order_id", orderID)
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	// Lock the order so concurrent shipments serialize on it
+	var status models.Status
+	err = tx.QueryRow(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&status)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.Warn("Order not found", "order_id", orderID)
+			return models.Fulfillment{}, err
+		}
+		repoLogger.WithError(err).Error("Failed to lock order", "order_id", orderID)
+		return models.Fulfillment{}, fmt.Errorf("failed to lock order: %w", err)
+	}
+	if status == models.StatusCancelled {
+		return models.Fulfillment{}, fmt.Errorf("%w: order %d is cancelled", domain.ErrInvalidShipment, orderID)
+	}
+
+	updateItemQuery := `UPDATE order_items
+		SET shipped_quantity = shipped_quantity + $1,
+			status = CASE WHEN shipped_quantity + $1 >= quantity THEN $2 ELSE status END,
+			updated_at = $3
+		WHERE id = $4 AND order_id = $5
+		RETURNING quantity, shipped_quantity`
+	for _, item := range items {
+		var quantity, shipped int
+		err = tx.QueryRow(ctx, updateItemQuery, item.Quantity, models.ItemStatusShipped, shippedAt, item.ItemID, orderID).Scan(&quantity, &shipped)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				repoLogger.Warn("Order item not found", "order_id", orderID, "item_id", item.ItemID)
+				return models.Fulfillment{}, err
+			}
+			repoLogger.WithError(err).Error("Failed to update shipped quantity", "order_id", orderID, "item_id", item.ItemID)
+			return models.Fulfillment{}, fmt.Errorf("failed to update shipped quantity: %w", err)
+		}
+		if shipped > quantity {
+			return models.Fulfillment{}, fmt.Errorf("%w: item %d would ship %d of %d", domain.ErrInvalidShipment, item.ItemID, shipped, quantity)
+		}
+	}
+
+	fulfillment = models.Fulfillment{OrderID: orderID, Items: items, CreatedAt: shippedAt}
+	err = tx.QueryRow(ctx, "INSERT INTO fulfillments (order_id, created_at) VALUES ($1, $2) RETURNING id", orderID, shippedAt).Scan(&fulfillment.ID)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to insert fulfillment", "order_id", orderID)
+		return models.Fulfillment{}, fmt.Errorf("failed to insert fulfillment: %w", err)
+	}
+
+	insertItemQuery := "INSERT INTO fulfillment_items (fulfillment_id, order_item_id, quantity) VALUES ($1, $2, $3)"
+	for _, item := range items {
+		if _, err = tx.Exec(ctx, insertItemQuery, fulfillment.ID, item.ItemID, item.Quantity); err != nil {
+			repoLogger.WithError(err).Error("Failed to insert fulfillment item", "fulfillment_id", fulfillment.ID, "item_id", item.ItemID)
+			return models.Fulfillment{}, fmt.Errorf("failed to insert fulfillment item: %w", err)
+		}
+	}
+
+	if err = writeDerivedStatus(ctx, tx, orderID, status, shippedAt); err != nil {
+		return models.Fulfillment{}, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "order_id", orderID)
+		return models.Fulfillment{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return fulfillment, nil
+}
@@ -0,0 +1,93 @@
+package repositories
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func row(values ...any) *fakeRows {
+	return &fakeRows{rows: [][]any{values}, next: 1}
+}
+
+func sqlContaining(fragment string) any {
+	return mock.MatchedBy(func(sql string) bool { return strings.Contains(sql, fragment) })
+}
+
+func TestOrderRepository_ShipOrderItems_RecordsFulfillment(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	shippedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []models.ShipItem{{ItemID: 11, Quantity: 2}, {ItemID: 12, Quantity: 1}}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("FOR UPDATE"), []any{5}).Return(row(models.StatusPending))
+	mockTx.On("QueryRow", ctx, sqlContaining("UPDATE order_items"), []any{2, models.ItemStatusShipped, shippedAt, 11, 5}).Return(row(3, 2))
+	mockTx.On("QueryRow", ctx, sqlContaining("UPDATE order_items"), []any{1, models.ItemStatusShipped, shippedAt, 12, 5}).Return(row(1, 1))
+	mockTx.On("QueryRow", ctx, sqlContaining("INSERT INTO fulfillments"), []any{5, shippedAt}).Return(row(99))
+	mockTx.On("Exec", ctx, sqlContaining("INSERT INTO fulfillment_items"), mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil).Twice()
+	mockTx.On("Query", ctx, sqlContaining("FROM order_items"), []any{5}).Return(&fakeRows{rows: [][]any{{3, 2}, {1, 1}}}, nil)
+	mockTx.On("Exec", ctx, sqlContaining("UPDATE orders"), []any{models.StatusPartiallyShipped, shippedAt, 5}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	fulfillment, err := repo.ShipOrderItems(ctx, 5, items, shippedAt)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.Fulfillment{ID: 99, OrderID: 5, Items: items, CreatedAt: shippedAt}, fulfillment)
+	mockTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_ShipOrderItems_OverShipRollsBack(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	shippedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("FOR UPDATE"), []any{5}).Return(row(models.StatusProcessing))
+	mockTx.On("QueryRow", ctx, sqlContaining("UPDATE order_items"), mock.Anything).Return(row(2, 3))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	_, err := repo.ShipOrderItems(ctx, 5, []models.ShipItem{{ItemID: 11, Quantity: 3}}, shippedAt)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidShipment)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+	mockTx.AssertNotCalled(t, "QueryRow", ctx, sqlContaining("INSERT INTO fulfillments"), mock.Anything)
+}
+
+func TestOrderRepository_ShipOrderItems_CancelledOrder(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("FOR UPDATE"), []any{5}).Return(row(models.StatusCancelled))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	_, err := repo.ShipOrderItems(ctx, 5, []models.ShipItem{{ItemID: 11, Quantity: 1}}, time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidShipment)
+	assert.ErrorContains(t, err, "cancelled")
+	mockTx.AssertExpectations(t)
+}
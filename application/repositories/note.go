@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// CreateOrderNote stores the note and returns it with its ID. It returns pgx.ErrNoRows when the
+// order does not exist.
+func (r *OrderRepository) CreateOrderNote(ctx context.Context, note models.OrderNote) (models.OrderNote, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	// Selecting from orders inserts nothing for an unknown order, so it surfaces as ErrNoRows
+	query := `INSERT INTO order_notes (order_id, author, text, created_at)
+		SELECT id, $2, $3, $4 FROM orders WHERE id = $1
+		RETURNING id`
+
+	err := r.db.QueryRow(ctx, query, note.OrderID, note.Author, note.Text, note.CreatedAt).Scan(&note.ID)
+	recordWrite(err)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.Warn("Order not found for note", "order_id", note.OrderID)
+			return models.OrderNote{}, err
+		}
+		repoLogger.WithError(err).Error("Failed to insert order note", "order_id", note.OrderID)
+		return models.OrderNote{}, translateWriteError(fmt.Errorf("failed to insert order note: %w", err))
+	}
+
+	return note, nil
+}
+
+// ListOrderNotes returns the order's notes oldest first. It returns pgx.ErrNoRows when the order
+// does not exist, so an order without notes can be told apart from a missing one.
+func (r *OrderRepository) ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	var exists int
+	if err := r.db.QueryRow(ctx, "SELECT 1 FROM orders WHERE id = $1", orderID).Scan(&exists); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.Warn("Order not found for notes", "order_id", orderID)
+			return nil, err
+		}
+		repoLogger.WithError(err).Error("Failed to check order", "order_id", orderID)
+		return nil, fmt.Errorf("failed to check order: %w", err)
+	}
+
+	query := `SELECT id, order_id, author, text, created_at
+		FROM order_notes
+		WHERE order_id = $1
+		ORDER BY created_at, id`
+
+	rows, err := r.db.Query(ctx, query, orderID)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to query order notes", "order_id", orderID)
+		return nil, fmt.Errorf("failed to query order notes: %w", err)
+	}
+	defer rows.Close()
+
+	notes := []models.OrderNote{}
+	for rows.Next() {
+		var note models.OrderNote
+		if err := rows.Scan(&note.ID, &note.OrderID, &note.Author, &note.Text, &note.CreatedAt); err != nil {
+			repoLogger.WithError(err).Error("Failed to scan order note")
+			return nil, fmt.Errorf("failed to scan order note: %w", err)
+		}
+		notes = append(notes, note)
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Error scanning order notes")
+		return nil, fmt.Errorf("error scanning order notes: %w", err)
+	}
+
+	return notes, nil
+}
@@ -0,0 +1,90 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrderRepository_CreateOrderNote(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	note := models.OrderNote{OrderID: 4, Author: "support", Text: "Call back", CreatedAt: createdAt}
+	mockDB.On("QueryRow", ctx, sqlContaining("INSERT INTO order_notes"), []any{4, "support", "Call back", createdAt}).Return(row(11))
+
+	// Act
+	created, err := repo.CreateOrderNote(ctx, note)
+
+	// Assert
+	assert.NoError(t, err)
+	note.ID = 11
+	assert.Equal(t, note, created)
+}
+
+func TestOrderRepository_CreateOrderNote_UnknownOrder(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	mockDB.On("QueryRow", ctx, sqlContaining("INSERT INTO order_notes"), mock.Anything).Return(errRow{err: pgx.ErrNoRows})
+
+	// Act
+	_, err := repo.CreateOrderNote(ctx, models.OrderNote{OrderID: 99, Author: "support", Text: "Call back"})
+
+	// Assert
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+}
+
+func TestOrderRepository_ListOrderNotes(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	mockDB.On("QueryRow", ctx, sqlContaining("FROM orders"), []any{4}).Return(row(1))
+	mockDB.On("Query", ctx, sqlContaining("ORDER BY created_at, id"), []any{4}).Return(&fakeRows{rows: [][]any{
+		{1, 4, "support", "First", createdAt},
+		{2, 4, "anonymous", "Second", createdAt.Add(time.Minute)},
+	}}, nil)
+
+	// Act
+	notes, err := repo.ListOrderNotes(ctx, 4)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.OrderNote{
+		{ID: 1, OrderID: 4, Author: "support", Text: "First", CreatedAt: createdAt},
+		{ID: 2, OrderID: 4, Author: "anonymous", Text: "Second", CreatedAt: createdAt.Add(time.Minute)},
+	}, notes)
+}
+
+func TestOrderRepository_ListOrderNotes_EmptyAndUnknownOrder(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("QueryRow", ctx, sqlContaining("FROM orders"), []any{4}).Return(row(1))
+	mockDB.On("Query", ctx, sqlContaining("FROM order_notes"), []any{4}).Return(&fakeRows{}, nil)
+	mockDB.On("QueryRow", ctx, sqlContaining("FROM orders"), []any{99}).Return(errRow{err: pgx.ErrNoRows})
+
+	// Act
+	empty, emptyErr := repo.ListOrderNotes(ctx, 4)
+	_, unknownErr := repo.ListOrderNotes(ctx, 99)
+
+	// Assert
+	assert.NoError(t, emptyErr)
+	assert.NotNil(t, empty)
+	assert.Empty(t, empty)
+	assert.ErrorIs(t, unknownErr, pgx.ErrNoRows)
+}
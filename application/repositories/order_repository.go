@@ -3,10 +3,16 @@ package repositories
 import (
 	"context"
 	"fmt"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"time"
 
+	"github.com/Testzyler/order-management-go/application/domain"
 	"github.com/Testzyler/order-management-go/application/models"
 	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/pkg/errors"
 )
 
@@ -20,6 +26,36 @@ func NewOrderRepository(db database.DatabaseInterface) *OrderRepository {
 	}
 }
 
+// DefaultItemsQueryChunkSize is how many order IDs one list items query is sent by default
+const DefaultItemsQueryChunkSize = 1000
+
+// itemsQueryChunkSize caps the order IDs sent in one list items query
+var itemsQueryChunkSize atomic.Int64
+
+func init() {
+	itemsQueryChunkSize.Store(DefaultItemsQueryChunkSize)
+}
+
+// SetItemsQueryChunkSize sets how many order IDs ListOrders sends per items query; larger pages
+// are fetched in several queries. Values <= 0 restore the default.
+func SetItemsQueryChunkSize(size int) {
+	if size <= 0 {
+		size = DefaultItemsQueryChunkSize
+	}
+	itemsQueryChunkSize.Store(int64(size))
+}
+
+// listItemLimit caps the items returned per order by ListOrders; 0 returns every item
+var listItemLimit atomic.Int64
+
+// SetListItemLimit configures how many items ListOrders returns per order; values <= 0 disable the cap
+func SetListItemLimit(limit int) {
+	if limit < 0 {
+		limit = 0
+	}
+	listItemLimit.Store(int64(limit))
+}
+
 func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput) (*models.ListPaginatedOrders, error) {
 	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
 
@@ -30,14 +66,13 @@ func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput
 		input.Size = 10
 	}
 	offset := (input.Page - 1) * input.Size
+	if input.After != nil {
+		offset = 0
+	}
 
-	queryOrders := `
-		SELECT COUNT(*) OVER() AS total_count, id, customer_name, total_amount, status, created_at, updated_at 
-		FROM orders
-		ORDER BY created_at DESC
-		LIMIT $1 OFFSET $2`
+	queryOrders, args := buildListOrdersQuery(input, offset)
 
-	rows, err := r.db.Query(ctx, queryOrders, input.Size, offset)
+	rows, err := r.db.Query(ctx, queryOrders, args...)
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to query orders")
 		return nil, err
@@ -52,10 +87,14 @@ func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput
 
 	for rows.Next() {
 		var order models.Order
-		if err := rows.Scan(&total, &order.ID, &order.CustomerName, &order.TotalAmount, &order.Status, &order.CreatedAt, &order.UpdatedAt); err != nil {
+		if err := rows.Scan(&total, &order.ID, &order.OrderNumber, &order.CustomerName, &order.TotalAmount, &order.Status, &order.CreatedAt, &order.UpdatedAt, &order.PublicID, &order.UserID); err != nil {
 			repoLogger.WithError(err).Error("Failed to scan order row")
 			return nil, err
 		}
+		if order.CustomerName, err = decryptCustomerName(order.CustomerName); err != nil {
+			repoLogger.WithError(err).Error("Failed to decrypt customer name", "order_id", order.ID)
+			return nil, err
+		}
 		orderIDs = append(orderIDs, order.ID)
 		orderWithItems := &models.OrderWithItems{Order: order}
 		orderMap[order.ID] = orderWithItems
@@ -72,27 +111,9 @@ func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput
 	}
 
 	// Get items for all orders in the page
-	queryItems := `SELECT id, order_id, product_name, quantity, price, created_at, updated_at
-		FROM order_items
-		WHERE order_id = ANY($1)`
-
-	itemRows, err := r.db.Query(ctx, queryItems, orderIDs)
-	if err != nil {
-		repoLogger.WithError(err).Error("Failed to query order items")
+	if err := r.loadListItems(ctx, orderIDs, orderMap, int(listItemLimit.Load())); err != nil {
 		return nil, err
 	}
-	defer itemRows.Close()
-
-	for itemRows.Next() {
-		var item models.OrderItem
-		if err := itemRows.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Quantity, &item.Price, &item.CreatedAt, &item.UpdatedAt); err != nil {
-			repoLogger.WithError(err).Error("Failed to scan order item")
-			return nil, err
-		}
-		if orderMap[item.OrderID] != nil {
-			orderMap[item.OrderID].Items = append(orderMap[item.OrderID].Items, item)
-		}
-	}
 
 	// Combine into list
 	var orderWithItems []models.OrderWithItems
@@ -101,10 +122,6 @@ func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput
 	}
 
 	totalPages := (total + input.Size - 1) / input.Size
-	if err := itemRows.Err(); err != nil {
-		repoLogger.WithError(err).Error("Error scanning order items")
-		return nil, fmt.Errorf("error scanning order items: %w", err)
-	}
 
 	return &models.ListPaginatedOrders{
 		Data:       orderWithItems,
@@ -115,50 +132,350 @@ func (r *OrderRepository) ListOrders(ctx context.Context, input models.ListInput
 	}, nil
 }
 
+// loadListItems attaches the items of the listed orders, querying at most itemsQueryChunkSize
+// order IDs at a time so a very large page never sends one huge array parameter. Each order's
+// items come from a single chunk, so they stay sorted by ID. With limit > 0 the database returns
+// only the first limit items of each order, and the truncated orders get MoreItems and ItemCount.
+func (r *OrderRepository) loadListItems(ctx context.Context, orderIDs []int, orderMap map[int]*models.OrderWithItems, limit int) error {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	queryItems := `SELECT id, order_id, product_name, quantity, price, status, shipped_quantity, created_at, updated_at
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY id`
+	if limit > 0 {
+		queryItems = listItemsWithLimitQuery
+	}
+
+	chunkSize := int(itemsQueryChunkSize.Load())
+	for chunk := range slices.Chunk(orderIDs, chunkSize) {
+		args := []any{chunk}
+		if limit > 0 {
+			args = append(args, limit)
+		}
+		itemRows, err := r.db.Query(ctx, queryItems, args...)
+		if err != nil {
+			repoLogger.WithError(err).Error("Failed to query order items", "orders", len(chunk))
+			return err
+		}
+
+		for itemRows.Next() {
+			var item models.OrderItem
+			dest := []any{&item.ID, &item.OrderID, &item.ProductName, &item.Quantity, &item.Price, &item.Status, &item.ShippedQuantity, &item.CreatedAt, &item.UpdatedAt}
+			var itemCount int
+			if limit > 0 {
+				dest = append(dest, &itemCount)
+			}
+			if err := itemRows.Scan(dest...); err != nil {
+				itemRows.Close()
+				repoLogger.WithError(err).Error("Failed to scan order item")
+				return err
+			}
+			order := orderMap[item.OrderID]
+			if order == nil {
+				continue
+			}
+			order.Items = append(order.Items, item)
+			if itemCount > limit {
+				order.ItemCount = itemCount
+				order.MoreItems = true
+			}
+		}
+		itemRows.Close()
+		if err := itemRows.Err(); err != nil {
+			repoLogger.WithError(err).Error("Error scanning order items")
+			return fmt.Errorf("error scanning order items: %w", err)
+		}
+	}
+	return nil
+}
+
+// listItemsWithLimitQuery selects the first $2 items of each order by ID, each row carrying the
+// order's full item count so truncated orders can be flagged without a second query
+const listItemsWithLimitQuery = `SELECT id, order_id, product_name, quantity, price, status, shipped_quantity, created_at, updated_at, item_count
+		FROM (
+			SELECT id, order_id, product_name, quantity, price, status, shipped_quantity, created_at, updated_at,
+				ROW_NUMBER() OVER (PARTITION BY order_id ORDER BY id) AS item_number,
+				COUNT(*) OVER (PARTITION BY order_id) AS item_count
+			FROM order_items
+			WHERE order_id = ANY($1)
+		) AS numbered_items
+		WHERE item_number <= $2
+		ORDER BY id`
+
+// buildListOrdersQuery returns the paginated orders query and its arguments.
+// With UpdatedSince set, orders are filtered by updated_at and sorted oldest first for incremental sync.
+// Every sort ends with id as a tiebreaker so orders sharing a timestamp keep a stable order across pages.
+// With a cursor the rows after its position are selected instead of skipping offset rows, so
+// total_count counts the remaining orders. With UserID set only that user's orders are listed, and
+// with Statuses only orders in one of those statuses.
+func buildListOrdersQuery(input models.ListInput, offset int) (string, []any) {
+	args := []any{input.Size, offset}
+	var conditions []string
+	orderBy := "created_at DESC, id DESC"
+
+	if input.UpdatedSince != nil {
+		// updated_at is a timestamp without time zone holding UTC, and pgx binds a time's wall
+		// clock, so an offset such as +07:00 must be converted first
+		args = append(args, input.UpdatedSince.UTC())
+		conditions = append(conditions, fmt.Sprintf("updated_at > $%d", len(args)))
+		orderBy = "updated_at ASC, id ASC"
+	}
+	if input.After != nil {
+		args = append(args, input.After.LastTime.UTC(), input.After.LastID)
+		if input.UpdatedSince != nil {
+			conditions = append(conditions, fmt.Sprintf("(updated_at, id) > ($%d, $%d)", len(args)-1, len(args)))
+		} else {
+			conditions = append(conditions, fmt.Sprintf("(created_at, id) < ($%d, $%d)", len(args)-1, len(args)))
+		}
+	}
+	if input.UserID != "" {
+		args = append(args, input.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if len(input.Statuses) > 0 {
+		args = append(args, statusStrings(input.Statuses))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = `
+		WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	return `
+		SELECT COUNT(*) OVER() AS total_count, id, order_number, customer_name, total_amount, status, created_at, updated_at, public_id, COALESCE(user_id, '')
+		FROM orders` + where + `
+		ORDER BY ` + orderBy + `
+		LIMIT $1 OFFSET $2`, args
+}
+
+// statusStrings converts statuses to the text array bound to a status = ANY($n) filter
+func statusStrings(statuses []models.Status) []string {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	return values
+}
+
+// CountOrders returns how many orders match the list filters, ignoring pagination
+func (r *OrderRepository) CountOrders(ctx context.Context, input models.ListInput) (int, error) {
+	var args []any
+	var conditions []string
+	if input.UpdatedSince != nil {
+		args = append(args, input.UpdatedSince.UTC())
+		conditions = append(conditions, fmt.Sprintf("updated_at > $%d", len(args)))
+	}
+	if input.UserID != "" {
+		args = append(args, input.UserID)
+		conditions = append(conditions, fmt.Sprintf("user_id = $%d", len(args)))
+	}
+	if len(input.Statuses) > 0 {
+		args = append(args, statusStrings(input.Statuses))
+		conditions = append(conditions, fmt.Sprintf("status = ANY($%d)", len(args)))
+	}
+
+	query := `SELECT COUNT(*) FROM orders`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(ctx, query, args...).Scan(&total); err != nil {
+		logger.LoggerWithRequestIDFromContext(ctx).WithError(err).Error("Failed to count orders")
+		return 0, err
+	}
+	return total, nil
+}
+
+// RecentOrders returns the newest orders without items or a total count, for cheap dashboard reads
+func (r *OrderRepository) RecentOrders(ctx context.Context, limit int) ([]models.Order, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	query := `
+		SELECT id, order_number, customer_name, total_amount, status, created_at, updated_at, public_id, COALESCE(user_id, '')
+		FROM orders
+		ORDER BY created_at DESC, id DESC
+		LIMIT $1`
+
+	rows, err := r.db.Query(ctx, query, limit)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to query recent orders", "limit", limit)
+		return nil, fmt.Errorf("failed to query recent orders: %w", err)
+	}
+	defer rows.Close()
+
+	orders := make([]models.Order, 0, limit)
+	for rows.Next() {
+		var order models.Order
+		if err := rows.Scan(&order.ID, &order.OrderNumber, &order.CustomerName, &order.TotalAmount, &order.Status, &order.CreatedAt, &order.UpdatedAt, &order.PublicID, &order.UserID); err != nil {
+			repoLogger.WithError(err).Error("Failed to scan recent order")
+			return nil, fmt.Errorf("failed to scan recent order: %w", err)
+		}
+		if order.CustomerName, err = decryptCustomerName(order.CustomerName); err != nil {
+			repoLogger.WithError(err).Error("Failed to decrypt customer name", "order_id", order.ID)
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Error scanning recent orders")
+		return nil, fmt.Errorf("error scanning recent orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// GetOrderById fetches an order and its items, returning pgx.ErrNoRows when it does not exist
 func (r *OrderRepository) GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error) {
+	orders, err := r.GetOrdersByIds(ctx, []int{id})
+	order, found := orders[id]
+	if err != nil {
+		return order, err
+	}
+	if !found {
+		logger.LoggerWithRequestIDFromContext(ctx).Error("Failed to query order", "id", id)
+		return models.OrderWithItems{}, pgx.ErrNoRows
+	}
+	return order, nil
+}
+
+// GetOrdersByIds fetches the orders with the given IDs and their items in two queries however
+// many IDs are asked for. IDs without an order are left out of the map. When the items cannot be
+// loaded the orders are returned without them, together with ErrItemsUnavailable.
+func (r *OrderRepository) GetOrdersByIds(ctx context.Context, ids []int) (map[int]models.OrderWithItems, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	if len(ids) == 0 {
+		return map[int]models.OrderWithItems{}, nil
+	}
+
+	query := `
+		SELECT id, order_number, customer_name, total_amount, status, created_at, updated_at, public_id, COALESCE(user_id, '')
+		FROM orders
+		WHERE id = ANY($1)`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to query orders", "ids", len(ids))
+		return nil, err
+	}
+	defer rows.Close()
+
+	var orderIDs []int
+	orderMap := make(map[int]*models.OrderWithItems, len(ids))
+	for rows.Next() {
+		var order models.Order
+		if err := rows.Scan(&order.ID, &order.OrderNumber, &order.CustomerName, &order.TotalAmount, &order.Status, &order.CreatedAt, &order.UpdatedAt, &order.PublicID, &order.UserID); err != nil {
+			repoLogger.WithError(err).Error("Failed to scan order row")
+			return nil, err
+		}
+		if order.CustomerName, err = decryptCustomerName(order.CustomerName); err != nil {
+			repoLogger.WithError(err).Error("Failed to decrypt customer name", "order_id", order.ID)
+			return nil, err
+		}
+		orderIDs = append(orderIDs, order.ID)
+		orderMap[order.ID] = &models.OrderWithItems{Order: order}
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Error reading orders")
+		return nil, err
+	}
+
+	var itemsErr error
+	if len(orderIDs) > 0 {
+		if err := r.loadListItems(ctx, orderIDs, orderMap, 0); err != nil {
+			itemsErr = fmt.Errorf("%w: failed to fetch order items: %w", domain.ErrItemsUnavailable, err)
+		}
+	}
+
+	orders := make(map[int]models.OrderWithItems, len(orderMap))
+	for id, order := range orderMap {
+		if itemsErr != nil {
+			order.Items = nil
+		}
+		orders[id] = *order
+	}
+	return orders, itemsErr
+}
+
+// GetOrderByNumber fetches an order and its items by its human-friendly order number
+func (r *OrderRepository) GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error) {
+	return r.getOrder(ctx, "order_number", orderNumber)
+}
+
+// GetOrderByPublicID fetches an order and its items by the UUID exposed with the uuid ID strategy
+func (r *OrderRepository) GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error) {
+	return r.getOrder(ctx, "public_id", publicID)
+}
+
+// GetOrderIDByPublicID returns the serial ID of the order with the given public ID, or pgx.ErrNoRows
+func (r *OrderRepository) GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error) {
+	var id int
+	if err := r.db.QueryRow(ctx, "SELECT id FROM orders WHERE public_id = $1", publicID).Scan(&id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// getOrder fetches a single order matched on column, which must be a trusted column name
+func (r *OrderRepository) getOrder(ctx context.Context, column string, value any) (models.OrderWithItems, error) {
 	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
 	var result models.OrderWithItems
 	var order models.Order
 	query := `
-		SELECT id, customer_name, total_amount, status, created_at, updated_at 
+		SELECT id, order_number, customer_name, total_amount, status, created_at, updated_at, public_id, COALESCE(user_id, '')
 		FROM orders 
-		WHERE id = $1`
+		WHERE ` + column + ` = $1`
 
-	err := r.db.QueryRow(ctx, query, id).Scan(
+	err := r.db.QueryRow(ctx, query, value).Scan(
 		&order.ID,
+		&order.OrderNumber,
 		&order.CustomerName,
 		&order.TotalAmount,
 		&order.Status,
 		&order.CreatedAt,
 		&order.UpdatedAt,
+		&order.PublicID,
+		&order.UserID,
 	)
 
 	if err != nil {
-		repoLogger.WithError(err).Error("Failed to query order", "order_id", id)
+		repoLogger.WithError(err).Error("Failed to query order", column, value)
+		return models.OrderWithItems{}, err
+	}
+	if order.CustomerName, err = decryptCustomerName(order.CustomerName); err != nil {
+		repoLogger.WithError(err).Error("Failed to decrypt customer name", "order_id", order.ID)
 		return models.OrderWithItems{}, err
 	}
 
-	// Fetch order items
-	itemQuery := `SELECT id, order_id, product_name, quantity, price, created_at, updated_at
+	// Fetch order items. Failures return the loaded order with ErrItemsUnavailable so the
+	// caller can choose to serve a partial result.
+	itemQuery := `SELECT id, order_id, product_name, quantity, price, status, shipped_quantity, created_at, updated_at
 		FROM order_items
-		WHERE order_id = $1`
+		WHERE order_id = $1
+		ORDER BY id`
 
-	itemRows, err := r.db.Query(ctx, itemQuery, id)
+	itemRows, err := r.db.Query(ctx, itemQuery, order.ID)
 	if err != nil {
-		repoLogger.WithError(err).Error("Failed to fetch order items", "order_id", id)
-		return models.OrderWithItems{}, fmt.Errorf("failed to fetch order items: %w", err)
+		repoLogger.WithError(err).Error("Failed to fetch order items", "order_id", order.ID)
+		return models.OrderWithItems{Order: order}, fmt.Errorf("%w: failed to fetch order items: %w", domain.ErrItemsUnavailable, err)
 	}
 	defer itemRows.Close()
 
 	var items []models.OrderItem
 	for itemRows.Next() {
 		var item models.OrderItem
-		if err := itemRows.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Quantity, &item.Price, &item.CreatedAt, &item.UpdatedAt); err != nil {
-			repoLogger.WithError(err).Error("Failed to scan order item", "order_id", id)
-			return models.OrderWithItems{}, fmt.Errorf("failed to scan order item: %w", err)
+		if err := itemRows.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Quantity, &item.Price, &item.Status, &item.ShippedQuantity, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			repoLogger.WithError(err).Error("Failed to scan order item", "order_id", order.ID)
+			return models.OrderWithItems{Order: order}, fmt.Errorf("%w: failed to scan order item: %w", domain.ErrItemsUnavailable, err)
 		}
 		items = append(items, item)
 	}
+	if err := itemRows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Error reading order items", "order_id", order.ID)
+		return models.OrderWithItems{Order: order}, fmt.Errorf("%w: error reading order items: %w", domain.ErrItemsUnavailable, err)
+	}
 
 	result.Order = order
 	result.Items = items
@@ -166,73 +483,222 @@ func (r *OrderRepository) GetOrderById(ctx context.Context, id int) (models.Orde
 	return result, nil
 }
 
-func (r *OrderRepository) CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) (err error) {
+func (r *OrderRepository) CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) (created models.OrderWithItems, err error) {
 	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
-	tx, err := r.db.Begin(ctx)
+	tx, err := r.beginTx(ctx, "create_order")
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to begin transaction")
 		err = errors.Wrap(err, "failed to begin transaction")
-		return err
+		return models.OrderWithItems{}, err
 	}
 	defer func() {
 		if err != nil {
-			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
 				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction")
 			}
+			err = translateWriteError(err)
 		}
 	}()
 
+	created, err = insertOrderWithItems(ctx, tx, order, items)
+	if err != nil {
+		return models.OrderWithItems{}, err
+	}
+
+	// Commit transaction
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "order_id", created.ID)
+		return models.OrderWithItems{}, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// CreateOrders inserts every order with its items in a single transaction; if any insert fails
+// none of the orders are created
+func (r *OrderRepository) CreateOrders(ctx context.Context, orders []models.OrderWithItems) (created []models.OrderWithItems, err error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	tx, err := r.beginTx(ctx, "create_orders")
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to begin transaction")
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction")
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	created = make([]models.OrderWithItems, 0, len(orders))
+	for i, order := range orders {
+		inserted, err := insertOrderWithItems(ctx, tx, order.Order, order.Items)
+		if err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		created = append(created, inserted)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "orders", len(orders))
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	r.analyzeAfterBulk(ctx, "create_orders", int64(len(created)))
+	return created, nil
+}
+
+// insertOrderWithItems inserts one order and its items inside tx and returns them with their IDs
+func insertOrderWithItems(ctx context.Context, tx *trackedTx, order models.Order, items []models.OrderItem) (models.OrderWithItems, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
 	// Insert order
-	insertOrderQuery := "INSERT INTO orders (customer_name, total_amount, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5) RETURNING id"
+	insertOrderQuery := "INSERT INTO orders (order_number, customer_name, total_amount, status, created_at, updated_at, public_id, user_id) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')) RETURNING id"
+
+	storedName, err := encryptCustomerName(order.CustomerName)
+	if err != nil {
+		return models.OrderWithItems{}, fmt.Errorf("failed to encrypt customer name: %w", err)
+	}
+
+	// The public ID is always written so switching to the uuid ID strategy needs no backfill
+	order.PublicID = models.NewOrderPublicID()
 
 	var insertedOrderID int
-	err = tx.QueryRow(ctx, insertOrderQuery, order.CustomerName, order.TotalAmount, order.Status, order.CreatedAt, order.UpdatedAt).Scan(&insertedOrderID)
+	err = tx.QueryRow(ctx, insertOrderQuery, order.OrderNumber, storedName, order.TotalAmount, order.Status, order.CreatedAt, order.UpdatedAt, order.PublicID, order.UserID).Scan(&insertedOrderID)
 
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to insert order", "customer", order.CustomerName)
-		return fmt.Errorf("failed to insert order: %w", err)
+		return models.OrderWithItems{}, fmt.Errorf("failed to insert order: %w", err)
 	}
 
-	// Insert order items
-	if len(items) > 0 {
-		insertItemsQuery := "INSERT INTO order_items (order_id, product_name, quantity, price, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6)"
+	createdItems, err := insertOrderItems(ctx, tx, insertedOrderID, items)
+	if err != nil {
+		return models.OrderWithItems{}, err
+	}
 
-		for i, item := range items {
-			_, err = tx.Exec(ctx, insertItemsQuery, insertedOrderID, item.ProductName, item.Quantity, item.Price, item.CreatedAt, item.UpdatedAt)
-			if err != nil {
-				repoLogger.WithError(err).Error("Failed to insert order item", "order_id", insertedOrderID, "product", item.ProductName, "index", i)
-				return fmt.Errorf("failed to insert order item: %w", err)
-			}
-		}
+	order.ID = insertedOrderID
+	return models.OrderWithItems{
+		Order: order,
+		Items: createdItems,
+	}, nil
+}
+
+// insertOrderItemsQuery inserts a list of items, of one or several orders, in one round trip. The
+// rows are inserted in array order so their serial IDs ascend in the order the items were given.
+const insertOrderItemsQuery = `INSERT INTO order_items (order_id, product_name, quantity, price, status, created_at, updated_at)
+	SELECT order_id, product_name, quantity, price, status, created_at, updated_at
+	FROM unnest($1::int[], $2::varchar[], $3::int[], $4::numeric[], $5::varchar[], $6::timestamp[], $7::timestamp[])
+		WITH ORDINALITY AS item(order_id, product_name, quantity, price, status, created_at, updated_at, position)
+	ORDER BY position
+	RETURNING id`
+
+// insertOrderItems inserts the items of an order inside tx and returns them with their IDs
+func insertOrderItems(ctx context.Context, tx *trackedTx, orderID int, items []models.OrderItem) ([]models.OrderItem, error) {
+	owned := make([]models.OrderItem, len(items))
+	for i, item := range items {
+		item.OrderID = orderID
+		owned[i] = item
 	}
+	return insertItems(ctx, tx, owned)
+}
 
-	// Commit transaction
-	if err = tx.Commit(ctx); err != nil {
-		repoLogger.WithError(err).Error("Failed to commit transaction", "order_id", insertedOrderID)
-		return fmt.Errorf("failed to commit transaction: %w", err)
+// insertItems inserts items, each already carrying its OrderID, inside tx and returns them with their IDs
+func insertItems(ctx context.Context, tx *trackedTx, items []models.OrderItem) ([]models.OrderItem, error) {
+	if len(items) == 0 {
+		return []models.OrderItem{}, nil
 	}
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
 
-	return nil
+	orderIDs := make([]int, len(items))
+	productNames := make([]string, len(items))
+	quantities := make([]int, len(items))
+	prices := make([]float64, len(items))
+	statuses := make([]string, len(items))
+	createdAts := make([]time.Time, len(items))
+	updatedAts := make([]time.Time, len(items))
+	for i, item := range items {
+		orderIDs[i] = item.OrderID
+		productNames[i] = item.ProductName
+		quantities[i] = item.Quantity
+		prices[i] = item.Price
+		statuses[i] = string(item.Status)
+		createdAts[i] = item.CreatedAt
+		updatedAts[i] = item.UpdatedAt
+	}
+
+	rows, err := tx.Query(ctx, insertOrderItemsQuery, orderIDs, productNames, quantities, prices, statuses, createdAts, updatedAts)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to insert order items", "order_id", orderIDs[0], "items", len(items))
+		return nil, fmt.Errorf("failed to insert order items: %w", err)
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to insert order items", "order_id", orderIDs[0], "items", len(items))
+		return nil, fmt.Errorf("failed to insert order items: %w", err)
+	}
+	if len(ids) != len(items) {
+		return nil, fmt.Errorf("failed to insert order items: %d of %d rows returned", len(ids), len(items))
+	}
+	// RETURNING order is not guaranteed, but the IDs were assigned in insert order
+	slices.Sort(ids)
+
+	createdItems := make([]models.OrderItem, 0, len(items))
+	for i, item := range items {
+		item.ID = ids[i]
+		createdItems = append(createdItems, item)
+	}
+	return createdItems, nil
 }
 
-func (r *OrderRepository) UpdateOrder(ctx context.Context, order models.Order) (err error) {
+// UpdateOrder writes the order's status and/or customer name. When precondition is set the write
+// only applies to that version of the order, and domain.ErrOrderModified is returned if the order
+// has changed since.
+func (r *OrderRepository) UpdateOrder(ctx context.Context, order models.Order, precondition models.OrderPrecondition) (err error) {
 	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := r.beginTx(ctx, "update_order")
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to begin transaction", "order_id", order.ID)
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err != nil {
-			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
 				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction", "order_id", order.ID)
 			}
+			err = translateWriteError(err)
 		}
 	}()
 
-	query := "UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3"
-	result, err := tx.Exec(ctx, query, order.Status, order.UpdatedAt, order.ID)
+	// status and customer_name are only written when set, so partial updates leave the other untouched
+	sets := []string{"updated_at = $1"}
+	args := []any{order.UpdatedAt}
+	if order.Status != "" {
+		args = append(args, order.Status)
+		sets = append(sets, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if order.CustomerName != "" {
+		storedName, encryptErr := encryptCustomerName(order.CustomerName)
+		if encryptErr != nil {
+			return fmt.Errorf("failed to encrypt customer name: %w", encryptErr)
+		}
+		args = append(args, storedName)
+		sets = append(sets, fmt.Sprintf("customer_name = $%d", len(args)))
+	}
+	args = append(args, order.ID)
+	conditions := []string{fmt.Sprintf("id = $%d", len(args))}
+	if !precondition.UpdatedAt.IsZero() {
+		args = append(args, precondition.UpdatedAt)
+		conditions = append(conditions, fmt.Sprintf("updated_at = $%d", len(args)))
+	}
+	if precondition.Status != "" {
+		args = append(args, precondition.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	query := fmt.Sprintf("UPDATE orders SET %s WHERE %s", strings.Join(sets, ", "), strings.Join(conditions, " AND "))
+	result, err := tx.Exec(ctx, query, args...)
 
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to update order", "order_id", order.ID)
@@ -241,6 +707,17 @@ func (r *OrderRepository) UpdateOrder(ctx context.Context, order models.Order) (
 
 	rowsAffected := result.RowsAffected()
 	if rowsAffected == 0 {
+		if len(conditions) > 1 {
+			var exists bool
+			if err = tx.QueryRow(ctx, "SELECT EXISTS (SELECT 1 FROM orders WHERE id = $1)", order.ID).Scan(&exists); err != nil {
+				repoLogger.WithError(err).Error("Failed to check order", "order_id", order.ID)
+				return fmt.Errorf("failed to check order: %w", err)
+			}
+			if exists {
+				repoLogger.Warn("Order changed before the update applied", "order_id", order.ID)
+				return fmt.Errorf("%w: order %d", domain.ErrOrderModified, order.ID)
+			}
+		}
 		repoLogger.Warn("Order not found", "order_id", order.ID)
 		return fmt.Errorf("order with ID %d not found", order.ID)
 	}
@@ -253,19 +730,117 @@ func (r *OrderRepository) UpdateOrder(ctx context.Context, order models.Order) (
 	return nil
 }
 
+// UpdateOrderItemStatus sets the status of one item of an order and re-derives the order status
+// from its items, in one transaction that locks the order so concurrent item updates and shipments
+// serialize on it. It returns pgx.ErrNoRows when the order or the item does not exist.
+func (r *OrderRepository) UpdateOrderItemStatus(ctx context.Context, orderID int, itemID int, status models.ItemStatus, updatedAt time.Time) (err error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	tx, err := r.beginTx(ctx, "update_item_status")
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to begin transaction", "order_id", orderID)
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction", "order_id", orderID)
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	var current models.Status
+	err = tx.QueryRow(ctx, "SELECT status FROM orders WHERE id = $1 FOR UPDATE", orderID).Scan(&current)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			repoLogger.Warn("Order not found", "order_id", orderID)
+			return err
+		}
+		repoLogger.WithError(err).Error("Failed to lock order", "order_id", orderID)
+		return fmt.Errorf("failed to lock order: %w", err)
+	}
+
+	// The shipped quantity follows the status so the order status, which is derived from
+	// quantities, agrees: shipped ships every unit and any other status leaves one unshipped
+	query := `UPDATE order_items
+		SET status = $1,
+			shipped_quantity = CASE WHEN $5 THEN quantity ELSE LEAST(shipped_quantity, quantity - 1) END,
+			updated_at = $2
+		WHERE id = $3 AND order_id = $4`
+	result, err := tx.Exec(ctx, query, status, updatedAt, itemID, orderID, status == models.ItemStatusShipped)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to update order item status", "order_id", orderID, "item_id", itemID)
+		return fmt.Errorf("failed to update order item status: %w", err)
+	}
+	if result.RowsAffected() == 0 {
+		repoLogger.Warn("Order item not found", "order_id", orderID, "item_id", itemID)
+		return pgx.ErrNoRows
+	}
+
+	if err = writeDerivedStatus(ctx, tx, orderID, current, updatedAt); err != nil {
+		return err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "order_id", orderID)
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// writeDerivedStatus reads the order's items inside tx and bumps the order's updated_at, so
+// conditional updates notice the item change, together with its status when the items imply
+// another one. The order must already be locked by tx.
+func writeDerivedStatus(ctx context.Context, tx *trackedTx, orderID int, current models.Status, updatedAt time.Time) error {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	rows, err := tx.Query(ctx, "SELECT quantity, shipped_quantity FROM order_items WHERE order_id = $1", orderID)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to read order items", "order_id", orderID)
+		return fmt.Errorf("failed to read order items: %w", err)
+	}
+	var items []models.OrderItem
+	for rows.Next() {
+		var item models.OrderItem
+		if err := rows.Scan(&item.Quantity, &item.ShippedQuantity); err != nil {
+			rows.Close()
+			return fmt.Errorf("failed to read order items: %w", err)
+		}
+		items = append(items, item)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Failed to read order items", "order_id", orderID)
+		return fmt.Errorf("failed to read order items: %w", err)
+	}
+
+	derived := models.DeriveOrderStatus(current, items)
+	if _, err := tx.Exec(ctx, "UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3", derived, updatedAt, orderID); err != nil {
+		repoLogger.WithError(err).Error("Failed to update derived order status", "order_id", orderID, "status", derived)
+		return fmt.Errorf("failed to update derived order status: %w", err)
+	}
+	if derived != current {
+		repoLogger.Info("Order status derived from items", "order_id", orderID, "from", current, "to", derived)
+	}
+	return nil
+}
+
 func (r *OrderRepository) DeleteOrder(ctx context.Context, id int) (err error) {
 	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
 
-	tx, err := r.db.Begin(ctx)
+	tx, err := r.beginTx(ctx, "delete_order")
 	if err != nil {
 		repoLogger.WithError(err).Error("Failed to begin transaction", "order_id", id)
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer func() {
 		if err != nil {
-			if rollbackErr := tx.Rollback(ctx); rollbackErr != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
 				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction", "order_id", id)
 			}
+			err = translateWriteError(err)
 		}
 	}()
 
@@ -298,3 +873,145 @@ func (r *OrderRepository) DeleteOrder(ctx context.Context, id int) (err error) {
 
 	return nil
 }
+
+// expirePendingOrdersQuery cancels stale pending orders and records each change in the status history
+const expirePendingOrdersQuery = `
+	WITH expired AS (
+		UPDATE orders SET status = $1, updated_at = $2
+		WHERE status = $3 AND created_at < $4
+		RETURNING id
+	)
+	INSERT INTO order_status_history (order_id, from_status, to_status, reason, changed_at)
+	SELECT id, $3, $1, 'expired', $2 FROM expired`
+
+// DeleteOrdersByFilter deletes matching orders and their items batchSize at a time, committing each
+// batch in its own transaction so a large cleanup never holds one huge transaction. Batches already
+// committed stay deleted if a later batch fails or ctx is cancelled; the returned count includes them.
+// Once done, planner statistics are refreshed in the background when SetAnalyzeAfterBulk allows it.
+func (r *OrderRepository) DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter, batchSize int) (int64, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	query, args := buildDeleteOrdersBatchQuery(filter, batchSize)
+
+	var deleted int64
+	defer func() { r.analyzeAfterBulk(ctx, "delete_orders_by_filter", deleted) }()
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		n, err := r.deleteOrdersBatch(ctx, query, args)
+		if err != nil {
+			repoLogger.WithError(err).Error("Failed to delete order batch", "deleted", deleted)
+			return deleted, err
+		}
+		deleted += n
+		repoLogger.Debug("Deleted order batch", "batch", n, "deleted", deleted)
+
+		if n < int64(batchSize) {
+			return deleted, nil
+		}
+	}
+}
+
+func (r *OrderRepository) deleteOrdersBatch(ctx context.Context, query string, args []any) (deleted int64, err error) {
+	tx, err := r.beginTx(ctx, "delete_orders_batch")
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				logger.LoggerWithRequestIDFromContext(ctx).WithError(rollbackErr).Error("Failed to rollback transaction")
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	result, err := tx.Exec(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete orders: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return result.RowsAffected(), nil
+}
+
+// buildDeleteOrdersBatchQuery returns a statement deleting up to batchSize orders matching filter,
+// together with their items. $1 is the batch size; filter values follow in field order.
+func buildDeleteOrdersBatchQuery(filter models.DeleteOrdersFilter, batchSize int) (string, []any) {
+	var conditions []string
+	args := []any{batchSize}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.CreatedBefore != nil {
+		// created_at holds UTC without a time zone and pgx binds a time's wall clock
+		args = append(args, filter.CreatedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	return `
+		WITH batch AS (
+			SELECT id FROM orders
+			WHERE ` + strings.Join(conditions, " AND ") + `
+			ORDER BY id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		), deleted_items AS (
+			DELETE FROM order_items WHERE order_id IN (SELECT id FROM batch)
+		)
+		DELETE FROM orders WHERE id IN (SELECT id FROM batch)`, args
+}
+
+func (r *OrderRepository) ExpirePendingOrders(ctx context.Context, createdBefore time.Time, expiredAt time.Time) (int64, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	result, err := r.db.Exec(ctx, expirePendingOrdersQuery, models.StatusCancelled, expiredAt, models.StatusPending, createdBefore)
+	recordWrite(err)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to expire pending orders", "created_before", createdBefore)
+		return 0, translateWriteError(fmt.Errorf("failed to expire pending orders: %w", err))
+	}
+
+	r.analyzeAfterBulk(ctx, "expire_pending_orders", result.RowsAffected())
+	return result.RowsAffected(), nil
+}
+
+// GetDailyTotals returns the order count and total amount per day for orders created in [from, to).
+// Days without orders are not returned.
+func (r *OrderRepository) GetDailyTotals(ctx context.Context, from time.Time, to time.Time) ([]models.DailyOrderTotal, error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	query := `
+		SELECT date_trunc('day', created_at)::date AS day, COUNT(*), COALESCE(SUM(total_amount), 0)
+		FROM orders
+		WHERE created_at >= $1 AND created_at < $2
+		GROUP BY day
+		ORDER BY day`
+
+	rows, err := r.db.Query(ctx, query, from, to)
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to query daily totals", "from", from, "to", to)
+		return nil, fmt.Errorf("failed to query daily totals: %w", err)
+	}
+	defer rows.Close()
+
+	var totals []models.DailyOrderTotal
+	for rows.Next() {
+		var total models.DailyOrderTotal
+		if err := rows.Scan(&total.Date, &total.Count, &total.Total); err != nil {
+			repoLogger.WithError(err).Error("Failed to scan daily total")
+			return nil, fmt.Errorf("failed to scan daily total: %w", err)
+		}
+		totals = append(totals, total)
+	}
+	if err := rows.Err(); err != nil {
+		repoLogger.WithError(err).Error("Error scanning daily totals")
+		return nil, fmt.Errorf("error scanning daily totals: %w", err)
+	}
+
+	return totals, nil
+}
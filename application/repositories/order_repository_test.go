@@ -0,0 +1,966 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// MockDatabase is a mock implementation of DatabaseInterface
+type MockDatabase struct {
+	mock.Mock
+}
+
+func (m *MockDatabase) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	called := m.Called(ctx, sql, args)
+	if called.Get(0) == nil {
+		return nil, called.Error(1)
+	}
+	return called.Get(0).(pgx.Rows), called.Error(1)
+}
+
+func (m *MockDatabase) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	called := m.Called(ctx, sql, args)
+	return called.Get(0).(pgx.Row)
+}
+
+func (m *MockDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	called := m.Called(ctx, sql, args)
+	return called.Get(0).(pgconn.CommandTag), called.Error(1)
+}
+
+func (m *MockDatabase) Begin(ctx context.Context) (pgx.Tx, error) {
+	called := m.Called(ctx)
+	if called.Get(0) == nil {
+		return nil, called.Error(1)
+	}
+	return called.Get(0).(pgx.Tx), called.Error(1)
+}
+
+func (m *MockDatabase) Close() {
+	m.Called()
+}
+
+// MockTx is a mock transaction. Methods that are not overridden panic through the nil embedded Tx.
+type MockTx struct {
+	pgx.Tx
+	mock.Mock
+}
+
+func (m *MockTx) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	called := m.Called(ctx, sql, args)
+	return called.Get(0).(pgconn.CommandTag), called.Error(1)
+}
+
+func (m *MockTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	called := m.Called(ctx, sql, args)
+	if called.Get(0) == nil {
+		return nil, called.Error(1)
+	}
+	return called.Get(0).(pgx.Rows), called.Error(1)
+}
+
+func (m *MockTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	called := m.Called(ctx, sql, args)
+	return called.Get(0).(pgx.Row)
+}
+
+func (m *MockTx) Commit(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+func (m *MockTx) Rollback(ctx context.Context) error {
+	return m.Called(ctx).Error(0)
+}
+
+// observeLogs installs a default logger that records entries at debug level and above
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.GetDefault()
+	logger.SetDefault(logger.New(zap.New(core)))
+	t.Cleanup(func() { logger.SetDefault(previous) })
+	return logs
+}
+
+func TestOrderRepository_UpdateOrder_LogsRollback(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := logger.WithRequestIDToContext(context.Background(), "req-123")
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("deadlock detected"))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing}, models.OrderPrecondition{})
+
+	// Assert
+	assert.Error(t, err)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+
+	rollbackLogs := logs.FilterMessage("Transaction rolled back").All()
+	if assert.Len(t, rollbackLogs, 1) {
+		fields := rollbackLogs[0].ContextMap()
+		assert.Equal(t, zapcore.DebugLevel, rollbackLogs[0].Level)
+		assert.Equal(t, "update_order", fields["operation"])
+		assert.Equal(t, "req-123", fields["request_id"])
+		assert.Contains(t, fields["error"], "deadlock detected")
+		assert.Contains(t, fields, "duration_ms")
+	}
+	assert.Len(t, logs.FilterMessage("Transaction begun").All(), 1)
+}
+
+func TestOrderRepository_ExpirePendingOrders_Success(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+
+	ctx := context.Background()
+	expiredAt := time.Now()
+	createdBefore := expiredAt.Add(-time.Hour)
+
+	mockDB.On("Exec", ctx, expirePendingOrdersQuery, []any{models.StatusCancelled, expiredAt, models.StatusPending, createdBefore}).
+		Return(pgconn.NewCommandTag("INSERT 0 2"), nil)
+
+	// Act
+	expired, err := repo.ExpirePendingOrders(ctx, createdBefore, expiredAt)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), expired)
+	assert.Contains(t, expirePendingOrdersQuery, "INSERT INTO order_status_history")
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_ExpirePendingOrders_Error(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+
+	ctx := context.Background()
+	mockDB.On("Exec", ctx, expirePendingOrdersQuery, mock.Anything).
+		Return(pgconn.CommandTag{}, errors.New("connection refused"))
+
+	// Act
+	expired, err := repo.ExpirePendingOrders(ctx, time.Now(), time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), expired)
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_ExpirePendingOrders_ReadOnly(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+
+	ctx := context.Background()
+	readOnlyErr := &pgconn.PgError{Code: "25006", Message: "cannot execute UPDATE in a read-only transaction"}
+	mockDB.On("Exec", ctx, expirePendingOrdersQuery, mock.Anything).
+		Return(pgconn.CommandTag{}, readOnlyErr)
+
+	// Act
+	_, err := repo.ExpirePendingOrders(ctx, time.Now(), time.Now())
+
+	// Assert
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, domain.ErrReadOnly)
+	mockDB.AssertExpectations(t)
+}
+
+func TestBuildListOrdersQuery_Default(t *testing.T) {
+	query, args := buildListOrdersQuery(models.ListInput{Page: 2, Size: 10}, 10)
+
+	assert.Contains(t, query, "ORDER BY created_at DESC, id DESC")
+	assert.NotContains(t, query, "WHERE updated_at")
+	assert.Equal(t, []any{10, 10}, args)
+}
+
+func TestBuildListOrdersQuery_UpdatedSince(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	query, args := buildListOrdersQuery(models.ListInput{Page: 1, Size: 50, UpdatedSince: &since}, 0)
+
+	assert.Contains(t, query, "WHERE updated_at > $3")
+	assert.Contains(t, query, "ORDER BY updated_at ASC, id ASC")
+	assert.Equal(t, []any{50, 0, since}, args)
+}
+
+func TestBuildListOrdersQuery_UpdatedSinceWithOffsetBindsUTC(t *testing.T) {
+	since := time.Date(2025, 1, 2, 10, 4, 5, 0, time.FixedZone("ICT", 7*60*60))
+
+	_, args := buildListOrdersQuery(models.ListInput{Page: 1, Size: 50, UpdatedSince: &since}, 0)
+
+	bound, ok := args[2].(time.Time)
+	if assert.True(t, ok) {
+		assert.Equal(t, time.UTC, bound.Location())
+		assert.Equal(t, time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC), bound)
+	}
+}
+
+func TestBuildListOrdersQuery_Cursor(t *testing.T) {
+	last := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	query, args := buildListOrdersQuery(models.ListInput{Size: 10, After: &models.ListCursor{Sort: models.SortCreatedDesc, LastTime: last, LastID: 7}}, 0)
+
+	assert.Contains(t, query, "WHERE (created_at, id) < ($3, $4)")
+	assert.Contains(t, query, "ORDER BY created_at DESC, id DESC")
+	assert.Equal(t, []any{10, 0, last, 7}, args)
+}
+
+func TestBuildListOrdersQuery_CursorWithUpdatedSince(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	last := since.Add(time.Hour)
+	cursor := &models.ListCursor{Sort: models.SortUpdatedAsc, UpdatedSince: &since, LastTime: last, LastID: 7}
+
+	query, args := buildListOrdersQuery(models.ListInput{Size: 10, UpdatedSince: &since, After: cursor}, 0)
+
+	assert.Contains(t, query, "WHERE updated_at > $3 AND (updated_at, id) > ($4, $5)")
+	assert.Contains(t, query, "ORDER BY updated_at ASC, id ASC")
+	assert.Equal(t, []any{10, 0, since, last, 7}, args)
+}
+
+func TestBuildListOrdersQuery_Statuses(t *testing.T) {
+	query, args := buildListOrdersQuery(models.ListInput{Size: 10, UserID: "user-42", Statuses: []models.Status{models.StatusPending, models.StatusProcessing}}, 20)
+
+	assert.Contains(t, query, "WHERE user_id = $3 AND status = ANY($4)")
+	assert.Equal(t, []any{10, 20, "user-42", []string{"pending", "processing"}}, args)
+}
+
+func TestBuildListOrdersQuery_UserID(t *testing.T) {
+	last := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	cursor := &models.ListCursor{Sort: models.SortCreatedDesc, UserID: "user-42", LastTime: last, LastID: 7}
+
+	query, args := buildListOrdersQuery(models.ListInput{Size: 10, UserID: "user-42", After: cursor}, 0)
+
+	assert.Contains(t, query, "WHERE (created_at, id) < ($3, $4) AND user_id = $5")
+	assert.Contains(t, query, "ORDER BY created_at DESC, id DESC")
+	assert.Equal(t, []any{10, 0, last, 7, "user-42"}, args)
+}
+
+// orderByClause returns the ORDER BY clause of a query that ends with ORDER BY ... LIMIT ... OFFSET ...
+func orderByClause(t *testing.T, query string) string {
+	_, clause, found := strings.Cut(query, "ORDER BY ")
+	if !assert.True(t, found, "query has no ORDER BY") {
+		return ""
+	}
+	clause, _, found = strings.Cut(clause, "\n")
+	assert.True(t, found)
+	return strings.TrimSpace(clause)
+}
+
+func TestBuildListOrdersQuery_StablePaginationForEqualTimestamps(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		input   models.ListInput
+		orderBy string
+	}{
+		{name: "created_at", input: models.ListInput{Page: 1, Size: 10}, orderBy: "created_at DESC, id DESC"},
+		{name: "created_at cursor", input: models.ListInput{Size: 10, After: &models.ListCursor{Sort: models.SortCreatedDesc, LastTime: since, LastID: 7}}, orderBy: "created_at DESC, id DESC"},
+		{name: "updated_since", input: models.ListInput{Page: 1, Size: 10, UpdatedSince: &since}, orderBy: "updated_at ASC, id ASC"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			query, _ := buildListOrdersQuery(tt.input, 0)
+
+			// Assert: id is the last sort key, so orders sharing a timestamp never swap between pages
+			assert.Equal(t, tt.orderBy, orderByClause(t, query))
+			assert.True(t, strings.HasSuffix(query, "LIMIT $1 OFFSET $2"), "nothing follows the ORDER BY but the page bounds")
+		})
+	}
+}
+
+func TestBuildDeleteOrdersBatchQuery_Filters(t *testing.T) {
+	createdBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		filter     models.DeleteOrdersFilter
+		conditions string
+		args       []any
+	}{
+		{name: "status", filter: models.DeleteOrdersFilter{Status: models.StatusCancelled}, conditions: "WHERE status = $2\n", args: []any{100, models.StatusCancelled}},
+		{name: "created_before", filter: models.DeleteOrdersFilter{CreatedBefore: &createdBefore}, conditions: "WHERE created_at < $2\n", args: []any{100, createdBefore}},
+		{name: "both", filter: models.DeleteOrdersFilter{Status: models.StatusCancelled, CreatedBefore: &createdBefore}, conditions: "WHERE status = $2 AND created_at < $3\n", args: []any{100, models.StatusCancelled, createdBefore}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			query, args := buildDeleteOrdersBatchQuery(tt.filter, 100)
+
+			assert.Contains(t, query, tt.conditions)
+			assert.Contains(t, query, "LIMIT $1")
+			assert.Contains(t, query, "DELETE FROM order_items WHERE order_id IN (SELECT id FROM batch)")
+			assert.Equal(t, tt.args, args)
+		})
+	}
+}
+
+func TestBuildDeleteOrdersBatchQuery_CreatedBeforeWithOffsetBindsUTC(t *testing.T) {
+	// Arrange
+	createdBefore := time.Date(2024, 1, 1, 0, 0, 0, 0, time.FixedZone("+07:00", 7*60*60))
+
+	// Act
+	_, args := buildDeleteOrdersBatchQuery(models.DeleteOrdersFilter{CreatedBefore: &createdBefore}, 100)
+
+	// Assert
+	assert.Equal(t, []any{100, time.Date(2023, 12, 31, 17, 0, 0, 0, time.UTC)}, args)
+}
+
+func TestOrderRepository_DeleteOrdersByFilter_Batches(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	filter := models.DeleteOrdersFilter{Status: models.StatusCancelled}
+
+	// Two full batches then a partial one
+	for _, deleted := range []string{"DELETE 2", "DELETE 2", "DELETE 1"} {
+		mockTx := &MockTx{}
+		mockTx.On("Exec", ctx, mock.Anything, []any{2, models.StatusCancelled}).Return(pgconn.NewCommandTag(deleted), nil)
+		mockTx.On("Commit", ctx).Return(nil)
+		mockDB.On("Begin", ctx).Return(mockTx, nil).Once()
+	}
+
+	// Act
+	deleted, err := repo.DeleteOrdersByFilter(ctx, filter, 2)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), deleted)
+	mockDB.AssertNumberOfCalls(t, "Begin", 3)
+}
+
+func TestOrderRepository_DeleteOrdersByFilter_StopsOnError(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	firstTx, failingTx := &MockTx{}, &MockTx{}
+	firstTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 2"), nil)
+	firstTx.On("Commit", ctx).Return(nil)
+	failingTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("connection reset"))
+	failingTx.On("Rollback", ctx).Return(nil)
+	mockDB.On("Begin", ctx).Return(firstTx, nil).Once()
+	mockDB.On("Begin", ctx).Return(failingTx, nil).Once()
+
+	// Act
+	deleted, err := repo.DeleteOrdersByFilter(ctx, models.DeleteOrdersFilter{Status: models.StatusCancelled}, 2)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int64(2), deleted)
+	failingTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_GetOrderById_ItemsQueryFails(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	orderRows := &fakeRows{rows: [][]any{{7, "ORD-20250601-K7QX2M", "Jane", 10.0, models.StatusPending, created, created}}}
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), []any{[]int{7}}).Return(orderRows, nil)
+	mockDB.On("Query", ctx, sqlContaining("FROM order_items"), []any{[]int{7}}).Return(nil, errors.New("statement timeout"))
+
+	// Act
+	order, err := repo.GetOrderById(ctx, 7)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrItemsUnavailable)
+	assert.ErrorContains(t, err, "statement timeout")
+	assert.Equal(t, 7, order.ID)
+	assert.Equal(t, "Jane", order.CustomerName)
+}
+
+func TestOrderRepository_GetOrdersByIds(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	orderRows := &fakeRows{rows: [][]any{
+		{3, "ORD-20250601-AAAAAA", "Jane", 10.0, models.StatusPending, created, created},
+		{5, "ORD-20250601-BBBBBB", "John", 20.0, models.StatusPending, created, created},
+	}}
+	itemRows := &fakeRows{rows: [][]any{
+		{30, 3, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created},
+		{50, 5, "Gadget", 1, 10.0, models.ItemStatusPending, 0, created, created},
+		{51, 5, "Gizmo", 1, 10.0, models.ItemStatusPending, 0, created, created},
+	}}
+	mockDB.On("Query", ctx, sqlContaining("WHERE id = ANY($1)"), []any{[]int{3, 4, 5}}).Return(orderRows, nil).Once()
+	mockDB.On("Query", ctx, sqlContaining("ORDER BY id"), []any{[]int{3, 5}}).Return(itemRows, nil).Once()
+
+	// Act
+	orders, err := repo.GetOrdersByIds(ctx, []int{3, 4, 5})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, orders, 2)
+	assert.NotContains(t, orders, 4)
+	assert.Equal(t, "Jane", orders[3].CustomerName)
+	if assert.Len(t, orders[5].Items, 2) {
+		assert.Equal(t, 50, orders[5].Items[0].ID)
+		assert.Equal(t, 51, orders[5].Items[1].ID)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_GetOrdersByIds_EmptyIDs(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+
+	// Act
+	orders, err := repo.GetOrdersByIds(context.Background(), nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, orders)
+	mockDB.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderRepository_GetOrderById_Missing(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), []any{[]int{404}}).Return(&fakeRows{}, nil)
+
+	// Act
+	_, err := repo.GetOrderById(ctx, 404)
+
+	// Assert
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockDB.AssertNumberOfCalls(t, "Query", 1)
+}
+
+func TestOrderRepository_RecentOrders(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rows := &fakeRows{rows: [][]any{
+		{9, "ORD-20250601-000009", "Jane", 10.0, models.StatusPending, created.Add(time.Minute), created},
+		{8, "ORD-20250601-000008", "Jane", 10.0, models.StatusPending, created, created},
+		{7, "ORD-20250601-000007", "Jane", 10.0, models.StatusPending, created, created},
+	}}
+	mockDB.On("Query", ctx, mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, "ORDER BY created_at DESC, id DESC") &&
+			strings.Contains(query, "LIMIT $1") &&
+			!strings.Contains(query, "COUNT(*)")
+	}), []any{3}).Return(rows, nil)
+
+	// Act
+	orders, err := repo.RecentOrders(ctx, 3)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, orders, 3)
+	assert.Equal(t, []int{9, 8, 7}, []int{orders[0].ID, orders[1].ID, orders[2].ID})
+	mockDB.AssertExpectations(t)
+}
+
+// errRow is a pgx.Row whose Scan fails with err
+type errRow struct{ err error }
+
+func (r errRow) Scan(dest ...any) error { return r.err }
+
+func TestOrderRepository_CreateOrders_RollsBackOnFailure(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	orders := []models.OrderWithItems{
+		{Order: models.Order{OrderNumber: "ORD-1", CustomerName: "Alice"}},
+		{Order: models.Order{OrderNumber: "ORD-2", CustomerName: "Bob"}},
+	}
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, mock.Anything, mock.MatchedBy(func(args []any) bool { return args[0] == "ORD-1" })).Return(row(1))
+	mockTx.On("QueryRow", ctx, mock.Anything, mock.MatchedBy(func(args []any) bool { return args[0] == "ORD-2" })).
+		Return(errRow{err: errors.New("duplicate key value violates unique constraint")})
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	created, err := repo.CreateOrders(ctx, orders)
+
+	// Assert
+	assert.ErrorContains(t, err, "order 1: failed to insert order: duplicate key")
+	assert.Nil(t, created)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestOrderRepository_UpdateOrder_WritesOnlySetFields(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name          string
+		order         models.Order
+		expectedQuery string
+		expectedArgs  []any
+	}{
+		{
+			name:          "status only",
+			order:         models.Order{ID: 1, Status: models.StatusProcessing, UpdatedAt: updatedAt},
+			expectedQuery: "UPDATE orders SET updated_at = $1, status = $2 WHERE id = $3",
+			expectedArgs:  []any{updatedAt, models.StatusProcessing, 1},
+		},
+		{
+			name:          "customer name only",
+			order:         models.Order{ID: 1, CustomerName: "Jane", UpdatedAt: updatedAt},
+			expectedQuery: "UPDATE orders SET updated_at = $1, customer_name = $2 WHERE id = $3",
+			expectedArgs:  []any{updatedAt, "Jane", 1},
+		},
+		{
+			name:          "both",
+			order:         models.Order{ID: 1, Status: models.StatusCancelled, CustomerName: "Jane", UpdatedAt: updatedAt},
+			expectedQuery: "UPDATE orders SET updated_at = $1, status = $2, customer_name = $3 WHERE id = $4",
+			expectedArgs:  []any{updatedAt, models.StatusCancelled, "Jane", 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockDB := &MockDatabase{}
+			mockTx := &MockTx{}
+			repo := NewOrderRepository(mockDB)
+			ctx := context.Background()
+
+			mockDB.On("Begin", ctx).Return(mockTx, nil)
+			mockTx.On("Exec", ctx, tt.expectedQuery, tt.expectedArgs).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+			mockTx.On("Commit", ctx).Return(nil)
+
+			// Act
+			err := repo.UpdateOrder(ctx, tt.order, models.OrderPrecondition{})
+
+			// Assert
+			assert.NoError(t, err)
+			mockTx.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderRepository_UpdateOrder_Precondition(t *testing.T) {
+	updatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	readAt := time.Date(2025, 6, 1, 11, 0, 0, 0, time.UTC)
+	query := "UPDATE orders SET updated_at = $1, status = $2 WHERE id = $3 AND updated_at = $4"
+	args := []any{updatedAt, models.StatusProcessing, 1, readAt}
+
+	t.Run("applies to the version read", func(t *testing.T) {
+		// Arrange
+		mockDB := &MockDatabase{}
+		mockTx := &MockTx{}
+		repo := NewOrderRepository(mockDB)
+		ctx := context.Background()
+
+		mockDB.On("Begin", ctx).Return(mockTx, nil)
+		mockTx.On("Exec", ctx, query, args).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+		mockTx.On("Commit", ctx).Return(nil)
+
+		// Act
+		err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing, UpdatedAt: updatedAt}, models.OrderPrecondition{UpdatedAt: readAt})
+
+		// Assert
+		assert.NoError(t, err)
+		mockTx.AssertExpectations(t)
+	})
+
+	t.Run("order changed since", func(t *testing.T) {
+		// Arrange
+		mockDB := &MockDatabase{}
+		mockTx := &MockTx{}
+		repo := NewOrderRepository(mockDB)
+		ctx := context.Background()
+
+		mockDB.On("Begin", ctx).Return(mockTx, nil)
+		mockTx.On("Exec", ctx, query, args).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+		mockTx.On("QueryRow", ctx, sqlContaining("SELECT EXISTS"), []any{1}).Return(row(true))
+		mockTx.On("Rollback", ctx).Return(nil)
+
+		// Act
+		err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing, UpdatedAt: updatedAt}, models.OrderPrecondition{UpdatedAt: readAt})
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrOrderModified)
+		mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+	})
+}
+
+func TestOrderRepository_CreateOrder_WritesPublicID(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	var insertedPublicID string
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("public_id"), mock.MatchedBy(func(args []any) bool {
+		insertedPublicID, _ = args[6].(string)
+		return true
+	})).Return(row(9))
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	created, err := repo.CreateOrder(ctx, models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 9, created.ID)
+	_, isUUID := models.ParseOrderPublicID(insertedPublicID)
+	assert.True(t, isUUID)
+	assert.Equal(t, insertedPublicID, created.PublicID)
+}
+
+func TestOrderRepository_CreateOrder_WritesOwner(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	var insertedOwner any
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("NULLIF($8, '')"), mock.MatchedBy(func(args []any) bool {
+		insertedOwner = args[7]
+		return true
+	})).Return(row(9))
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	created, err := repo.CreateOrder(ctx, models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane", UserID: "user-42"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "user-42", insertedOwner)
+	assert.Equal(t, "user-42", created.UserID)
+}
+
+// idRows returns rows holding one ID each, as returned by an INSERT ... RETURNING id
+func idRows(ids ...int) *fakeRows {
+	rows := &fakeRows{}
+	for _, id := range ids {
+		rows.rows = append(rows.rows, []any{id})
+	}
+	return rows
+}
+
+func TestOrderRepository_CreateOrder_InsertsItemsInOneQuery(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := []models.OrderItem{
+		{ProductName: "Widget", Quantity: 2, Price: 10, Status: models.ItemStatusPending, CreatedAt: now, UpdatedAt: now},
+		{ProductName: "Gadget", Quantity: 1, Price: 5.5, Status: models.ItemStatusPending, CreatedAt: now, UpdatedAt: now},
+	}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("INSERT INTO orders"), mock.Anything).Return(row(9))
+	mockTx.On("Query", ctx, insertOrderItemsQuery, []any{
+		[]int{9, 9},
+		[]string{"Widget", "Gadget"},
+		[]int{2, 1},
+		[]float64{10, 5.5},
+		[]string{"pending", "pending"},
+		[]time.Time{now, now},
+		[]time.Time{now, now},
+	}).Return(idRows(41, 40), nil).Once()
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	created, err := repo.CreateOrder(ctx, models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane"}, items)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, created.Items, 2) {
+		assert.Equal(t, 40, created.Items[0].ID)
+		assert.Equal(t, "Widget", created.Items[0].ProductName)
+		assert.Equal(t, 41, created.Items[1].ID)
+		assert.Equal(t, 9, created.Items[1].OrderID)
+	}
+	mockTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_CreateOrder_RollsBackWhenItemsFail(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("INSERT INTO orders"), mock.Anything).Return(row(9))
+	mockTx.On("Query", ctx, insertOrderItemsQuery, mock.Anything).Return(nil, errors.New("value too long for type character varying(100)"))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	_, err := repo.CreateOrder(ctx, models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane"}, []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 1}})
+
+	// Assert
+	assert.ErrorContains(t, err, "failed to insert order items")
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestOrderRepository_GetOrderIDByPublicID(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	publicID := "3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b"
+	mockDB.On("QueryRow", ctx, "SELECT id FROM orders WHERE public_id = $1", []any{publicID}).Return(row(42))
+	mockDB.On("QueryRow", ctx, "SELECT id FROM orders WHERE public_id = $1", mock.Anything).Return(errRow{err: pgx.ErrNoRows})
+
+	// Act
+	id, err := repo.GetOrderIDByPublicID(ctx, publicID)
+	_, missingErr := repo.GetOrderIDByPublicID(ctx, "00000000-0000-4000-8000-000000000000")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 42, id)
+	assert.ErrorIs(t, missingErr, pgx.ErrNoRows)
+}
+
+// useItemsQueryChunkSize sets the list items chunk size for the duration of the test
+func useItemsQueryChunkSize(t *testing.T, size int) {
+	previous := int(itemsQueryChunkSize.Load())
+	SetItemsQueryChunkSize(size)
+	t.Cleanup(func() { SetItemsQueryChunkSize(previous) })
+}
+
+func TestOrderRepository_ListOrders_ChunksItemsQuery(t *testing.T) {
+	// Arrange
+	useItemsQueryChunkSize(t, 2)
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := &fakeRows{}
+	for id := 1; id <= 5; id++ {
+		orders.rows = append(orders.rows, []any{5, id, fmt.Sprintf("ORD-%d", id), "Jane", 10.0, models.StatusPending, created, created})
+	}
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), mock.Anything).Return(orders, nil)
+
+	// Every order has two items, returned by the chunk that holds its ID
+	var itemQueries [][]int
+	for _, chunk := range [][]int{{1, 2}, {3, 4}, {5}} {
+		items := &fakeRows{}
+		for _, orderID := range chunk {
+			for n := 0; n < 2; n++ {
+				items.rows = append(items.rows, []any{orderID*10 + n, orderID, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created})
+			}
+		}
+		mockDB.On("Query", ctx, sqlContaining("FROM order_items"), []any{chunk}).
+			Run(func(args mock.Arguments) { itemQueries = append(itemQueries, args.Get(2).([]any)[0].([]int)) }).
+			Return(items, nil).Once()
+	}
+
+	// Act
+	result, err := repo.ListOrders(ctx, models.ListInput{Page: 1, Size: 5})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, [][]int{{1, 2}, {3, 4}, {5}}, itemQueries)
+	if assert.Len(t, result.Data, 5) {
+		for i, order := range result.Data {
+			assert.Equal(t, i+1, order.ID)
+			if assert.Len(t, order.Items, 2) {
+				assert.Equal(t, order.ID*10, order.Items[0].ID)
+				assert.Equal(t, order.ID*10+1, order.Items[1].ID)
+			}
+		}
+	}
+	assert.Equal(t, 5, result.Total)
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_ListOrders_CapsItemsPerOrderInSQL(t *testing.T) {
+	// Arrange
+	previous := int(listItemLimit.Load())
+	SetListItemLimit(2)
+	t.Cleanup(func() { SetListItemLimit(previous) })
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	orders := &fakeRows{rows: [][]any{
+		{2, 1, "ORD-1", "Jane", 10.0, models.StatusPending, created, created},
+		{2, 2, "ORD-2", "Jane", 10.0, models.StatusPending, created, created},
+	}}
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), mock.Anything).Return(orders, nil)
+
+	// Order 1 has five items of which the database returns two, order 2 has exactly two
+	items := &fakeRows{rows: [][]any{
+		{10, 1, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created, 5},
+		{11, 1, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created, 5},
+		{20, 2, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created, 2},
+		{21, 2, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created, 2},
+	}}
+	var itemsQuery string
+	mockDB.On("Query", ctx, sqlContaining("FROM order_items"), []any{[]int{1, 2}, 2}).
+		Run(func(args mock.Arguments) { itemsQuery = args.String(1) }).
+		Return(items, nil).Once()
+
+	// Act
+	result, err := repo.ListOrders(ctx, models.ListInput{Page: 1, Size: 2})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Contains(t, itemsQuery, "ROW_NUMBER() OVER (PARTITION BY order_id ORDER BY id)")
+	assert.Contains(t, itemsQuery, "WHERE item_number <= $2")
+	if assert.Len(t, result.Data, 2) {
+		large, small := result.Data[0], result.Data[1]
+		assert.Len(t, large.Items, 2)
+		assert.True(t, large.MoreItems)
+		assert.Equal(t, 5, large.ItemCount)
+		assert.Len(t, small.Items, 2)
+		assert.False(t, small.MoreItems)
+		assert.Zero(t, small.ItemCount)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+// roundTripTx is a transaction whose queries each wait for a simulated network round trip
+type roundTripTx struct {
+	pgx.Tx
+	latency time.Duration
+	nextID  int
+}
+
+func (t *roundTripTx) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	time.Sleep(t.latency)
+	t.nextID++
+	return row(t.nextID)
+}
+
+func (t *roundTripTx) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	time.Sleep(t.latency)
+	rows := &fakeRows{}
+	for range len(args[1].([]string)) {
+		t.nextID++
+		rows.rows = append(rows.rows, []any{t.nextID})
+	}
+	return rows, nil
+}
+
+// insertOrderItemsPerRow is the previous item insert, one statement per item, kept as the
+// benchmark baseline
+func insertOrderItemsPerRow(ctx context.Context, tx *trackedTx, orderID int, items []models.OrderItem) ([]models.OrderItem, error) {
+	insertItemQuery := "INSERT INTO order_items (order_id, product_name, quantity, price, status, created_at, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7) RETURNING id"
+
+	createdItems := make([]models.OrderItem, 0, len(items))
+	for _, item := range items {
+		if err := tx.QueryRow(ctx, insertItemQuery, orderID, item.ProductName, item.Quantity, item.Price, item.Status, item.CreatedAt, item.UpdatedAt).Scan(&item.ID); err != nil {
+			return nil, err
+		}
+		item.OrderID = orderID
+		createdItems = append(createdItems, item)
+	}
+	return createdItems, nil
+}
+
+// BenchmarkInsertOrderItems compares inserting an order of 100 items row by row with the single
+// unnest insert, with every query paying a 1ms round trip
+func BenchmarkInsertOrderItems(b *testing.B) {
+	items := make([]models.OrderItem, 100)
+	for i := range items {
+		items[i] = models.OrderItem{ProductName: fmt.Sprintf("Product %d", i), Quantity: 1, Price: 9.99, Status: models.ItemStatusPending}
+	}
+	inserts := map[string]func(context.Context, *trackedTx, int, []models.OrderItem) ([]models.OrderItem, error){
+		"per_row": insertOrderItemsPerRow,
+		"unnest":  insertOrderItems,
+	}
+
+	ctx := context.Background()
+	for _, name := range []string{"per_row", "unnest"} {
+		b.Run(name, func(b *testing.B) {
+			tx := &trackedTx{Tx: &roundTripTx{latency: time.Millisecond}}
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := inserts[name](ctx, tx, 1, items); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestOrderRepository_UpdateOrderItemStatus_DerivesOrderStatusInTransaction(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	updatedAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	items := &fakeRows{rows: [][]any{{1, 1}, {2, 2}}}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("FOR UPDATE"), []any{5}).Return(row(models.StatusProcessing))
+	mockTx.On("Exec", ctx, sqlContaining("UPDATE order_items"), []any{models.ItemStatusShipped, updatedAt, 11, 5, true}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	mockTx.On("Query", ctx, sqlContaining("FROM order_items"), []any{5}).Return(items, nil)
+	mockTx.On("Exec", ctx, sqlContaining("UPDATE orders"), []any{models.StatusCompleted, updatedAt, 5}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrderItemStatus(ctx, 5, 11, models.ItemStatusShipped, updatedAt)
+
+	// Assert
+	assert.NoError(t, err)
+	mockTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_UpdateOrderItemStatus_MissingItemRollsBack(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("FOR UPDATE"), []any{5}).Return(row(models.StatusProcessing))
+	mockTx.On("Exec", ctx, sqlContaining("UPDATE order_items"), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrderItemStatus(ctx, 5, 11, models.ItemStatusShipped, time.Now())
+
+	// Assert
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockTx.AssertExpectations(t)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+}
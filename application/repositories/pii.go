@@ -0,0 +1,126 @@
+package repositories
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// encryptedPrefix marks a stored value as ciphertext; it is followed by the key version and a colon,
+// e.g. "enc:v2:<base64>". Values without it are legacy plaintext and are returned unchanged.
+const encryptedPrefix = "enc:v"
+
+// PIICipher encrypts personal data with AES-256-GCM. Every key version it holds can decrypt,
+// new values are always written with the current one so keys can be rotated without rewriting rows.
+type PIICipher struct {
+	aeads   map[int]cipher.AEAD
+	current int
+	encrypt bool
+}
+
+// NewPIICipher builds a cipher from base64 encoded 32-byte keys indexed by version. When encrypt is
+// false values are still decrypted on read but written as plaintext.
+func NewPIICipher(keys map[string]string, current int, encrypt bool) (*PIICipher, error) {
+	c := &PIICipher{aeads: make(map[int]cipher.AEAD, len(keys)), current: current, encrypt: encrypt}
+	for versionKey, encoded := range keys {
+		version, err := strconv.Atoi(versionKey)
+		if err != nil || version < 1 {
+			return nil, fmt.Errorf("invalid PII key version %q", versionKey)
+		}
+		key, err := base64.StdEncoding.DecodeString(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("PII key %d is not valid base64: %w", version, err)
+		}
+		if len(key) != 32 {
+			return nil, fmt.Errorf("PII key %d must be 32 bytes, got %d", version, len(key))
+		}
+		block, err := aes.NewCipher(key)
+		if err != nil {
+			return nil, fmt.Errorf("PII key %d: %w", version, err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			return nil, fmt.Errorf("PII key %d: %w", version, err)
+		}
+		c.aeads[version] = aead
+	}
+	if _, ok := c.aeads[current]; encrypt && !ok {
+		return nil, fmt.Errorf("no PII key configured for current version %d", current)
+	}
+	return c, nil
+}
+
+// Encrypt returns the stored form of value, or value itself when encryption is off
+func (c *PIICipher) Encrypt(value string) (string, error) {
+	if !c.encrypt || value == "" {
+		return value, nil
+	}
+	aead := c.aeads[c.current]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, []byte(value), nil)
+	return encryptedPrefix + strconv.Itoa(c.current) + ":" + base64.RawStdEncoding.EncodeToString(sealed), nil
+}
+
+// Decrypt returns the plaintext of a stored value. Legacy plaintext rows pass through unchanged.
+func (c *PIICipher) Decrypt(stored string) (string, error) {
+	rest, ok := strings.CutPrefix(stored, encryptedPrefix)
+	if !ok {
+		return stored, nil
+	}
+	versionPart, payload, ok := strings.Cut(rest, ":")
+	version, err := strconv.Atoi(versionPart)
+	if !ok || err != nil {
+		return "", errors.New("malformed encrypted value")
+	}
+	aead, ok := c.aeads[version]
+	if !ok {
+		return "", fmt.Errorf("no PII key configured for version %d", version)
+	}
+	sealed, err := base64.RawStdEncoding.DecodeString(payload)
+	if err != nil || len(sealed) < aead.NonceSize() {
+		return "", errors.New("malformed encrypted value")
+	}
+	plaintext, err := aead.Open(nil, sealed[:aead.NonceSize()], sealed[aead.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt value with key %d: %w", version, err)
+	}
+	return string(plaintext), nil
+}
+
+// piiCipher is the cipher applied to customer names, nil stores and reads them as plaintext
+var piiCipher atomic.Pointer[PIICipher]
+
+// SetPIICipher sets the cipher used for customer names at rest, nil disables it
+func SetPIICipher(c *PIICipher) {
+	piiCipher.Store(c)
+}
+
+// encryptCustomerName returns the value to write to orders.customer_name
+func encryptCustomerName(name string) (string, error) {
+	c := piiCipher.Load()
+	if c == nil {
+		return name, nil
+	}
+	return c.Encrypt(name)
+}
+
+// decryptCustomerName returns the customer name read from orders.customer_name
+func decryptCustomerName(stored string) (string, error) {
+	c := piiCipher.Load()
+	if c == nil {
+		if strings.HasPrefix(stored, encryptedPrefix) {
+			return "", errors.New("customer name is encrypted but no PII keys are configured")
+		}
+		return stored, nil
+	}
+	return c.Decrypt(stored)
+}
@@ -0,0 +1,213 @@
+package repositories
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var (
+	testPIIKey1 = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("k", 32)))
+	testPIIKey2 = base64.StdEncoding.EncodeToString([]byte(strings.Repeat("r", 32)))
+)
+
+// usePIICipher installs a cipher for the duration of the test
+func usePIICipher(t *testing.T, c *PIICipher) {
+	previous := piiCipher.Load()
+	SetPIICipher(c)
+	t.Cleanup(func() { SetPIICipher(previous) })
+}
+
+func TestPIICipher_RoundTrip(t *testing.T) {
+	// Arrange
+	c, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+
+	// Act
+	stored, err := c.Encrypt("Jane Doe")
+	assert.NoError(t, err)
+	plaintext, decryptErr := c.Decrypt(stored)
+
+	// Assert
+	assert.True(t, strings.HasPrefix(stored, "enc:v1:"))
+	assert.NotContains(t, stored, "Jane")
+	assert.NoError(t, decryptErr)
+	assert.Equal(t, "Jane Doe", plaintext)
+}
+
+func TestPIICipher_LegacyPlaintextPassesThrough(t *testing.T) {
+	// Arrange
+	c, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+
+	// Act
+	plaintext, err := c.Decrypt("Jane Doe")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", plaintext)
+}
+
+func TestPIICipher_KeyRotation(t *testing.T) {
+	// Arrange
+	old, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+	storedWithOld, err := old.Encrypt("Jane Doe")
+	assert.NoError(t, err)
+
+	rotated, err := NewPIICipher(map[string]string{"1": testPIIKey1, "2": testPIIKey2}, 2, true)
+	assert.NoError(t, err)
+
+	// Act
+	storedWithNew, encryptErr := rotated.Encrypt("Jane Doe")
+	oldPlaintext, oldErr := rotated.Decrypt(storedWithOld)
+
+	// Assert
+	assert.NoError(t, encryptErr)
+	assert.True(t, strings.HasPrefix(storedWithNew, "enc:v2:"))
+	assert.NoError(t, oldErr)
+	assert.Equal(t, "Jane Doe", oldPlaintext)
+
+	_, err = old.Decrypt(storedWithNew)
+	assert.ErrorContains(t, err, "no PII key configured for version 2")
+}
+
+func TestPIICipher_DecryptOnlyWhenDisabled(t *testing.T) {
+	// Arrange
+	writer, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+	stored, err := writer.Encrypt("Jane Doe")
+	assert.NoError(t, err)
+	reader, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, false)
+	assert.NoError(t, err)
+
+	// Act
+	written, encryptErr := reader.Encrypt("Bob")
+	plaintext, decryptErr := reader.Decrypt(stored)
+
+	// Assert
+	assert.NoError(t, encryptErr)
+	assert.Equal(t, "Bob", written)
+	assert.NoError(t, decryptErr)
+	assert.Equal(t, "Jane Doe", plaintext)
+}
+
+func TestPIICipher_TamperedValue(t *testing.T) {
+	// Arrange
+	c, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+	stored, err := c.Encrypt("Jane Doe")
+	assert.NoError(t, err)
+	tampered := stored[:len(stored)-2] + "AA"
+
+	// Act
+	_, tamperedErr := c.Decrypt(tampered)
+	_, malformedErr := c.Decrypt("enc:vX:abc")
+
+	// Assert
+	assert.Error(t, tamperedErr)
+	assert.ErrorContains(t, malformedErr, "malformed encrypted value")
+}
+
+func TestNewPIICipher_InvalidConfig(t *testing.T) {
+	tests := []struct {
+		name     string
+		keys     map[string]string
+		current  int
+		expected string
+	}{
+		{name: "missing current key", keys: map[string]string{"1": testPIIKey1}, current: 2, expected: "no PII key configured for current version 2"},
+		{name: "short key", keys: map[string]string{"1": base64.StdEncoding.EncodeToString([]byte("short"))}, current: 1, expected: "must be 32 bytes"},
+		{name: "bad base64", keys: map[string]string{"1": "%%%"}, current: 1, expected: "not valid base64"},
+		{name: "bad version", keys: map[string]string{"one": testPIIKey1}, current: 1, expected: "invalid PII key version"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewPIICipher(tt.keys, tt.current, true)
+
+			assert.ErrorContains(t, err, tt.expected)
+		})
+	}
+}
+
+func TestOrderRepository_CreateOrder_EncryptsCustomerName(t *testing.T) {
+	// Arrange
+	c, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+	usePIICipher(t, c)
+
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	var storedName string
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("INSERT INTO orders"), mock.MatchedBy(func(args []any) bool {
+		storedName = args[1].(string)
+		return true
+	})).Return(row(7))
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	created, err := repo.CreateOrder(ctx, models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane Doe"}, nil)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", created.CustomerName)
+	assert.True(t, strings.HasPrefix(storedName, "enc:v1:"))
+	plaintext, err := c.Decrypt(storedName)
+	assert.NoError(t, err)
+	assert.Equal(t, "Jane Doe", plaintext)
+}
+
+func TestOrderRepository_GetOrderById_DecryptsAndReadsLegacyRows(t *testing.T) {
+	// Arrange
+	c, err := NewPIICipher(map[string]string{"1": testPIIKey1}, 1, true)
+	assert.NoError(t, err)
+	usePIICipher(t, c)
+	encrypted, err := c.Encrypt("Jane Doe")
+	assert.NoError(t, err)
+
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), []any{[]int{7}}).
+		Return(&fakeRows{rows: [][]any{{7, "ORD-20250601-K7QX2M", encrypted, 10.0, models.StatusPending, created, created}}}, nil)
+	mockDB.On("Query", ctx, sqlContaining("FROM orders"), []any{[]int{8}}).
+		Return(&fakeRows{rows: [][]any{{8, "ORD-20250601-K7QX2N", "Legacy Bob", 10.0, models.StatusPending, created, created}}}, nil)
+	mockDB.On("Query", ctx, sqlContaining("FROM order_items"), mock.Anything).Return(&fakeRows{}, nil)
+
+	// Act
+	encryptedOrder, encryptedErr := repo.GetOrderById(ctx, 7)
+	legacyOrder, legacyErr := repo.GetOrderById(ctx, 8)
+
+	// Assert
+	assert.NoError(t, encryptedErr)
+	assert.Equal(t, "Jane Doe", encryptedOrder.CustomerName)
+	assert.NoError(t, legacyErr)
+	assert.Equal(t, "Legacy Bob", legacyOrder.CustomerName)
+}
+
+func TestDecryptCustomerName_WithoutKeys(t *testing.T) {
+	// Arrange
+	usePIICipher(t, nil)
+
+	// Act
+	plaintext, plaintextErr := decryptCustomerName("Jane Doe")
+	_, encryptedErr := decryptCustomerName("enc:v1:abc")
+
+	// Assert
+	assert.NoError(t, plaintextErr)
+	assert.Equal(t, "Jane Doe", plaintext)
+	assert.ErrorContains(t, encryptedErr, "no PII keys are configured")
+}
@@ -0,0 +1,145 @@
+package repositories
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+)
+
+// streamBatchSize is how many orders StreamOrders reads, and loads items for, per query
+const streamBatchSize = 200
+
+// StreamOrders emits every order matching filter, with its items, in id order without buffering
+// the whole result. Orders are read in keyset batches (id > last seen) so no transaction or
+// server-side cursor is held open while the consumer is slow, and each batch loads its items in a
+// single query. The order channel is closed when the scan finishes, fails or ctx is cancelled; the
+// error channel then receives at most one error (ctx.Err() on cancellation) and is closed.
+func (r *OrderRepository) StreamOrders(ctx context.Context, filter models.OrderFilter) (<-chan models.OrderWithItems, <-chan error) {
+	orders := make(chan models.OrderWithItems)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(orders)
+
+		if err := r.streamOrders(ctx, filter, orders); err != nil {
+			logger.LoggerWithRequestIDFromContext(ctx).WithError(err).Error("Order stream stopped")
+			errs <- err
+		}
+	}()
+
+	return orders, errs
+}
+
+func (r *OrderRepository) streamOrders(ctx context.Context, filter models.OrderFilter, out chan<- models.OrderWithItems) error {
+	afterID := 0
+	for {
+		batch, err := r.readOrderBatch(ctx, filter, afterID)
+		if err != nil {
+			return err
+		}
+
+		for _, order := range batch {
+			select {
+			case out <- order:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if len(batch) < streamBatchSize {
+			return nil
+		}
+		afterID = batch[len(batch)-1].ID
+	}
+}
+
+// readOrderBatch reads the next batch of orders after afterID together with their items
+func (r *OrderRepository) readOrderBatch(ctx context.Context, filter models.OrderFilter, afterID int) ([]models.OrderWithItems, error) {
+	query, args := buildStreamOrdersQuery(filter, afterID)
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders: %w", err)
+	}
+	defer rows.Close()
+
+	var (
+		batch    []models.OrderWithItems
+		orderIDs []int
+		byID     = make(map[int]int)
+	)
+	for rows.Next() {
+		var order models.Order
+		if err := rows.Scan(&order.ID, &order.OrderNumber, &order.CustomerName, &order.TotalAmount, &order.Status, &order.CreatedAt, &order.UpdatedAt, &order.PublicID, &order.UserID); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		if order.CustomerName, err = decryptCustomerName(order.CustomerName); err != nil {
+			return nil, err
+		}
+		byID[order.ID] = len(batch)
+		batch = append(batch, models.OrderWithItems{Order: order, Items: []models.OrderItem{}})
+		orderIDs = append(orderIDs, order.ID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning orders: %w", err)
+	}
+	rows.Close()
+
+	if len(batch) == 0 {
+		return nil, nil
+	}
+
+	itemRows, err := r.db.Query(ctx, `SELECT id, order_id, product_name, quantity, price, status, shipped_quantity, created_at, updated_at
+		FROM order_items
+		WHERE order_id = ANY($1)
+		ORDER BY order_id, id`, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query order items: %w", err)
+	}
+	defer itemRows.Close()
+
+	for itemRows.Next() {
+		var item models.OrderItem
+		if err := itemRows.Scan(&item.ID, &item.OrderID, &item.ProductName, &item.Quantity, &item.Price, &item.Status, &item.ShippedQuantity, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan order item: %w", err)
+		}
+		if i, ok := byID[item.OrderID]; ok {
+			batch[i].Items = append(batch[i].Items, item)
+		}
+	}
+	if err := itemRows.Err(); err != nil {
+		return nil, fmt.Errorf("error scanning order items: %w", err)
+	}
+
+	return batch, nil
+}
+
+// buildStreamOrdersQuery returns the keyset query for the batch after afterID.
+// $1 is afterID and $2 the batch size; filter values follow in field order.
+func buildStreamOrdersQuery(filter models.OrderFilter, afterID int) (string, []any) {
+	conditions := []string{"id > $1"}
+	args := []any{afterID, streamBatchSize}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	// created_at holds UTC without a time zone and pgx binds a time's wall clock
+	if filter.CreatedAfter != nil {
+		args = append(args, filter.CreatedAfter.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at >= $%d", len(args)))
+	}
+	if filter.CreatedBefore != nil {
+		args = append(args, filter.CreatedBefore.UTC())
+		conditions = append(conditions, fmt.Sprintf("created_at < $%d", len(args)))
+	}
+
+	return `
+		SELECT id, order_number, customer_name, total_amount, status, created_at, updated_at, public_id, COALESCE(user_id, '')
+		FROM orders
+		WHERE ` + strings.Join(conditions, " AND ") + `
+		ORDER BY id
+		LIMIT $2`, args
+}
@@ -0,0 +1,161 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// fakeRows serves fixed rows; values are assigned to Scan destinations of the same type
+type fakeRows struct {
+	pgx.Rows
+	rows [][]any
+	next int
+}
+
+func (r *fakeRows) Next() bool {
+	r.next++
+	return r.next <= len(r.rows)
+}
+
+func (r *fakeRows) Scan(dest ...any) error {
+	for i, value := range r.rows[r.next-1] {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(value))
+	}
+	return nil
+}
+
+func (r *fakeRows) Err() error { return nil }
+func (r *fakeRows) Close()     {}
+
+func orderRows(fromID, toID int) *fakeRows {
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rows := &fakeRows{}
+	for id := fromID; id <= toID; id++ {
+		rows.rows = append(rows.rows, []any{id, fmt.Sprintf("ORD-20250601-%06d", id), "Jane", 10.0, models.StatusPending, created, created})
+	}
+	return rows
+}
+
+func itemRows(orderIDs ...int) *fakeRows {
+	created := time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+	rows := &fakeRows{}
+	for i, orderID := range orderIDs {
+		rows.rows = append(rows.rows, []any{i + 1, orderID, "Widget", 1, 10.0, models.ItemStatusPending, 0, created, created})
+	}
+	return rows
+}
+
+func TestOrderRepository_StreamOrders_ConsumesAllBatches(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	firstIDs := make([]int, streamBatchSize)
+	for i := range firstIDs {
+		firstIDs[i] = i + 1
+	}
+	lastID := streamBatchSize + 1
+
+	mockDB.On("Query", ctx, mock.Anything, []any{0, streamBatchSize, models.StatusPending}).Return(orderRows(1, streamBatchSize), nil).Once()
+	mockDB.On("Query", ctx, mock.Anything, []any{firstIDs}).Return(itemRows(1, 1, streamBatchSize), nil).Once()
+	mockDB.On("Query", ctx, mock.Anything, []any{streamBatchSize, streamBatchSize, models.StatusPending}).Return(orderRows(lastID, lastID), nil).Once()
+	mockDB.On("Query", ctx, mock.Anything, []any{[]int{lastID}}).Return(itemRows(lastID), nil).Once()
+
+	// Act
+	orders, errs := repo.StreamOrders(ctx, models.OrderFilter{Status: models.StatusPending})
+
+	var received []models.OrderWithItems
+	for order := range orders {
+		received = append(received, order)
+	}
+	err := <-errs
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, received, streamBatchSize+1) {
+		assert.Equal(t, 1, received[0].ID)
+		assert.Len(t, received[0].Items, 2)
+		assert.Len(t, received[streamBatchSize-1].Items, 1)
+		assert.Empty(t, received[1].Items)
+		assert.Equal(t, lastID, received[streamBatchSize].ID)
+		assert.Len(t, received[streamBatchSize].Items, 1)
+	}
+	mockDB.AssertExpectations(t)
+}
+
+func TestOrderRepository_StreamOrders_StopsOnCancel(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockDB.On("Query", ctx, mock.Anything, []any{0, streamBatchSize}).Return(orderRows(1, 3), nil).Once()
+	mockDB.On("Query", ctx, mock.Anything, []any{[]int{1, 2, 3}}).Return(itemRows(), nil).Once()
+
+	// Act
+	orders, errs := repo.StreamOrders(ctx, models.OrderFilter{})
+	first := <-orders
+	cancel()
+
+	// Nobody is receiving, so the producer can only observe the cancellation
+	err := <-errs
+	_, open := <-orders
+
+	// Assert
+	assert.Equal(t, 1, first.ID)
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.False(t, open)
+}
+
+func TestOrderRepository_StreamOrders_QueryError(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Query", ctx, mock.Anything, mock.Anything).Return(nil, errors.New("connection refused"))
+
+	// Act
+	orders, errs := repo.StreamOrders(ctx, models.OrderFilter{})
+	_, open := <-orders
+	err := <-errs
+
+	// Assert
+	assert.False(t, open)
+	assert.ErrorContains(t, err, "connection refused")
+}
+
+func TestBuildStreamOrdersQuery_Filters(t *testing.T) {
+	after := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := after.AddDate(0, 1, 0)
+
+	query, args := buildStreamOrdersQuery(models.OrderFilter{Status: models.StatusCompleted, CreatedAfter: &after, CreatedBefore: &before}, 42)
+
+	assert.Contains(t, query, "WHERE id > $1 AND status = $3 AND created_at >= $4 AND created_at < $5")
+	assert.Contains(t, query, "ORDER BY id")
+	assert.Equal(t, []any{42, streamBatchSize, models.StatusCompleted, after, before}, args)
+}
+
+func TestBuildStreamOrdersQuery_CreatedRangeWithOffsetBindsUTC(t *testing.T) {
+	// Arrange
+	bangkok := time.FixedZone("+07:00", 7*60*60)
+	after := time.Date(2024, 1, 1, 0, 0, 0, 0, bangkok)
+	before := time.Date(2024, 2, 1, 0, 0, 0, 0, bangkok)
+
+	// Act
+	_, args := buildStreamOrdersQuery(models.OrderFilter{CreatedAfter: &after, CreatedBefore: &before}, 0)
+
+	// Assert
+	assert.Equal(t, []any{0, streamBatchSize, time.Date(2023, 12, 31, 17, 0, 0, 0, time.UTC), time.Date(2024, 1, 31, 17, 0, 0, 0, time.UTC)}, args)
+}
@@ -0,0 +1,106 @@
+package repositories
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// slowTxThreshold is the duration after which a finished transaction is logged at Warn, 0 disables it
+var slowTxThreshold atomic.Int64
+
+// SetSlowTransactionThreshold sets how long a transaction may take, from begin to commit or
+// rollback, before it is logged as slow. Slow transactions usually point at lock contention.
+func SetSlowTransactionThreshold(threshold time.Duration) {
+	slowTxThreshold.Store(int64(threshold))
+}
+
+// trackedTx wraps a transaction and logs its begin, commit and rollback at Debug level
+type trackedTx struct {
+	pgx.Tx
+	operation string
+	start     time.Time
+	logger    *logger.Logger
+}
+
+// beginTx starts a transaction whose lifecycle is logged under the given operation name
+func (r *OrderRepository) beginTx(ctx context.Context, operation string) (*trackedTx, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		recordWrite(err)
+		return nil, err
+	}
+
+	txLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", operation)
+	txLogger.Debug("Transaction begun")
+
+	return &trackedTx{
+		Tx:        tx,
+		operation: operation,
+		start:     time.Now(),
+		logger:    txLogger,
+	}, nil
+}
+
+func (t *trackedTx) Commit(ctx context.Context) error {
+	err := t.Tx.Commit(ctx)
+	duration := time.Since(t.start)
+	recordWrite(err)
+	if err != nil {
+		t.logger.WithError(err).Debug("Transaction commit failed", "duration_ms", duration.Milliseconds())
+		t.warnIfSlow(duration, "commit_failed")
+		return err
+	}
+	t.logger.Debug("Transaction committed", "duration_ms", duration.Milliseconds())
+	t.warnIfSlow(duration, "committed")
+	return nil
+}
+
+// rollback rolls the transaction back and logs the error that caused it
+func (t *trackedTx) rollback(ctx context.Context, cause error) error {
+	err := t.Tx.Rollback(ctx)
+	duration := time.Since(t.start)
+	recordWrite(cause)
+	t.logger.WithError(cause).Debug("Transaction rolled back", "duration_ms", duration.Milliseconds())
+	t.warnIfSlow(duration, "rolled_back")
+	return err
+}
+
+func (t *trackedTx) warnIfSlow(duration time.Duration, outcome string) {
+	threshold := time.Duration(slowTxThreshold.Load())
+	if threshold <= 0 || duration < threshold {
+		return
+	}
+	t.logger.Warn("Slow transaction",
+		"duration_ms", duration.Milliseconds(),
+		"threshold_ms", threshold.Milliseconds(),
+		"outcome", outcome,
+	)
+}
+
+// recordWrite notes a finished write in the write tracker reported by the detailed health check,
+// err is nil when the write committed. Only failures of the database itself count as failed
+// writes: a missing row, a failed precondition or a constraint violation means it answered.
+func recordWrite(err error) {
+	if err == nil {
+		database.Writes().RecordSuccess(time.Now())
+		return
+	}
+	if isInfrastructureError(err) {
+		database.Writes().RecordAttempt(time.Now())
+	}
+}
+
+// isInfrastructureError reports whether err means the database could not be reached or did not
+// answer in time
+func isInfrastructureError(err error) bool {
+	switch database.ErrorCode(err) {
+	case database.ErrorCodeUnavailable, database.ErrorCodeTimeout:
+		return true
+	}
+	return false
+}
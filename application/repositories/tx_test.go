@@ -0,0 +1,157 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap/zapcore"
+)
+
+// useSlowTxThreshold sets the slow transaction threshold for the duration of the test
+func useSlowTxThreshold(t *testing.T, threshold time.Duration) {
+	previous := time.Duration(slowTxThreshold.Load())
+	SetSlowTransactionThreshold(threshold)
+	t.Cleanup(func() { SetSlowTransactionThreshold(previous) })
+}
+
+func TestTrackedTx_LogsSlowCommit(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+	useSlowTxThreshold(t, 10*time.Millisecond)
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	// The update waits as if blocked on a row lock
+	mockTx.On("Exec", ctx, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { time.Sleep(20 * time.Millisecond) }).
+		Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing}, models.OrderPrecondition{})
+
+	// Assert
+	assert.NoError(t, err)
+	slow := logs.FilterMessage("Slow transaction").All()
+	if assert.Len(t, slow, 1) {
+		fields := slow[0].ContextMap()
+		assert.Equal(t, zapcore.WarnLevel, slow[0].Level)
+		assert.Equal(t, "update_order", fields["operation"])
+		assert.Equal(t, "committed", fields["outcome"])
+		assert.Equal(t, int64(10), fields["threshold_ms"])
+		assert.GreaterOrEqual(t, fields["duration_ms"], int64(20))
+	}
+}
+
+func TestTrackedTx_LogsSlowRollback(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+	useSlowTxThreshold(t, 10*time.Millisecond)
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("Exec", ctx, mock.Anything, mock.Anything).
+		Run(func(mock.Arguments) { time.Sleep(20 * time.Millisecond) }).
+		Return(pgconn.CommandTag{}, errors.New("lock timeout"))
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing}, models.OrderPrecondition{})
+
+	// Assert
+	assert.Error(t, err)
+	slow := logs.FilterMessage("Slow transaction").All()
+	if assert.Len(t, slow, 1) {
+		assert.Equal(t, "rolled_back", slow[0].ContextMap()["outcome"])
+	}
+}
+
+func TestTrackedTx_FastOrDisabled(t *testing.T) {
+	for name, threshold := range map[string]time.Duration{"fast": time.Minute, "disabled": 0} {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			logs := observeLogs(t)
+			useSlowTxThreshold(t, threshold)
+			mockDB := &MockDatabase{}
+			mockTx := &MockTx{}
+			repo := NewOrderRepository(mockDB)
+			ctx := context.Background()
+
+			mockDB.On("Begin", ctx).Return(mockTx, nil)
+			mockTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+			mockTx.On("Commit", ctx).Return(nil)
+
+			// Act
+			err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing}, models.OrderPrecondition{})
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Empty(t, logs.FilterMessage("Slow transaction").All())
+		})
+	}
+}
+
+func TestTrackedTx_RecordsSuccessfulWrite(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+	start := time.Now()
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("Exec", ctx, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	err := repo.UpdateOrder(ctx, models.Order{ID: 1, Status: models.StatusProcessing}, models.OrderPrecondition{})
+
+	// Assert
+	assert.NoError(t, err)
+	at, ok := database.Writes().LastSuccessfulWrite()
+	assert.True(t, ok)
+	assert.False(t, at.Before(start))
+}
+
+func TestIsInfrastructureError(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "pool closed", err: database.ErrPoolClosed, want: true},
+		{name: "connection failure", err: &pgconn.PgError{Code: "08006"}, want: true},
+		{name: "timeout", err: context.DeadlineExceeded, want: true},
+		{name: "statement timeout", err: &pgconn.PgError{Code: "57014"}, want: true},
+		{name: "order not found", err: pgx.ErrNoRows},
+		{name: "order modified", err: domain.ErrOrderModified},
+		{name: "unique violation", err: &pgconn.PgError{Code: "23505"}},
+		{name: "serialization failure", err: &pgconn.PgError{Code: "40001"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			got := isInfrastructureError(fmt.Errorf("failed to update order: %w", tt.err))
+
+			// Assert
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
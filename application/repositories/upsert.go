@@ -0,0 +1,100 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
+)
+
+// UpsertOrderByNumber inserts the order or, when an order with the same order number exists,
+// updates it and replaces its items, all in one transaction. created reports which happened.
+// An updated order keeps its ID, public ID, owner and created_at. Its status is only replaced when
+// it currently is one of statusFrom, the statuses allowed to move to order.Status, and
+// domain.ErrInvalidStatusTransition is returned otherwise; a nil statusFrom keeps the status.
+// With ownerOnly an existing order is only replaced when it belongs to order.UserID, and
+// domain.ErrNotOrderOwner is returned for another user's order.
+func (r *OrderRepository) UpsertOrderByNumber(ctx context.Context, order models.Order, items []models.OrderItem, statusFrom []models.Status, ownerOnly bool) (result models.OrderWithItems, created bool, err error) {
+	repoLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	tx, err := r.beginTx(ctx, "upsert_order")
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to begin transaction", "order_number", order.OrderNumber)
+		return models.OrderWithItems{}, false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			if rollbackErr := tx.rollback(ctx, err); rollbackErr != nil {
+				repoLogger.WithError(rollbackErr).Error("Failed to rollback transaction", "order_number", order.OrderNumber)
+			}
+			err = translateWriteError(err)
+		}
+	}()
+
+	// xmax is 0 only for a freshly inserted row, which tells the two branches apart. The status
+	// and owner checks are part of the conflict update, so the row lock taken by it covers them.
+	upsertQuery := `INSERT INTO orders (order_number, customer_name, total_amount, status, created_at, updated_at, public_id, user_id)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, ''))
+		ON CONFLICT (order_number) DO UPDATE
+		SET customer_name = EXCLUDED.customer_name,
+			total_amount = EXCLUDED.total_amount,
+			status = CASE WHEN $9 THEN EXCLUDED.status ELSE orders.status END,
+			updated_at = EXCLUDED.updated_at
+		WHERE (NOT $9 OR orders.status = ANY($10::varchar[]))
+			AND (NOT $11 OR orders.user_id = EXCLUDED.user_id)
+		RETURNING id, created_at, (xmax = 0) AS inserted, public_id, COALESCE(user_id, ''), status`
+
+	storedName, err := encryptCustomerName(order.CustomerName)
+	if err != nil {
+		return models.OrderWithItems{}, false, fmt.Errorf("failed to encrypt customer name: %w", err)
+	}
+
+	err = tx.QueryRow(ctx, upsertQuery, order.OrderNumber, storedName, order.TotalAmount, order.Status, order.CreatedAt, order.UpdatedAt, models.NewOrderPublicID(), order.UserID, statusFrom != nil, statusStrings(statusFrom), ownerOnly).
+		Scan(&order.ID, &order.CreatedAt, &created, &order.PublicID, &order.UserID, &order.Status)
+	if errors.Is(err, pgx.ErrNoRows) && ownerOnly {
+		var owner string
+		if err = tx.QueryRow(ctx, "SELECT COALESCE(user_id, '') FROM orders WHERE order_number = $1", order.OrderNumber).Scan(&owner); err != nil {
+			repoLogger.WithError(err).Error("Failed to read order owner", "order_number", order.OrderNumber)
+			return models.OrderWithItems{}, false, fmt.Errorf("failed to read order owner: %w", err)
+		}
+		if owner != order.UserID {
+			repoLogger.Warn("Rejected upsert of another user's order", "order_number", order.OrderNumber, "user_id", order.UserID)
+			err = fmt.Errorf("%w: order %s", domain.ErrNotOrderOwner, order.OrderNumber)
+			return models.OrderWithItems{}, false, err
+		}
+		err = pgx.ErrNoRows
+	}
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The order exists but its status may not move to the requested one
+		repoLogger.Warn("Rejected status transition on upsert", "order_number", order.OrderNumber, "status", order.Status)
+		err = fmt.Errorf("%w: order %s cannot move to %s", domain.ErrInvalidStatusTransition, order.OrderNumber, order.Status)
+		return models.OrderWithItems{}, false, err
+	}
+	if err != nil {
+		repoLogger.WithError(err).Error("Failed to upsert order", "order_number", order.OrderNumber)
+		return models.OrderWithItems{}, false, fmt.Errorf("failed to upsert order: %w", err)
+	}
+
+	if !created {
+		if _, err = tx.Exec(ctx, "DELETE FROM order_items WHERE order_id = $1", order.ID); err != nil {
+			repoLogger.WithError(err).Error("Failed to delete replaced order items", "order_id", order.ID)
+			return models.OrderWithItems{}, false, fmt.Errorf("failed to delete replaced order items: %w", err)
+		}
+	}
+
+	insertedItems, err := insertOrderItems(ctx, tx, order.ID, items)
+	if err != nil {
+		return models.OrderWithItems{}, false, err
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		repoLogger.WithError(err).Error("Failed to commit transaction", "order_id", order.ID)
+		return models.OrderWithItems{}, false, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return models.OrderWithItems{Order: order, Items: insertedItems}, created, nil
+}
@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestOrderRepository_UpsertOrderByNumber_Inserts(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane", TotalAmount: 20, Status: models.StatusPending, CreatedAt: now, UpdatedAt: now}
+	items := []models.OrderItem{{ProductName: "Widget", Quantity: 2, Price: 10, Status: models.ItemStatusPending}}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("ON CONFLICT (order_number)"), mock.Anything).Return(row(7, now, true, "", "", models.StatusPending))
+	mockTx.On("Query", ctx, sqlContaining("INSERT INTO order_items"), mock.Anything).Return(idRows(70), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	result, created, err := repo.UpsertOrderByNumber(ctx, order, items, []models.Status{models.StatusPending, models.StatusProcessing}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, 7, result.ID)
+	if assert.Len(t, result.Items, 1) {
+		assert.Equal(t, 70, result.Items[0].ID)
+		assert.Equal(t, 7, result.Items[0].OrderID)
+	}
+	mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderRepository_UpsertOrderByNumber_ReplacesItemsOnUpdate(t *testing.T) {
+	// Arrange
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	createdAt := time.Date(2025, 5, 1, 9, 0, 0, 0, time.UTC)
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := models.Order{OrderNumber: "ORD-20250501-K7QX2M", CustomerName: "Jane", TotalAmount: 5, Status: models.StatusProcessing, CreatedAt: now, UpdatedAt: now}
+	items := []models.OrderItem{{ProductName: "Gadget", Quantity: 1, Price: 5, Status: models.ItemStatusPending}}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("ON CONFLICT (order_number)"), mock.Anything).Return(row(7, createdAt, false, "", "", models.StatusProcessing))
+	mockTx.On("Exec", ctx, sqlContaining("DELETE FROM order_items"), []any{7}).Return(pgconn.NewCommandTag("DELETE 2"), nil)
+	mockTx.On("Query", ctx, sqlContaining("INSERT INTO order_items"), mock.Anything).Return(idRows(71), nil)
+	mockTx.On("Commit", ctx).Return(nil)
+
+	// Act
+	result, created, err := repo.UpsertOrderByNumber(ctx, order, items, []models.Status{models.StatusPending, models.StatusProcessing}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, 7, result.ID)
+	assert.Equal(t, createdAt, result.CreatedAt)
+	if assert.Len(t, result.Items, 1) {
+		assert.Equal(t, 71, result.Items[0].ID)
+	}
+	mockTx.AssertExpectations(t)
+}
+
+func TestOrderRepository_UpsertOrderByNumber_RejectsStatusTransition(t *testing.T) {
+	// Arrange: the conflict update's status check fails, so no row comes back
+	mockDB := &MockDatabase{}
+	mockTx := &MockTx{}
+	repo := NewOrderRepository(mockDB)
+	ctx := context.Background()
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	order := models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane", TotalAmount: 5, Status: models.StatusPending, CreatedAt: now, UpdatedAt: now}
+	statusFrom := []models.Status{models.StatusPending}
+
+	mockDB.On("Begin", ctx).Return(mockTx, nil)
+	mockTx.On("QueryRow", ctx, sqlContaining("orders.status = ANY($10::varchar[])"), mock.MatchedBy(func(args []any) bool {
+		return args[8] == true && assert.ObjectsAreEqual([]string{"pending"}, args[9]) && args[10] == false
+	})).Return(errRow{err: pgx.ErrNoRows})
+	mockTx.On("Rollback", ctx).Return(nil)
+
+	// Act
+	_, _, err := repo.UpsertOrderByNumber(ctx, order, []models.OrderItem{{ProductName: "Gadget", Quantity: 1, Price: 5}}, statusFrom, false)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockTx.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+	mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+}
+
+func TestOrderRepository_UpsertOrderByNumber_OwnerOnly(t *testing.T) {
+	tests := []struct {
+		name    string
+		owner   string
+		wantErr error
+	}{
+		{name: "another user's order", owner: "user-7", wantErr: domain.ErrNotOrderOwner},
+		{name: "own order with a rejected status", owner: "user-42", wantErr: domain.ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange: the conflict update's owner or status check fails, so no row comes back
+			mockDB := &MockDatabase{}
+			mockTx := &MockTx{}
+			repo := NewOrderRepository(mockDB)
+			ctx := context.Background()
+
+			now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			order := models.Order{OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "Jane", TotalAmount: 5, Status: models.StatusPending, CreatedAt: now, UpdatedAt: now, UserID: "user-42"}
+
+			mockDB.On("Begin", ctx).Return(mockTx, nil)
+			mockTx.On("QueryRow", ctx, sqlContaining("orders.user_id = EXCLUDED.user_id"), mock.MatchedBy(func(args []any) bool {
+				return args[10] == true
+			})).Return(errRow{err: pgx.ErrNoRows})
+			mockTx.On("QueryRow", ctx, sqlContaining("SELECT COALESCE(user_id, '') FROM orders"), []any{order.OrderNumber}).Return(row(tt.owner))
+			mockTx.On("Rollback", ctx).Return(nil)
+
+			// Act
+			_, _, err := repo.UpsertOrderByNumber(ctx, order, []models.OrderItem{{ProductName: "Gadget", Quantity: 1, Price: 5}}, []models.Status{models.StatusPending}, true)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.wantErr)
+			mockTx.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+			mockTx.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+			mockTx.AssertNotCalled(t, "Commit", mock.Anything)
+		})
+	}
+}
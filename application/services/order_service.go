@@ -2,79 +2,458 @@ package services
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"sort"
+	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/Testzyler/order-management-go/application/domain"
 	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/clock"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 )
 
+// totalMismatchEpsilon is the largest accepted difference between a client-provided and computed total
+const totalMismatchEpsilon = 0.005
+
+// validateClientTotal enables checking a non-zero CreateOrderInput.TotalAmount against the items
+var validateClientTotal = false
+
+// SetValidateClientTotal configures whether client-provided order totals are verified
+func SetValidateClientTotal(validate bool) {
+	validateClientTotal = validate
+}
+
+// collapseNameWhitespace enables replacing runs of whitespace inside names with a single space
+var collapseNameWhitespace = false
+
+// SetCollapseNameWhitespace configures whether internal whitespace in customer and product names is collapsed
+func SetCollapseNameWhitespace(collapse bool) {
+	collapseNameWhitespace = collapse
+}
+
+// normalizeName trims a customer or product name and optionally collapses its internal whitespace
+func normalizeName(name string) string {
+	if collapseNameWhitespace {
+		return strings.Join(strings.Fields(name), " ")
+	}
+	return strings.TrimSpace(name)
+}
+
+// DefaultMaxOrderTotal is the largest value the orders.total_amount DECIMAL(10, 2) column holds
+const DefaultMaxOrderTotal = 99999999.99
+
+// maxOrderTotal is the largest accepted order total; larger or non-finite totals are rejected
+var maxOrderTotal = DefaultMaxOrderTotal
+
+// SetMaxOrderTotal configures the largest accepted order total; values <= 0 restore the default
+func SetMaxOrderTotal(max float64) {
+	if max <= 0 {
+		max = DefaultMaxOrderTotal
+	}
+	maxOrderTotal = max
+}
+
+// duplicateItemStrategy decides what happens to items that repeat a product name
+var duplicateItemStrategy = models.DuplicateItemsAllow
+
+// SetDuplicateItemStrategy configures how orders with repeated product names are created.
+// Unknown values fall back to allow.
+func SetDuplicateItemStrategy(strategy models.DuplicateItemStrategy) {
+	if !strategy.IsValid() {
+		strategy = models.DuplicateItemsAllow
+	}
+	duplicateItemStrategy = strategy
+}
+
+// applyDuplicateItemStrategy merges or rejects items that repeat a product name. Merging only
+// combines items with the same price, so the order total does not change.
+func applyDuplicateItemStrategy(items []models.OrderItem) ([]models.OrderItem, error) {
+	switch duplicateItemStrategy {
+	case models.DuplicateItemsReject:
+		seen := make(map[string]int, len(items))
+		var duplicates []string
+		for _, item := range items {
+			seen[item.ProductName]++
+			if seen[item.ProductName] == 2 {
+				duplicates = append(duplicates, item.ProductName)
+			}
+		}
+		if len(duplicates) > 0 {
+			return nil, &domain.DuplicateItemsError{ProductNames: duplicates}
+		}
+		return items, nil
+	case models.DuplicateItemsMerge:
+		type itemKey struct {
+			productName string
+			price       float64
+		}
+		merged := make([]models.OrderItem, 0, len(items))
+		positions := make(map[itemKey]int, len(items))
+		for _, item := range items {
+			key := itemKey{productName: item.ProductName, price: item.Price}
+			if i, ok := positions[key]; ok {
+				merged[i].Quantity += item.Quantity
+				continue
+			}
+			positions[key] = len(merged)
+			merged = append(merged, item)
+		}
+		return merged, nil
+	default:
+		return items, nil
+	}
+}
+
+// deepPageOffset is the list offset from which orders are counted before paging; 0 disables the check
+var deepPageOffset = 1000
+
+// SetDeepPageOffset configures the offset at which list requests are checked against the total first,
+// so pages past the end return empty without running an expensive OFFSET query
+func SetDeepPageOffset(offset int) {
+	deepPageOffset = offset
+}
+
+// strictItemLoad makes order reads fail when the items cannot be loaded, instead of returning
+// the order with no items and a warning
+var strictItemLoad = true
+
+// SetStrictItemLoad configures whether GetOrderById and GetOrderByNumber fail on item-load errors
+func SetStrictItemLoad(strict bool) {
+	strictItemLoad = strict
+}
+
+// itemsUnavailableWarning is added to partial results when items could not be loaded
+const itemsUnavailableWarning = "items could not be loaded"
+
+// bulkDeleteBatchSize is how many orders each bulk delete transaction removes
+const bulkDeleteBatchSize = 500
+
+// CancellationHook runs after an order has been cancelled, e.g. to restock its items or start a refund.
+// The cancellation is already committed, so a failing hook is logged and does not undo it.
+type CancellationHook func(ctx context.Context, order models.OrderWithItems) error
+
+// cancellationHook is called for every newly cancelled order; nil disables it
+var cancellationHook CancellationHook
+
+// SetCancellationHook configures the hook run after CancelOrder cancels an order
+func SetCancellationHook(hook CancellationHook) {
+	cancellationHook = hook
+}
+
 type OrderService struct {
-	repo domain.OrderRepository
+	repo  domain.OrderRepository
+	clock clock.Clock
 }
 
 func NewOrderService(repo domain.OrderRepository) *OrderService {
+	return NewOrderServiceWithClock(repo, clock.New())
+}
+
+// NewOrderServiceWithClock creates an OrderService that reads the time from the given clock
+func NewOrderServiceWithClock(repo domain.OrderRepository, clk clock.Clock) *OrderService {
 	return &OrderService{
-		repo: repo,
+		repo:  repo,
+		clock: clk,
 	}
 }
 
-func (s *OrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) error {
-	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx)
+func (s *OrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "create_order")
 
-	// Validate input
+	order, items, err := s.buildOrder(ctx, serviceLogger, input)
+	if err != nil {
+		return models.OrderWithItems{}, err
+	}
+
+	releaseQuota, err := reserveOrderQuota(serviceLogger, order)
+	if err != nil {
+		return models.OrderWithItems{}, err
+	}
+
+	created, err := s.repo.CreateOrder(ctx, order, items)
+
+	if err != nil {
+		releaseQuota()
+		serviceLogger.WithError(err).Error("Failed to create order", "customer", input.CustomerName, "total", order.TotalAmount)
+		return models.OrderWithItems{}, err
+	}
+
+	return created, nil
+}
+
+// UpsertOrderByNumber creates the order under the given order number or, when it exists, replaces
+// its customer, status, total and items. The input is validated like CreateOrder; a status, when
+// given, is kept instead of starting as pending. With input.OwnerOnly only the owner's order is
+// replaced. It reports whether the order was created.
+func (s *OrderService) UpsertOrderByNumber(ctx context.Context, orderNumber string, input models.CreateOrderInput) (models.OrderWithItems, bool, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "upsert_order")
+
+	if !models.IsOrderNumber(orderNumber) {
+		serviceLogger.Warn("Invalid order number", "order_number", orderNumber)
+		return models.OrderWithItems{}, false, fmt.Errorf("%w: %q", domain.ErrInvalidOrderNumber, orderNumber)
+	}
+	if input.Status != "" && !input.Status.IsValid() {
+		serviceLogger.Warn("Invalid order status", "order_number", orderNumber, "status", input.Status)
+		return models.OrderWithItems{}, false, fmt.Errorf("unknown status %q", input.Status)
+	}
+
+	order, items, err := s.buildOrder(ctx, serviceLogger, input)
+	if err != nil {
+		return models.OrderWithItems{}, false, err
+	}
+	order.OrderNumber = orderNumber
+	// Without a status an existing order keeps its own; with one it must be a valid transition
+	var statusFrom []models.Status
+	if input.Status != "" {
+		order.Status = input.Status
+		statusFrom = statusesInto(input.Status)
+	}
+
+	// Whether the order is new is only known after the upsert, so the quota is given back when
+	// it replaced an existing order
+	releaseQuota, err := reserveOrderQuota(serviceLogger, order)
+	if err != nil {
+		return models.OrderWithItems{}, false, err
+	}
+
+	result, created, err := s.repo.UpsertOrderByNumber(ctx, order, items, statusFrom, input.OwnerOnly)
+	if err != nil {
+		releaseQuota()
+		serviceLogger.WithError(err).Error("Failed to upsert order", "order_number", orderNumber)
+		return models.OrderWithItems{}, false, err
+	}
+	if !created {
+		releaseQuota()
+	}
+
+	return result, created, nil
+}
+
+// CreateOrders validates every order before inserting any. In atomic mode a single invalid order
+// fails the whole batch with ErrBatchInvalid and the valid ones are created in one transaction;
+// otherwise each valid order is created on its own and failures are reported per index.
+func (s *OrderService) CreateOrders(ctx context.Context, inputs []models.CreateOrderInput, atomic bool) (models.BatchCreateResult, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "create_orders")
+
+	batch := s.prepareBatch(ctx, serviceLogger, inputs)
+	result := models.BatchCreateResult{Created: []models.OrderWithItems{}, Errors: batch.errors}
+	if atomic {
+		if err := batch.rejectInvalid(serviceLogger, len(inputs)); err != nil {
+			return result, err
+		}
+		created, err := s.repo.CreateOrders(ctx, batch.valid)
+		if err != nil {
+			batch.release()
+			serviceLogger.WithError(err).Error("Failed to create order batch", "orders", len(batch.valid))
+			return models.BatchCreateResult{}, err
+		}
+		result.Created = created
+		return result, nil
+	}
+
+	for i, order := range batch.valid {
+		created, err := s.repo.CreateOrder(ctx, order.Order, order.Items)
+		if err != nil {
+			batch.releases[i]()
+			serviceLogger.WithError(err).Error("Failed to create order", "index", batch.indexes[i], "customer", order.CustomerName)
+			result.Errors = append(result.Errors, models.BatchOrderError{Index: batch.indexes[i], Message: err.Error()})
+			continue
+		}
+		result.Created = append(result.Created, created)
+	}
+	sort.Slice(result.Errors, func(a, b int) bool { return result.Errors[a].Index < result.Errors[b].Index })
+
+	return result, nil
+}
+
+// BulkCreateOrders validates every order, then inserts the valid ones with batched inserts in a
+// single transaction. Invalid orders are reported by index next to the created ones; in atomic
+// mode a single invalid order fails the whole request with ErrBatchInvalid and nothing is created.
+func (s *OrderService) BulkCreateOrders(ctx context.Context, inputs []models.CreateOrderInput, atomic bool) ([]models.BulkOrderResult, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "bulk_create_orders")
+
+	batch := s.prepareBatch(ctx, serviceLogger, inputs)
+	results := make([]models.BulkOrderResult, len(inputs))
+	for i := range results {
+		results[i].Index = i
+	}
+	for _, orderErr := range batch.errors {
+		results[orderErr.Index].Error = orderErr.Message
+	}
+
+	if atomic {
+		if err := batch.rejectInvalid(serviceLogger, len(inputs)); err != nil {
+			return results, err
+		}
+	}
+	if len(batch.valid) == 0 {
+		return results, nil
+	}
+
+	created, err := s.repo.BulkCreateOrders(ctx, batch.valid)
+	if err != nil {
+		batch.release()
+		serviceLogger.WithError(err).Error("Failed to bulk create orders", "orders", len(batch.valid))
+		return nil, err
+	}
+	for i := range created {
+		results[batch.indexes[i]].Order = &created[i]
+	}
+
+	return results, nil
+}
+
+// preparedBatch holds the orders of a batch that passed validation and the customer quota, with
+// their indexes in the request and the quota reservations to give back if they are not created
+type preparedBatch struct {
+	valid    []models.OrderWithItems
+	indexes  []int
+	releases []func()
+	errors   []models.BatchOrderError
+}
+
+// prepareBatch validates every input and reserves its customer's quota, collecting the orders to
+// insert and the per-index errors of the rest, in request order
+func (s *OrderService) prepareBatch(ctx context.Context, serviceLogger *logger.Logger, inputs []models.CreateOrderInput) preparedBatch {
+	batch := preparedBatch{
+		valid:    make([]models.OrderWithItems, 0, len(inputs)),
+		indexes:  make([]int, 0, len(inputs)),
+		releases: make([]func(), 0, len(inputs)),
+	}
+	for i, input := range inputs {
+		indexLogger := serviceLogger.WithField("index", i)
+		order, items, err := s.buildOrder(ctx, indexLogger, input)
+		var release func()
+		if err == nil {
+			release, err = reserveOrderQuota(indexLogger, order)
+		}
+		if err != nil {
+			batch.errors = append(batch.errors, models.BatchOrderError{Index: i, Message: err.Error()})
+			continue
+		}
+		batch.valid = append(batch.valid, models.OrderWithItems{Order: order, Items: items})
+		batch.indexes = append(batch.indexes, i)
+		batch.releases = append(batch.releases, release)
+	}
+	return batch
+}
+
+// rejectInvalid fails an atomic batch with ErrBatchInvalid when any of its orders is invalid,
+// giving back the quota reserved for the valid ones
+func (b preparedBatch) rejectInvalid(serviceLogger *logger.Logger, total int) error {
+	if len(b.errors) == 0 {
+		return nil
+	}
+	b.release()
+	serviceLogger.Warn("Atomic batch rejected", "orders", total, "invalid", len(b.errors))
+	return fmt.Errorf("%w: %d of %d orders failed validation", domain.ErrBatchInvalid, len(b.errors), total)
+}
+
+// release gives back the quota reserved for every valid order of the batch
+func (b preparedBatch) release() {
+	for _, release := range b.releases {
+		release()
+	}
+}
+
+// buildOrder validates input and computes the order and items to insert, timestamped with the clock.
+// The order is owned by input.UserID or, when empty, by the user authenticated on the request.
+func (s *OrderService) buildOrder(ctx context.Context, serviceLogger *logger.Logger, input models.CreateOrderInput) (models.Order, []models.OrderItem, error) {
+	// Validate input; whitespace-only names count as missing
+	input.CustomerName = normalizeName(input.CustomerName)
 	if input.CustomerName == "" {
 		serviceLogger.Error("Customer name is required")
-		return errors.New("customer name is required")
+		return models.Order{}, nil, errors.New("customer name is required")
 	}
 
 	if len(input.Items) == 0 {
 		serviceLogger.Error("Order must have at least one item")
-		return errors.New("order must have at least one item")
+		return models.Order{}, nil, errors.New("order must have at least one item")
 	}
 
+	// One timestamp for the order and all its items, so created_at and updated_at agree across rows
+	now := s.clock.Now().UTC()
 	order := models.Order{
+		OrderNumber:  models.NewOrderNumber(now),
 		CustomerName: input.CustomerName,
 		Status:       models.StatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+		UserID:       input.UserID,
+	}
+	if order.UserID == "" {
+		order.UserID = logger.UserIDFromContext(ctx)
 	}
 
 	items := make([]models.OrderItem, len(input.Items))
 	totalAmount := 0.0
 
 	for i, v := range input.Items {
+		v.ProductName = normalizeName(v.ProductName)
+		if v.ProductName == "" {
+			serviceLogger.Error("Product name is required", "item_index", i)
+			return models.Order{}, nil, errors.New("item product name is required")
+		}
+
 		if v.Quantity <= 0 {
 			serviceLogger.Error("Invalid item quantity", "product", v.ProductName, "quantity", v.Quantity)
-			return errors.New("item quantity must be greater than 0")
+			return models.Order{}, nil, errors.New("item quantity must be greater than 0")
 		}
 
 		if v.Price < 0 {
 			serviceLogger.Error("Invalid item price", "product", v.ProductName, "price", v.Price)
-			return errors.New("item price cannot be negative")
+			return models.Order{}, nil, errors.New("item price cannot be negative")
 		}
 
 		items[i] = models.OrderItem{
 			ProductName: v.ProductName,
 			Quantity:    v.Quantity,
 			Price:       v.Price,
+			Status:      models.ItemStatusPending,
+			CreatedAt:   now,
+			UpdatedAt:   now,
 		}
+		// Check each subtotal so the error names the item instead of only the overflowed total
 		itemTotal := v.Price * float64(v.Quantity)
+		if math.IsNaN(itemTotal) || math.IsInf(itemTotal, 0) || itemTotal > maxOrderTotal {
+			serviceLogger.Error("Item subtotal out of range", "item_index", i, "quantity", v.Quantity, "price", v.Price, "max", maxOrderTotal)
+			return models.Order{}, nil, &domain.FieldError{
+				Field: fmt.Sprintf("items[%d]", i),
+				Err:   fmt.Errorf("%w: subtotal %g exceeds %.2f", domain.ErrTotalOutOfRange, itemTotal, maxOrderTotal),
+			}
+		}
 		totalAmount += itemTotal
 	}
 
-	order.TotalAmount = totalAmount
-	err := s.repo.CreateOrder(ctx, order, items)
-
+	items, err := applyDuplicateItemStrategy(items)
 	if err != nil {
-		serviceLogger.WithError(err).Error("Failed to create order", "customer", input.CustomerName, "total", order.TotalAmount)
-		return err
+		serviceLogger.WithError(err).Warn("Order has duplicate items")
+		return models.Order{}, nil, err
 	}
 
-	return nil
+	// Huge quantities or prices overflow float64 to +Inf or produce totals the column cannot store
+	if math.IsNaN(totalAmount) || math.IsInf(totalAmount, 0) || totalAmount > maxOrderTotal {
+		serviceLogger.Error("Order total out of range", "total", totalAmount, "max", maxOrderTotal)
+		return models.Order{}, nil, fmt.Errorf("%w: total %g exceeds %.2f", domain.ErrTotalOutOfRange, totalAmount, maxOrderTotal)
+	}
+
+	if validateClientTotal && input.TotalAmount != 0 && math.Abs(input.TotalAmount-totalAmount) > totalMismatchEpsilon {
+		serviceLogger.Error("Order total mismatch", "provided", input.TotalAmount, "computed", totalAmount)
+		return models.Order{}, nil, fmt.Errorf("%w: provided %.2f, computed %.2f", domain.ErrTotalMismatch, input.TotalAmount, totalAmount)
+	}
+
+	order.TotalAmount = totalAmount
+	return order, items, nil
 }
 
 func (s *OrderService) GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error) {
-	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "get_order")
 	// Validate input
 	if id <= 0 {
 		serviceLogger.Error("Invalid order ID", "order_id", id)
@@ -82,6 +461,10 @@ func (s *OrderService) GetOrderById(ctx context.Context, id int) (models.OrderWi
 	}
 
 	order, err := s.repo.GetOrderById(ctx, id)
+	if partial, ok := partialOrder(order, err); ok {
+		serviceLogger.WithError(err).Warn("Returning order without items", "order_id", id)
+		return partial, nil
+	}
 
 	if err != nil {
 		serviceLogger.WithError(err).Error("Failed to get order", "order_id", id)
@@ -96,15 +479,152 @@ func (s *OrderService) GetOrderById(ctx context.Context, id int) (models.OrderWi
 	return order, nil
 }
 
+// GetOrderByNumber fetches an order by its human-friendly order number
+func (s *OrderService) GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "get_order_by_number")
+	if !models.IsOrderNumber(orderNumber) {
+		serviceLogger.Error("Invalid order number", "order_number", orderNumber)
+		return models.OrderWithItems{}, fmt.Errorf("invalid order number %q", orderNumber)
+	}
+
+	order, err := s.repo.GetOrderByNumber(ctx, orderNumber)
+	if partial, ok := partialOrder(order, err); ok {
+		serviceLogger.WithError(err).Warn("Returning order without items", "order_number", orderNumber)
+		return partial, nil
+	}
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order", "order_number", orderNumber)
+		return models.OrderWithItems{}, err
+	}
+
+	return order, nil
+}
+
+// GetOrderByPublicID fetches an order by the UUID exposed with the uuid ID strategy
+func (s *OrderService) GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "get_order_by_public_id")
+	canonical, ok := models.ParseOrderPublicID(publicID)
+	if !ok {
+		serviceLogger.Error("Invalid order public ID", "public_id", publicID)
+		return models.OrderWithItems{}, fmt.Errorf("invalid order public ID %q", publicID)
+	}
+
+	order, err := s.repo.GetOrderByPublicID(ctx, canonical)
+	if partial, ok := partialOrder(order, err); ok {
+		serviceLogger.WithError(err).Warn("Returning order without items", "public_id", canonical)
+		return partial, nil
+	}
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order", "public_id", canonical)
+		return models.OrderWithItems{}, err
+	}
+
+	return order, nil
+}
+
+// GetOrderIDByPublicID resolves a public order ID to the serial ID used by the other operations.
+// Unknown public IDs return pgx.ErrNoRows.
+func (s *OrderService) GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "get_order_id_by_public_id")
+	canonical, ok := models.ParseOrderPublicID(publicID)
+	if !ok {
+		serviceLogger.Error("Invalid order public ID", "public_id", publicID)
+		return 0, fmt.Errorf("invalid order public ID %q", publicID)
+	}
+
+	id, err := s.repo.GetOrderIDByPublicID(ctx, canonical)
+	if err != nil {
+		serviceLogger.WithError(err).Warn("Failed to resolve order public ID", "public_id", canonical)
+		return 0, err
+	}
+	return id, nil
+}
+
+// partialOrder reports whether a failed read can be served as the order without items,
+// which is the case when only the items failed to load and strict item loading is off
+func partialOrder(order models.OrderWithItems, err error) (models.OrderWithItems, bool) {
+	if strictItemLoad || !errors.Is(err, domain.ErrItemsUnavailable) || order.ID == 0 {
+		return models.OrderWithItems{}, false
+	}
+
+	order.Items = []models.OrderItem{}
+	order.Warnings = append(order.Warnings, itemsUnavailableWarning)
+	return order, true
+}
+
+// statusTransitions lists the statuses an order may move to from each status.
+// Completed and cancelled orders are final.
+var statusTransitions = map[models.Status][]models.Status{
+	models.StatusPending:          {models.StatusProcessing, models.StatusCancelled},
+	models.StatusProcessing:       {models.StatusPartiallyShipped, models.StatusCompleted, models.StatusCancelled},
+	models.StatusPartiallyShipped: {models.StatusCompleted},
+}
+
+// validateStatusTransition rejects unknown target statuses and moves the state machine does not allow.
+// Keeping the current status is allowed so retried updates stay idempotent.
+func validateStatusTransition(from, to models.Status) error {
+	if !to.IsValid() {
+		return fmt.Errorf("%w: unknown status %q", domain.ErrInvalidStatusTransition, to)
+	}
+	if from == to || slices.Contains(statusTransitions[from], to) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s to %s", domain.ErrInvalidStatusTransition, from, to)
+}
+
+// ifMatchPrecondition checks an If-Match header against the order as read and returns the
+// precondition that limits the write to that version, so a change made in between is not
+// overwritten. Without If-Match, or with "*", any version may be written.
+func ifMatchPrecondition(current models.OrderWithItems, ifMatch string) (models.OrderPrecondition, error) {
+	if ifMatch == "" || strings.TrimSpace(ifMatch) == "*" {
+		return models.OrderPrecondition{}, nil
+	}
+	if !models.ETagMatches(ifMatch, current.ETag()) {
+		return models.OrderPrecondition{}, fmt.Errorf("%w: If-Match does not name the current version", domain.ErrOrderModified)
+	}
+	return models.OrderPrecondition{UpdatedAt: current.UpdatedAt}, nil
+}
+
+// statusesInto returns the statuses an order may move to status from, status itself included
+func statusesInto(status models.Status) []models.Status {
+	from := []models.Status{status}
+	for candidate, targets := range statusTransitions {
+		if slices.Contains(targets, status) {
+			from = append(from, candidate)
+		}
+	}
+	slices.Sort(from)
+	return from
+}
+
+// UpdateOrder moves the order to the requested status after checking the transition against its current status
 func (s *OrderService) UpdateOrder(ctx context.Context, order models.UpdateOrderInput) error {
-	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "update_order")
+
+	// Without items the ETag cannot be checked, so a partial read only serves updates without If-Match
+	current, err := s.repo.GetOrderById(ctx, order.ID)
+	if err != nil && (!errors.Is(err, domain.ErrItemsUnavailable) || order.IfMatch != "") {
+		serviceLogger.WithError(err).Error("Failed to get order for update", "order_id", order.ID)
+		return err
+	}
+	precondition, err := ifMatchPrecondition(current, order.IfMatch)
+	if err != nil {
+		serviceLogger.WithError(err).Warn("Rejected update of a stale order", "order_id", order.ID)
+		return err
+	}
+	if err := validateStatusTransition(current.Status, order.Status); err != nil {
+		serviceLogger.WithError(err).Warn("Rejected status transition", "order_id", order.ID, "from", current.Status, "to", order.Status)
+		return err
+	}
+
 	orderToUpdate := models.Order{
 		ID:        order.ID,
 		Status:    order.Status,
-		UpdatedAt: time.Now(),
+		UpdatedAt: s.clock.Now().UTC(),
 	}
+	precondition.Status = current.Status
 
-	err := s.repo.UpdateOrder(ctx, orderToUpdate)
+	err = s.repo.UpdateOrder(ctx, orderToUpdate, precondition)
 	if err != nil {
 		serviceLogger.WithError(err).Error("Failed to update order", "order_id", order.ID)
 		return err
@@ -113,8 +633,306 @@ func (s *OrderService) UpdateOrder(ctx context.Context, order models.UpdateOrder
 	return nil
 }
 
+// PatchOrder updates only the fields set in input. A new status must be a valid transition from the
+// current one and a customer name must not be blank; only the provided fields are written.
+func (s *OrderService) PatchOrder(ctx context.Context, id int, input models.PatchOrderInput) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "patch_order")
+
+	if input.CustomerName == nil && input.Status == nil {
+		serviceLogger.Warn("Patch has no fields", "order_id", id)
+		return models.OrderWithItems{}, fmt.Errorf("%w: customer_name or status is required", domain.ErrInvalidPatch)
+	}
+
+	current, err := s.repo.GetOrderById(ctx, id)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order for patch", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+	precondition, err := ifMatchPrecondition(current, input.IfMatch)
+	if err != nil {
+		serviceLogger.WithError(err).Warn("Rejected patch of a stale order", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+
+	orderToUpdate := models.Order{ID: id, UpdatedAt: s.clock.Now().UTC()}
+	if input.CustomerName != nil {
+		orderToUpdate.CustomerName = normalizeName(*input.CustomerName)
+		if orderToUpdate.CustomerName == "" {
+			return models.OrderWithItems{}, fmt.Errorf("%w: customer name is required", domain.ErrInvalidPatch)
+		}
+	}
+	if input.Status != nil {
+		if err := validateStatusTransition(current.Status, *input.Status); err != nil {
+			serviceLogger.WithError(err).Warn("Rejected status transition", "order_id", id, "from", current.Status, "to", *input.Status)
+			return models.OrderWithItems{}, err
+		}
+		orderToUpdate.Status = *input.Status
+		precondition.Status = current.Status
+	}
+
+	if err := s.repo.UpdateOrder(ctx, orderToUpdate, precondition); err != nil {
+		serviceLogger.WithError(err).Error("Failed to update patched order", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+
+	if input.CustomerName != nil {
+		current.CustomerName = orderToUpdate.CustomerName
+	}
+	if input.Status != nil {
+		current.Status = orderToUpdate.Status
+	}
+	current.UpdatedAt = orderToUpdate.UpdatedAt
+	return current, nil
+}
+
+// CancelOrder cancels a pending or processing order. Cancelling an already cancelled order returns it
+// unchanged, while completed and partially shipped orders are rejected with ErrOrderNotCancellable.
+// Each cancellation is logged as an order.cancelled event and handed to the cancellation hook.
+func (s *OrderService) CancelOrder(ctx context.Context, id int) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "cancel_order")
+
+	previousStatus, err := s.repo.CancelOrder(ctx, id, s.clock.Now().UTC())
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotCancellable) {
+			serviceLogger.WithError(err).Warn("Order is not cancellable", "order_id", id)
+		} else {
+			serviceLogger.WithError(err).Error("Failed to cancel order", "order_id", id)
+		}
+		return models.OrderWithItems{}, err
+	}
+
+	order, err := s.repo.GetOrderById(ctx, id)
+	if err != nil && !errors.Is(err, domain.ErrItemsUnavailable) {
+		serviceLogger.WithError(err).Error("Failed to get order after cancellation", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+	if previousStatus == models.StatusCancelled {
+		serviceLogger.Debug("Order already cancelled", "order_id", id)
+		return order, nil
+	}
+
+	serviceLogger.Info("order.cancelled", "order_id", id, "order_number", order.OrderNumber, "from_status", previousStatus)
+
+	if cancellationHook != nil {
+		if err := cancellationHook(ctx, order); err != nil {
+			serviceLogger.WithError(err).Error("Cancellation hook failed", "order_id", id)
+		}
+	}
+
+	return order, nil
+}
+
+// patchableOrder is the representation of an order that merge patches are applied to
+type patchableOrder struct {
+	CustomerName string        `json:"customer_name"`
+	Status       models.Status `json:"status"`
+}
+
+// MergePatchOrder applies an RFC 7386 JSON Merge Patch to the order's customer_name and status.
+// Patches touching other fields or producing an invalid order are rejected with ErrInvalidPatch.
+// A patch that changes nothing returns the current order without writing. A non-empty ifMatch must
+// name the order's current ETag.
+func (s *OrderService) MergePatchOrder(ctx context.Context, id int, patch []byte, ifMatch string) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "merge_patch_order")
+
+	var patchFields map[string]json.RawMessage
+	if err := json.Unmarshal(patch, &patchFields); err != nil || patchFields == nil {
+		serviceLogger.Error("Merge patch is not a JSON object", "order_id", id)
+		return models.OrderWithItems{}, fmt.Errorf("%w: patch must be a JSON object", domain.ErrInvalidPatch)
+	}
+	for field := range patchFields {
+		if field != "customer_name" && field != "status" {
+			serviceLogger.Error("Merge patch touches a read-only field", "order_id", id, "field", field)
+			return models.OrderWithItems{}, fmt.Errorf("%w: field %s cannot be patched", domain.ErrInvalidPatch, field)
+		}
+	}
+
+	current, err := s.repo.GetOrderById(ctx, id)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order for patch", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+	precondition, err := ifMatchPrecondition(current, ifMatch)
+	if err != nil {
+		serviceLogger.WithError(err).Warn("Rejected patch of a stale order", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+
+	document, err := json.Marshal(patchableOrder{CustomerName: current.CustomerName, Status: current.Status})
+	if err != nil {
+		return models.OrderWithItems{}, err
+	}
+	merged, err := models.ApplyMergePatch(document, patch)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to apply merge patch", "order_id", id)
+		return models.OrderWithItems{}, fmt.Errorf("%w: %v", domain.ErrInvalidPatch, err)
+	}
+
+	var patched patchableOrder
+	if err := json.Unmarshal(merged, &patched); err != nil {
+		return models.OrderWithItems{}, fmt.Errorf("%w: %v", domain.ErrInvalidPatch, err)
+	}
+	patched.CustomerName = normalizeName(patched.CustomerName)
+	if patched.CustomerName == "" {
+		return models.OrderWithItems{}, fmt.Errorf("%w: customer name is required", domain.ErrInvalidPatch)
+	}
+	if !patched.Status.IsValid() {
+		return models.OrderWithItems{}, fmt.Errorf("%w: unknown status %q", domain.ErrInvalidPatch, patched.Status)
+	}
+
+	if patched.CustomerName == current.CustomerName && patched.Status == current.Status {
+		serviceLogger.Debug("Merge patch made no changes", "order_id", id)
+		return current, nil
+	}
+	if patched.Status != current.Status {
+		if err := validateStatusTransition(current.Status, patched.Status); err != nil {
+			serviceLogger.WithError(err).Warn("Rejected status transition", "order_id", id, "from", current.Status, "to", patched.Status)
+			return models.OrderWithItems{}, err
+		}
+		precondition.Status = current.Status
+	}
+
+	orderToUpdate := models.Order{
+		ID:           id,
+		CustomerName: patched.CustomerName,
+		Status:       patched.Status,
+		UpdatedAt:    s.clock.Now().UTC(),
+	}
+	if err := s.repo.UpdateOrder(ctx, orderToUpdate, precondition); err != nil {
+		serviceLogger.WithError(err).Error("Failed to update patched order", "order_id", id)
+		return models.OrderWithItems{}, err
+	}
+
+	current.CustomerName = orderToUpdate.CustomerName
+	current.Status = orderToUpdate.Status
+	current.UpdatedAt = orderToUpdate.UpdatedAt
+	return current, nil
+}
+
+// UpdateItemStatus sets one item's fulfillment status and re-derives the order status from its items
+func (s *OrderService) UpdateItemStatus(ctx context.Context, input models.UpdateItemStatusInput) (models.OrderWithItems, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "update_item_status")
+
+	if !input.Status.IsValid() {
+		serviceLogger.Error("Unknown item status", "order_id", input.OrderID, "item_id", input.ItemID, "status", input.Status)
+		return models.OrderWithItems{}, fmt.Errorf("%w: %q", domain.ErrInvalidItemStatus, input.Status)
+	}
+
+	if err := s.repo.UpdateOrderItemStatus(ctx, input.OrderID, input.ItemID, input.Status, s.clock.Now().UTC()); err != nil {
+		serviceLogger.WithError(err).Error("Failed to update item status", "order_id", input.OrderID, "item_id", input.ItemID)
+		return models.OrderWithItems{}, err
+	}
+
+	order, err := s.repo.GetOrderById(ctx, input.OrderID)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order after item update", "order_id", input.OrderID)
+		return models.OrderWithItems{}, err
+	}
+
+	return order, nil
+}
+
+// ShipOrder ships the given item quantities as one fulfillment. The order becomes completed once
+// every item has fully shipped and partially_shipped otherwise.
+func (s *OrderService) ShipOrder(ctx context.Context, input models.ShipOrderInput) (models.ShipOrderResult, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "ship_order")
+
+	if err := validateShipment(input.Items); err != nil {
+		serviceLogger.WithError(err).Warn("Invalid shipment", "order_id", input.OrderID)
+		return models.ShipOrderResult{}, err
+	}
+
+	now := s.clock.Now().UTC()
+	fulfillment, err := s.repo.ShipOrderItems(ctx, input.OrderID, input.Items, now)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to ship order items", "order_id", input.OrderID)
+		return models.ShipOrderResult{}, err
+	}
+
+	order, err := s.repo.GetOrderById(ctx, input.OrderID)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get order after shipment", "order_id", input.OrderID)
+		return models.ShipOrderResult{}, err
+	}
+
+	return models.ShipOrderResult{Order: order, Fulfillment: fulfillment}, nil
+}
+
+// validateShipment rejects empty shipments, non-positive quantities and repeated items
+func validateShipment(items []models.ShipItem) error {
+	if len(items) == 0 {
+		return fmt.Errorf("%w: at least one item is required", domain.ErrInvalidShipment)
+	}
+
+	seen := make(map[int]bool, len(items))
+	for _, item := range items {
+		if item.Quantity <= 0 {
+			return fmt.Errorf("%w: item %d quantity must be positive", domain.ErrInvalidShipment, item.ItemID)
+		}
+		if seen[item.ItemID] {
+			return fmt.Errorf("%w: item %d is listed more than once", domain.ErrInvalidShipment, item.ItemID)
+		}
+		seen[item.ItemID] = true
+	}
+	return nil
+}
+
+// AddOrderNote validates and stores a note on the order. The text and author are trimmed, and
+// an empty author is recorded as models.DefaultNoteAuthor.
+func (s *OrderService) AddOrderNote(ctx context.Context, input models.CreateNoteInput) (models.OrderNote, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "add_order_note")
+
+	note := models.OrderNote{
+		OrderID:   input.OrderID,
+		Author:    strings.TrimSpace(input.Author),
+		Text:      strings.TrimSpace(input.Text),
+		CreatedAt: s.clock.Now().UTC(),
+	}
+	if note.Author == "" {
+		note.Author = models.DefaultNoteAuthor
+	}
+	if err := validateNote(note); err != nil {
+		serviceLogger.WithError(err).Warn("Invalid order note", "order_id", input.OrderID)
+		return models.OrderNote{}, err
+	}
+
+	created, err := s.repo.CreateOrderNote(ctx, note)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to add order note", "order_id", input.OrderID)
+		return models.OrderNote{}, err
+	}
+
+	return created, nil
+}
+
+func (s *OrderService) ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "list_order_notes")
+
+	notes, err := s.repo.ListOrderNotes(ctx, orderID)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to list order notes", "order_id", orderID)
+		return nil, err
+	}
+
+	return notes, nil
+}
+
+// validateNote rejects notes without text and text or authors longer than the columns allow
+func validateNote(note models.OrderNote) error {
+	if note.Text == "" {
+		return fmt.Errorf("%w: text is required", domain.ErrInvalidNote)
+	}
+	if length := utf8.RuneCountInString(note.Text); length > models.MaxNoteLength {
+		return fmt.Errorf("%w: text must be at most %d characters, got %d", domain.ErrInvalidNote, models.MaxNoteLength, length)
+	}
+	if length := utf8.RuneCountInString(note.Author); length > models.MaxNoteAuthorLength {
+		return fmt.Errorf("%w: author must be at most %d characters, got %d", domain.ErrInvalidNote, models.MaxNoteAuthorLength, length)
+	}
+	return nil
+}
+
 func (s *OrderService) DeleteOrder(ctx context.Context, id int) error {
-	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "delete_order")
 	err := s.repo.DeleteOrder(ctx, id)
 	if err != nil {
 		serviceLogger.WithError(err).Error("Failed to delete order", "order_id", id)
@@ -124,13 +942,151 @@ func (s *OrderService) DeleteOrder(ctx context.Context, id int) error {
 	return nil
 }
 
+// DeleteOrdersByFilter removes every order matching filter in batches and returns how many were
+// deleted. An empty filter is refused with ErrEmptyFilter rather than deleting everything.
+func (s *OrderService) DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter) (int64, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "delete_orders_by_filter")
+
+	if filter.IsEmpty() {
+		serviceLogger.Error("Refusing bulk delete without a filter")
+		return 0, domain.ErrEmptyFilter
+	}
+	if filter.Status != "" && !filter.Status.IsValid() {
+		serviceLogger.Error("Unknown status in bulk delete filter", "status", filter.Status)
+		return 0, fmt.Errorf("unknown status %q", filter.Status)
+	}
+
+	deleted, err := s.repo.DeleteOrdersByFilter(ctx, filter, bulkDeleteBatchSize)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to bulk delete orders", "status", filter.Status, "deleted", deleted)
+		return deleted, err
+	}
+
+	serviceLogger.Info("Bulk deleted orders", "status", filter.Status, "created_before", filter.CreatedBefore, "deleted", deleted)
+	return deleted, nil
+}
+
 func (s *OrderService) ListOrders(ctx context.Context, input models.ListInput) (models.ListPaginatedOrders, error) {
-	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "list_orders")
+
+	if input.Page < 1 {
+		input.Page = 1
+	}
+	if input.Size < 1 {
+		input.Size = 10
+	}
+
+	// Large offsets make Postgres walk every skipped row, so check the page exists first
+	if input.After == nil && deepPageOffset > 0 && (input.Page-1) >= deepPageOffset/input.Size {
+		total, err := s.repo.CountOrders(ctx, input)
+		if err != nil {
+			serviceLogger.WithError(err).Error("Failed to count orders", "page", input.Page, "size", input.Size)
+			return models.ListPaginatedOrders{}, err
+		}
+
+		totalPages := (total + input.Size - 1) / input.Size
+		if input.Page > totalPages {
+			serviceLogger.Debug("Requested page is past the last page", "page", input.Page, "total_pages", totalPages)
+			return models.ListPaginatedOrders{
+				Data:       []models.OrderWithItems{},
+				Total:      total,
+				Page:       input.Page,
+				Size:       input.Size,
+				TotalPages: totalPages,
+			}, nil
+		}
+	}
+
 	orders, err := s.repo.ListOrders(ctx, input)
 	if err != nil {
 		serviceLogger.WithError(err).Error("Failed to list orders", "page", input.Page, "size", input.Size)
 		return models.ListPaginatedOrders{}, err
 	}
 
+	if len(orders.Data) == input.Size {
+		orders.NextCursor = models.NewListCursor(input, orders.Data[len(orders.Data)-1].Order).Encode()
+	}
 	return *orders, nil
 }
+
+func (s *OrderService) ExpirePendingOrders(ctx context.Context, maxAge time.Duration) (int64, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "expire_pending_orders")
+	if maxAge <= 0 {
+		serviceLogger.Error("Invalid pending order max age", "max_age", maxAge)
+		return 0, errors.New("max age must be greater than 0")
+	}
+
+	now := s.clock.Now().UTC()
+	expired, err := s.repo.ExpirePendingOrders(ctx, now.Add(-maxAge), now)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to expire pending orders", "max_age", maxAge)
+		return 0, err
+	}
+
+	return expired, nil
+}
+
+// RecentOrders returns up to limit of the newest orders; limit falls back to the default when
+// not positive and is capped at models.MaxRecentOrdersLimit
+func (s *OrderService) RecentOrders(ctx context.Context, limit int) ([]models.Order, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "recent_orders")
+
+	if limit < 1 {
+		limit = models.DefaultRecentOrdersLimit
+	}
+	if limit > models.MaxRecentOrdersLimit {
+		limit = models.MaxRecentOrdersLimit
+	}
+
+	orders, err := s.repo.RecentOrders(ctx, limit)
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get recent orders", "limit", limit)
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+func (s *OrderService) GetDailyTotals(ctx context.Context, input models.DailyTotalsInput) ([]models.DailyOrderTotal, error) {
+	serviceLogger := logger.LoggerWithRequestIDFromContext(ctx).WithField("operation", "get_daily_totals")
+
+	from := truncateToDay(input.From)
+	to := truncateToDay(input.To)
+	if to.Before(from) {
+		serviceLogger.Error("Invalid daily totals range", "from", from, "to", to)
+		return nil, errors.New("from must not be after to")
+	}
+	days := int(to.Sub(from).Hours()/24) + 1
+	if days > models.MaxDailyTotalsDays {
+		serviceLogger.Error("Daily totals range too large", "days", days)
+		return nil, fmt.Errorf("date range must not exceed %d days", models.MaxDailyTotalsDays)
+	}
+
+	totals, err := s.repo.GetDailyTotals(ctx, from, to.AddDate(0, 0, 1))
+	if err != nil {
+		serviceLogger.WithError(err).Error("Failed to get daily totals", "from", from, "to", to)
+		return nil, err
+	}
+
+	return fillDailyGaps(from, to, totals), nil
+}
+
+// fillDailyGaps returns one entry per day in [from, to], using zero values for days without orders
+func fillDailyGaps(from, to time.Time, totals []models.DailyOrderTotal) []models.DailyOrderTotal {
+	byDay := make(map[string]models.DailyOrderTotal, len(totals))
+	for _, total := range totals {
+		byDay[total.Date.Format(models.DailyTotalsDateLayout)] = total
+	}
+
+	filled := make([]models.DailyOrderTotal, 0, int(to.Sub(from).Hours()/24)+1)
+	for day := from; !day.After(to); day = day.AddDate(0, 0, 1) {
+		total := byDay[day.Format(models.DailyTotalsDateLayout)]
+		total.Date = day
+		filled = append(filled, total)
+	}
+	return filled
+}
+
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -3,12 +3,22 @@ package services
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/Testzyler/order-management-go/application/domain"
 	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/clock"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
 )
 
 // MockOrderRepository is a mock implementation of OrderRepository
@@ -16,9 +26,27 @@ type MockOrderRepository struct {
 	mock.Mock
 }
 
-func (m *MockOrderRepository) CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) error {
+func (m *MockOrderRepository) CreateOrder(ctx context.Context, order models.Order, items []models.OrderItem) (models.OrderWithItems, error) {
 	args := m.Called(ctx, order, items)
-	return args.Error(0)
+	if args.Get(0) == nil {
+		return models.OrderWithItems{}, args.Error(1)
+	}
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error) {
+	args := m.Called(ctx, orderNumber)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error) {
+	args := m.Called(ctx, publicID)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error) {
+	args := m.Called(ctx, publicID)
+	return args.Int(0), args.Error(1)
 }
 
 func (m *MockOrderRepository) GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error) {
@@ -29,11 +57,55 @@ func (m *MockOrderRepository) GetOrderById(ctx context.Context, id int) (models.
 	return args.Get(0).(models.OrderWithItems), args.Error(1)
 }
 
-func (m *MockOrderRepository) UpdateOrder(ctx context.Context, order models.Order) error {
-	args := m.Called(ctx, order)
+func (m *MockOrderRepository) GetOrdersByIds(ctx context.Context, ids []int) (map[int]models.OrderWithItems, error) {
+	args := m.Called(ctx, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[int]models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrder(ctx context.Context, order models.Order, precondition models.OrderPrecondition) error {
+	args := m.Called(ctx, order, precondition)
+	return args.Error(0)
+}
+
+func (m *MockOrderRepository) DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter, batchSize int) (int64, error) {
+	args := m.Called(ctx, filter, batchSize)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderRepository) CancelOrder(ctx context.Context, id int, cancelledAt time.Time) (models.Status, error) {
+	args := m.Called(ctx, id, cancelledAt)
+	return args.Get(0).(models.Status), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpdateOrderItemStatus(ctx context.Context, orderID int, itemID int, status models.ItemStatus, updatedAt time.Time) error {
+	args := m.Called(ctx, orderID, itemID, status, updatedAt)
 	return args.Error(0)
 }
 
+func (m *MockOrderRepository) CreateOrders(ctx context.Context, orders []models.OrderWithItems) ([]models.OrderWithItems, error) {
+	args := m.Called(ctx, orders)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) BulkCreateOrders(ctx context.Context, orders []models.OrderWithItems) ([]models.OrderWithItems, error) {
+	args := m.Called(ctx, orders)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderRepository) ShipOrderItems(ctx context.Context, orderID int, items []models.ShipItem, shippedAt time.Time) (models.Fulfillment, error) {
+	args := m.Called(ctx, orderID, items, shippedAt)
+	return args.Get(0).(models.Fulfillment), args.Error(1)
+}
+
 func (m *MockOrderRepository) DeleteOrder(ctx context.Context, id int) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -47,6 +119,55 @@ func (m *MockOrderRepository) ListOrders(ctx context.Context, input models.ListI
 	return args.Get(0).(*models.ListPaginatedOrders), args.Error(1)
 }
 
+func (m *MockOrderRepository) StreamOrders(ctx context.Context, filter models.OrderFilter) (<-chan models.OrderWithItems, <-chan error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(<-chan models.OrderWithItems), args.Get(1).(<-chan error)
+}
+
+func (m *MockOrderRepository) CountOrders(ctx context.Context, input models.ListInput) (int, error) {
+	args := m.Called(ctx, input)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockOrderRepository) ExpirePendingOrders(ctx context.Context, createdBefore time.Time, expiredAt time.Time) (int64, error) {
+	args := m.Called(ctx, createdBefore, expiredAt)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderRepository) RecentOrders(ctx context.Context, limit int) ([]models.Order, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Order), args.Error(1)
+}
+
+func (m *MockOrderRepository) CreateOrderNote(ctx context.Context, note models.OrderNote) (models.OrderNote, error) {
+	args := m.Called(ctx, note)
+	return args.Get(0).(models.OrderNote), args.Error(1)
+}
+
+func (m *MockOrderRepository) ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderNote), args.Error(1)
+}
+
+func (m *MockOrderRepository) UpsertOrderByNumber(ctx context.Context, order models.Order, items []models.OrderItem, statusFrom []models.Status, ownerOnly bool) (models.OrderWithItems, bool, error) {
+	args := m.Called(ctx, order, items, statusFrom, ownerOnly)
+	return args.Get(0).(models.OrderWithItems), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderRepository) GetDailyTotals(ctx context.Context, from time.Time, to time.Time) ([]models.DailyOrderTotal, error) {
+	args := m.Called(ctx, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.DailyOrderTotal), args.Error(1)
+}
+
 func TestNewOrderService(t *testing.T) {
 	mockRepo := &MockOrderRepository{}
 	service := NewOrderService(mockRepo)
@@ -74,15 +195,239 @@ func TestOrderService_CreateOrder_Success(t *testing.T) {
 
 	ctx := context.Background()
 
+	createdOrder := models.OrderWithItems{
+		Order: models.Order{
+			ID:           42,
+			CustomerName: "John Doe",
+			TotalAmount:  100.50,
+			Status:       models.StatusPending,
+		},
+		Items: []models.OrderItem{
+			{
+				ID:          7,
+				OrderID:     42,
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
 	// Set up mock expectation
-	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(nil)
+	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(createdOrder, nil)
+
+	// Act
+	result, err := service.CreateOrder(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, createdOrder, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_CreateOrder_SetsTimestamps(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 14, 30, 0, 0, time.FixedZone("ICT", 7*60*60))
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+	ctx := context.Background()
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1, Price: 10},
+			{ProductName: "Product 2", Quantity: 2, Price: 5},
+		},
+	}
+
+	var order models.Order
+	var items []models.OrderItem
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).
+		Run(func(args mock.Arguments) {
+			order = args.Get(1).(models.Order)
+			items = args.Get(2).([]models.OrderItem)
+		}).
+		Return(models.OrderWithItems{}, nil)
+
+	// Act
+	_, err := service.CreateOrder(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, order.CreatedAt.IsZero())
+	assert.Equal(t, now.UTC(), order.CreatedAt)
+	assert.Equal(t, time.UTC, order.CreatedAt.Location())
+	assert.Equal(t, order.CreatedAt, order.UpdatedAt)
+	if assert.Len(t, items, 2) {
+		for _, item := range items {
+			assert.Equal(t, order.CreatedAt, item.CreatedAt)
+			assert.Equal(t, order.CreatedAt, item.UpdatedAt)
+		}
+	}
+}
+
+func TestOrderService_CreateOrder_RecordsOwner(t *testing.T) {
+	cases := []struct {
+		name      string
+		ctx       context.Context
+		inputUser string
+		wantOwner string
+	}{
+		{name: "authenticated user", ctx: logger.WithUserIDToContext(context.Background(), "user-42"), wantOwner: "user-42"},
+		{name: "explicit owner", ctx: logger.WithUserIDToContext(context.Background(), "user-42"), inputUser: "user-7", wantOwner: "user-7"},
+		{name: "unauthenticated", ctx: context.Background(), wantOwner: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			input := models.CreateOrderInput{
+				CustomerName: "John Doe",
+				UserID:       tc.inputUser,
+				Items:        []models.OrderItem{{ProductName: "Product 1", Quantity: 1, Price: 10}},
+			}
+
+			var order models.Order
+			mockRepo.On("CreateOrder", tc.ctx, mock.Anything, mock.Anything).
+				Run(func(args mock.Arguments) { order = args.Get(1).(models.Order) }).
+				Return(models.OrderWithItems{}, nil)
+
+			// Act
+			_, err := service.CreateOrder(tc.ctx, input)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantOwner, order.UserID)
+		})
+	}
+}
+
+// mixedBatch holds valid orders at indexes 0 and 2 and invalid ones at 1 and 3
+func mixedBatch() []models.CreateOrderInput {
+	item := []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 10}}
+	return []models.CreateOrderInput{
+		{CustomerName: "Alice", Items: item},
+		{CustomerName: "", Items: item},
+		{CustomerName: "Carol", Items: item},
+		{CustomerName: "Dave", Items: []models.OrderItem{{ProductName: "Widget", Quantity: 0, Price: 10}}},
+	}
+}
+
+func TestOrderService_CreateOrders_BestEffort(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+
+	byCustomer := func(name string) any {
+		return mock.MatchedBy(func(order models.Order) bool { return order.CustomerName == name })
+	}
+	mockRepo.On("CreateOrder", ctx, byCustomer("Alice"), mock.Anything).Return(models.OrderWithItems{Order: models.Order{ID: 1, CustomerName: "Alice"}}, nil)
+	mockRepo.On("CreateOrder", ctx, byCustomer("Carol"), mock.Anything).Return(models.OrderWithItems{}, errors.New("unique violation"))
+
+	// Act
+	result, err := service.CreateOrders(ctx, mixedBatch(), false)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, result.Created, 1) {
+		assert.Equal(t, 1, result.Created[0].ID)
+	}
+	assert.Equal(t, []models.BatchOrderError{
+		{Index: 1, Message: "customer name is required"},
+		{Index: 2, Message: "unique violation"},
+		{Index: 3, Message: "item quantity must be greater than 0"},
+	}, result.Errors)
+	mockRepo.AssertNotCalled(t, "CreateOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_BulkCreateOrders_MixedBatch(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+
+	created := []models.OrderWithItems{{Order: models.Order{ID: 1, CustomerName: "Alice"}}, {Order: models.Order{ID: 2, CustomerName: "Carol"}}}
+	mockRepo.On("BulkCreateOrders", ctx, mock.MatchedBy(func(orders []models.OrderWithItems) bool {
+		return len(orders) == 2 && orders[0].CustomerName == "Alice" && orders[1].CustomerName == "Carol"
+	})).Return(created, nil).Once()
 
 	// Act
-	err := service.CreateOrder(ctx, input)
+	results, err := service.BulkCreateOrders(ctx, mixedBatch(), false)
 
 	// Assert
 	assert.NoError(t, err)
+	if assert.Len(t, results, 4) {
+		assert.Equal(t, 1, results[0].Order.ID)
+		assert.Equal(t, models.BulkOrderResult{Index: 1, Error: "customer name is required"}, results[1])
+		assert.Equal(t, 2, results[2].Order.ID)
+		assert.Equal(t, 2, results[2].Index)
+		assert.Equal(t, models.BulkOrderResult{Index: 3, Error: "item quantity must be greater than 0"}, results[3])
+	}
 	mockRepo.AssertExpectations(t)
+	mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_BulkCreateOrders_AtomicRejectsInvalid(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	results, err := service.BulkCreateOrders(context.Background(), mixedBatch(), true)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrBatchInvalid)
+	if assert.Len(t, results, 4) {
+		assert.Nil(t, results[0].Order)
+		assert.Empty(t, results[0].Error)
+		assert.Equal(t, "customer name is required", results[1].Error)
+	}
+	mockRepo.AssertNotCalled(t, "BulkCreateOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_CreateOrders_AtomicRejectsInvalid(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	result, err := service.CreateOrders(context.Background(), mixedBatch(), true)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrBatchInvalid)
+	assert.Empty(t, result.Created)
+	assert.Equal(t, []models.BatchOrderError{
+		{Index: 1, Message: "customer name is required"},
+		{Index: 3, Message: "item quantity must be greater than 0"},
+	}, result.Errors)
+	mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertNotCalled(t, "CreateOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_CreateOrders_AtomicCreatesInOneTransaction(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	batch := mixedBatch()
+	valid := []models.CreateOrderInput{batch[0], batch[2]}
+
+	created := []models.OrderWithItems{{Order: models.Order{ID: 1}}, {Order: models.Order{ID: 2}}}
+	mockRepo.On("CreateOrders", ctx, mock.MatchedBy(func(orders []models.OrderWithItems) bool {
+		return len(orders) == 2 && orders[0].CustomerName == "Alice" && orders[1].CustomerName == "Carol" &&
+			orders[0].TotalAmount == 10 && orders[0].Status == models.StatusPending
+	})).Return(created, nil)
+
+	// Act
+	result, err := service.CreateOrders(ctx, valid, true)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, created, result.Created)
+	assert.Empty(t, result.Errors)
+	mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
 }
 
 func TestOrderService_CreateOrder_EmptyCustomerName(t *testing.T) {
@@ -105,7 +450,7 @@ func TestOrderService_CreateOrder_EmptyCustomerName(t *testing.T) {
 	ctx := context.Background()
 
 	// Act
-	err := service.CreateOrder(ctx, input)
+	_, err := service.CreateOrder(ctx, input)
 
 	// Assert
 	assert.Error(t, err)
@@ -113,6 +458,138 @@ func TestOrderService_CreateOrder_EmptyCustomerName(t *testing.T) {
 	mockRepo.AssertNotCalled(t, "CreateOrder")
 }
 
+func TestOrderService_CreateOrder_WhitespaceOnlyNames(t *testing.T) {
+	tests := []struct {
+		name          string
+		input         models.CreateOrderInput
+		expectedError string
+	}{
+		{
+			name:          "customer name",
+			input:         models.CreateOrderInput{CustomerName: " \t ", Items: []models.OrderItem{{ProductName: "Product 1", Quantity: 1, Price: 10}}},
+			expectedError: "customer name is required",
+		},
+		{
+			name:          "product name",
+			input:         models.CreateOrderInput{CustomerName: "John Doe", Items: []models.OrderItem{{ProductName: "   ", Quantity: 1, Price: 10}}},
+			expectedError: "item product name is required",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, err := service.CreateOrder(context.Background(), tt.input)
+
+			// Assert
+			assert.ErrorContains(t, err, tt.expectedError)
+			mockRepo.AssertNotCalled(t, "CreateOrder")
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_TrimsNames(t *testing.T) {
+	tests := []struct {
+		name             string
+		collapse         bool
+		expectedCustomer string
+		expectedProduct  string
+	}{
+		{name: "trimmed", collapse: false, expectedCustomer: "John   Doe", expectedProduct: "Blue  Widget"},
+		{name: "collapsed", collapse: true, expectedCustomer: "John Doe", expectedProduct: "Blue Widget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetCollapseNameWhitespace(tt.collapse)
+			defer SetCollapseNameWhitespace(false)
+
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+
+			input := models.CreateOrderInput{
+				CustomerName: "  John   Doe \n",
+				Items:        []models.OrderItem{{ProductName: "\tBlue  Widget ", Quantity: 1, Price: 10}},
+			}
+			mockRepo.On("CreateOrder", ctx, mock.MatchedBy(func(order models.Order) bool {
+				return order.CustomerName == tt.expectedCustomer
+			}), mock.MatchedBy(func(items []models.OrderItem) bool {
+				return len(items) == 1 && items[0].ProductName == tt.expectedProduct
+			})).Return(models.OrderWithItems{}, nil)
+
+			// Act
+			_, err := service.CreateOrder(ctx, input)
+
+			// Assert
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_DuplicateItemStrategy(t *testing.T) {
+	items := []models.OrderItem{
+		{ProductName: "Widget", Quantity: 1, Price: 10},
+		{ProductName: "Gadget", Quantity: 1, Price: 5},
+		{ProductName: "Widget", Quantity: 2, Price: 10},
+		{ProductName: "Gadget", Quantity: 1, Price: 6},
+	}
+	tests := []struct {
+		name          string
+		strategy      models.DuplicateItemStrategy
+		expectedItems []string
+		expectedErr   string
+	}{
+		{name: "allow", strategy: models.DuplicateItemsAllow, expectedItems: []string{"Widget x1", "Gadget x1", "Widget x2", "Gadget x1"}},
+		{name: "merge", strategy: models.DuplicateItemsMerge, expectedItems: []string{"Widget x3", "Gadget x1", "Gadget x1"}},
+		{name: "reject", strategy: models.DuplicateItemsReject, expectedErr: "duplicate items: Widget, Gadget"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			SetDuplicateItemStrategy(tt.strategy)
+			defer SetDuplicateItemStrategy(models.DuplicateItemsAllow)
+
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+
+			var createdItems []string
+			var total float64
+			mockRepo.On("CreateOrder", ctx, mock.MatchedBy(func(order models.Order) bool {
+				total = order.TotalAmount
+				return true
+			}), mock.MatchedBy(func(items []models.OrderItem) bool {
+				for _, item := range items {
+					createdItems = append(createdItems, fmt.Sprintf("%s x%d", item.ProductName, item.Quantity))
+				}
+				return true
+			})).Return(models.OrderWithItems{}, nil)
+
+			// Act
+			_, err := service.CreateOrder(ctx, models.CreateOrderInput{CustomerName: "Jane", Items: items})
+
+			// Assert
+			if tt.expectedErr != "" {
+				assert.ErrorIs(t, err, domain.ErrDuplicateItems)
+				assert.EqualError(t, err, tt.expectedErr)
+				mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedItems, createdItems)
+			assert.Equal(t, 41.0, total)
+		})
+	}
+}
+
 func TestOrderService_CreateOrder_RepositoryError(t *testing.T) {
 	// Arrange
 	mockRepo := &MockOrderRepository{}
@@ -134,10 +611,10 @@ func TestOrderService_CreateOrder_RepositoryError(t *testing.T) {
 	repoError := errors.New("database connection failed")
 
 	// Set up mock expectation
-	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(repoError)
+	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(nil, repoError)
 
 	// Act
-	err := service.CreateOrder(ctx, input)
+	_, err := service.CreateOrder(ctx, input)
 
 	// Assert
 	assert.Error(t, err)
@@ -145,96 +622,200 @@ func TestOrderService_CreateOrder_RepositoryError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderService_GetOrderById_Success(t *testing.T) {
+func TestOrderService_CreateOrder_TotalMatches(t *testing.T) {
 	// Arrange
+	SetValidateClientTotal(true)
+	defer SetValidateClientTotal(false)
+
 	mockRepo := &MockOrderRepository{}
 	service := NewOrderService(mockRepo)
 
-	orderID := 1
-	expectedOrder := models.OrderWithItems{
-		Order: models.Order{
-			ID:           orderID,
-			CustomerName: "John Doe",
-			TotalAmount:  100.50,
-			Status:       models.StatusPending,
-			CreatedAt:    time.Now(),
-			UpdatedAt:    time.Now(),
-		},
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		TotalAmount:  100.50,
 		Items: []models.OrderItem{
-			{
-				ID:          1,
-				OrderID:     orderID,
-				ProductName: "Product 1",
-				Quantity:    2,
-				Price:       50.25,
-				CreatedAt:   time.Now(),
-				UpdatedAt:   time.Now(),
-			},
+			{ProductName: "Product 1", Quantity: 2, Price: 50.25},
 		},
 	}
 
 	ctx := context.Background()
-
-	// Set up mock expectation
-	mockRepo.On("GetOrderById", ctx, orderID).Return(expectedOrder, nil)
+	mockRepo.On("CreateOrder", ctx, mock.MatchedBy(func(order models.Order) bool {
+		return order.TotalAmount == 100.50
+	}), mock.AnythingOfType("[]models.OrderItem")).Return(models.OrderWithItems{}, nil)
 
 	// Act
-	result, err := service.GetOrderById(ctx, orderID)
+	_, err := service.CreateOrder(ctx, input)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, expectedOrder, result)
 	mockRepo.AssertExpectations(t)
 }
 
-func TestOrderService_GetOrderById_NotFound(t *testing.T) {
+func TestOrderService_CreateOrder_TotalMismatch(t *testing.T) {
 	// Arrange
+	SetValidateClientTotal(true)
+	defer SetValidateClientTotal(false)
+
 	mockRepo := &MockOrderRepository{}
 	service := NewOrderService(mockRepo)
 
-	orderID := 999
-	ctx := context.Background()
-
-	// Set up mock expectation
-	mockRepo.On("GetOrderById", ctx, orderID).Return(models.OrderWithItems{}, errors.New("order not found"))
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		TotalAmount:  99.99,
+		Items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 2, Price: 50.25},
+		},
+	}
 
 	// Act
-	result, err := service.GetOrderById(ctx, orderID)
+	_, err := service.CreateOrder(context.Background(), input)
 
 	// Assert
-	assert.Error(t, err)
-	assert.Equal(t, models.OrderWithItems{}, result)
-	assert.Contains(t, err.Error(), "order not found")
-	mockRepo.AssertExpectations(t)
+	assert.ErrorIs(t, err, domain.ErrTotalMismatch)
+	mockRepo.AssertNotCalled(t, "CreateOrder")
 }
 
-// Benchmark tests for performance profiling
-func BenchmarkOrderService_CreateOrder(b *testing.B) {
+func TestOrderService_CreateOrder_TotalOverflow(t *testing.T) {
+	tests := []struct {
+		name  string
+		items []models.OrderItem
+	}{
+		{name: "overflows to infinity", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: math.MaxInt64, Price: math.MaxFloat64},
+		}},
+		{name: "sum overflows", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1, Price: math.MaxFloat64},
+			{ProductName: "Product 2", Quantity: 1, Price: math.MaxFloat64},
+		}},
+		{name: "exceeds column", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1000000, Price: 100},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, err := service.CreateOrder(context.Background(), models.CreateOrderInput{CustomerName: "John Doe", Items: tt.items})
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrTotalOutOfRange)
+			mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_ItemSubtotalOutOfRange(t *testing.T) {
+	tests := []struct {
+		name          string
+		items         []models.OrderItem
+		expectedField string
+	}{
+		{name: "max quantity", expectedField: "items[1]", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1, Price: 10},
+			{ProductName: "Product 2", Quantity: math.MaxInt64, Price: 0.01},
+		}},
+		{name: "overflows to infinity", expectedField: "items[0]", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: math.MaxInt64, Price: math.MaxFloat64},
+		}},
+		{name: "just over the maximum", expectedField: "items[2]", items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1, Price: 1},
+			{ProductName: "Product 2", Quantity: 1, Price: 1},
+			{ProductName: "Product 3", Quantity: 10000001, Price: 10},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, err := service.CreateOrder(context.Background(), models.CreateOrderInput{CustomerName: "John Doe", Items: tt.items})
+
+			// Assert
+			var fieldErr *domain.FieldError
+			if assert.ErrorAs(t, err, &fieldErr) {
+				assert.Equal(t, tt.expectedField, fieldErr.Field)
+			}
+			assert.ErrorIs(t, err, domain.ErrTotalOutOfRange)
+			mockRepo.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_CreateOrder_SumOutOfRangeIsNotFieldError(t *testing.T) {
+	// Arrange: each subtotal fits, the sum does not
 	mockRepo := &MockOrderRepository{}
 	service := NewOrderService(mockRepo)
 
 	input := models.CreateOrderInput{
 		CustomerName: "John Doe",
-		Status:       models.StatusPending,
 		Items: []models.OrderItem{
-			{
-				ProductName: "Product 1",
-				Quantity:    2,
-				Price:       50.25,
-			},
+			{ProductName: "Product 1", Quantity: 6000000, Price: 10},
+			{ProductName: "Product 2", Quantity: 6000000, Price: 10},
 		},
 	}
 
-	ctx := context.Background()
-	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(nil)
+	// Act
+	_, err := service.CreateOrder(context.Background(), input)
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_ = service.CreateOrder(ctx, input)
+	// Assert
+	var fieldErr *domain.FieldError
+	assert.ErrorIs(t, err, domain.ErrTotalOutOfRange)
+	assert.False(t, errors.As(err, &fieldErr))
+}
+
+func TestOrderService_CreateOrder_ConfiguredMaxTotal(t *testing.T) {
+	// Arrange
+	SetMaxOrderTotal(500)
+	defer SetMaxOrderTotal(DefaultMaxOrderTotal)
+
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items:        []models.OrderItem{{ProductName: "Product 1", Quantity: 6, Price: 100}},
 	}
+
+	// Act
+	_, err := service.CreateOrder(context.Background(), input)
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrTotalOutOfRange)
 }
 
-func BenchmarkOrderService_GetOrderById(b *testing.B) {
+func TestOrderService_CreateOrder_TotalMismatchValidationDisabled(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		TotalAmount:  99.99,
+		Items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 2, Price: 50.25},
+		},
+	}
+
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(models.OrderWithItems{}, nil)
+
+	// Act
+	_, err := service.CreateOrder(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetOrderById_Success(t *testing.T) {
+	// Arrange
 	mockRepo := &MockOrderRepository{}
 	service := NewOrderService(mockRepo)
 
@@ -262,10 +843,1263 @@ func BenchmarkOrderService_GetOrderById(b *testing.B) {
 	}
 
 	ctx := context.Background()
+
+	// Set up mock expectation
 	mockRepo.On("GetOrderById", ctx, orderID).Return(expectedOrder, nil)
 
-	b.ResetTimer()
-	for i := 0; i < b.N; i++ {
-		_, _ = service.GetOrderById(ctx, orderID)
+	// Act
+	result, err := service.GetOrderById(ctx, orderID)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, expectedOrder, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetOrderById_NotFound(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	orderID := 999
+	ctx := context.Background()
+
+	// Set up mock expectation
+	mockRepo.On("GetOrderById", ctx, orderID).Return(models.OrderWithItems{}, errors.New("order not found"))
+
+	// Act
+	result, err := service.GetOrderById(ctx, orderID)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, models.OrderWithItems{}, result)
+	assert.Contains(t, err.Error(), "order not found")
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetOrderByPublicID_Canonicalizes(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	publicID := "3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b"
+	order := models.OrderWithItems{Order: models.Order{ID: 42, PublicID: publicID}}
+	mockRepo.On("GetOrderByPublicID", ctx, publicID).Return(order, nil)
+
+	// Act
+	result, err := service.GetOrderByPublicID(ctx, "3F2B8C1E-9A4D-4E5F-8B6A-1C2D3E4F5A6B")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, order, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetOrderIDByPublicID_InvalidID(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	_, err := service.GetOrderIDByPublicID(context.Background(), "42")
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid order public ID")
+	mockRepo.AssertNotCalled(t, "GetOrderIDByPublicID", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_ExpirePendingOrders_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+
+	ctx := context.Background()
+	maxAge := time.Hour
+
+	mockRepo.On("ExpirePendingOrders", ctx, now.Add(-maxAge), now).Return(int64(3), nil)
+
+	// Act
+	expired, err := service.ExpirePendingOrders(ctx, maxAge)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), expired)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ExpirePendingOrders_InvalidMaxAge(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	expired, err := service.ExpirePendingOrders(context.Background(), 0)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), expired)
+	mockRepo.AssertNotCalled(t, "ExpirePendingOrders")
+}
+
+func TestOrderService_UpdateOrder_AllowedTransitions(t *testing.T) {
+	tests := []struct {
+		from models.Status
+		to   models.Status
+	}{
+		{from: models.StatusPending, to: models.StatusProcessing},
+		{from: models.StatusPending, to: models.StatusCancelled},
+		{from: models.StatusProcessing, to: models.StatusPartiallyShipped},
+		{from: models.StatusProcessing, to: models.StatusCompleted},
+		{from: models.StatusProcessing, to: models.StatusCancelled},
+		{from: models.StatusPartiallyShipped, to: models.StatusCompleted},
+		{from: models.StatusPending, to: models.StatusPending},
+		{from: models.StatusCompleted, to: models.StatusCompleted},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+			mockRepo.On("GetOrderById", ctx, 1).Return(models.OrderWithItems{Order: models.Order{ID: 1, Status: tt.from}}, nil)
+			mockRepo.On("UpdateOrder", ctx, mock.MatchedBy(func(order models.Order) bool {
+				return order.ID == 1 && order.Status == tt.to
+			}), models.OrderPrecondition{Status: tt.from}).Return(nil)
+
+			// Act
+			err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: tt.to})
+
+			// Assert
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_UpdateOrder_DisallowedTransitions(t *testing.T) {
+	tests := []struct {
+		from models.Status
+		to   models.Status
+	}{
+		{from: models.StatusPending, to: models.StatusCompleted},
+		{from: models.StatusPending, to: models.StatusPartiallyShipped},
+		{from: models.StatusProcessing, to: models.StatusPending},
+		{from: models.StatusPartiallyShipped, to: models.StatusPending},
+		{from: models.StatusPartiallyShipped, to: models.StatusProcessing},
+		{from: models.StatusPartiallyShipped, to: models.StatusCancelled},
+		{from: models.StatusCompleted, to: models.StatusPending},
+		{from: models.StatusCompleted, to: models.StatusProcessing},
+		{from: models.StatusCompleted, to: models.StatusCancelled},
+		{from: models.StatusCancelled, to: models.StatusPending},
+		{from: models.StatusCancelled, to: models.StatusProcessing},
+		{from: models.StatusCancelled, to: models.StatusCompleted},
+		{from: models.StatusPending, to: "shipped"},
+		{from: models.StatusPending, to: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.from)+" to "+string(tt.to), func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+			mockRepo.On("GetOrderById", ctx, 1).Return(models.OrderWithItems{Order: models.Order{ID: 1, Status: tt.from}}, nil)
+
+			// Act
+			err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: tt.to})
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+			mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_UpdateOrder_UnknownOrder(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, 99).Return(models.OrderWithItems{}, pgx.ErrNoRows)
+
+	// Act
+	err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 99, Status: models.StatusProcessing})
+
+	// Assert
+	assert.ErrorIs(t, err, pgx.ErrNoRows)
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_UpdateOrder_UsesClock(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	fixedClock := clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	fixedClock.Advance(30 * time.Minute)
+	service := NewOrderServiceWithClock(mockRepo, fixedClock)
+
+	ctx := context.Background()
+	expected := models.Order{
+		ID:        1,
+		Status:    models.StatusProcessing,
+		UpdatedAt: time.Date(2025, 6, 1, 12, 30, 0, 0, time.UTC),
+	}
+	mockRepo.On("GetOrderById", ctx, 1).Return(models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusPending}}, nil)
+	mockRepo.On("UpdateOrder", ctx, expected, models.OrderPrecondition{Status: models.StatusPending}).Return(nil)
+
+	// Act
+	err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: models.StatusProcessing})
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpdateOrder_IfMatch(t *testing.T) {
+	readAt := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	current := models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusPending, UpdatedAt: readAt}}
+	stale := models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusPending, UpdatedAt: readAt.Add(-time.Hour)}}
+
+	cases := []struct {
+		name             string
+		ifMatch          string
+		wantPrecondition models.OrderPrecondition
+		wantErr          error
+	}{
+		{name: "matching", ifMatch: current.ETag(), wantPrecondition: models.OrderPrecondition{UpdatedAt: readAt, Status: models.StatusPending}},
+		{name: "wildcard", ifMatch: "*", wantPrecondition: models.OrderPrecondition{Status: models.StatusPending}},
+		{name: "stale", ifMatch: stale.ETag(), wantErr: domain.ErrOrderModified},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+			mockRepo.On("GetOrderById", ctx, 1).Return(current, nil)
+			mockRepo.On("UpdateOrder", ctx, mock.Anything, tc.wantPrecondition).Return(nil)
+
+			// Act
+			err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: models.StatusProcessing, IfMatch: tc.ifMatch})
+
+			// Assert
+			if tc.wantErr != nil {
+				assert.ErrorIs(t, err, tc.wantErr)
+				mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+				return
+			}
+			assert.NoError(t, err)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_UpdateOrder_ConditionalOnStatusRead(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, 1).Return(models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusPending}}, nil)
+	mockRepo.On("UpdateOrder", ctx, mock.Anything, models.OrderPrecondition{Status: models.StatusPending}).
+		Return(fmt.Errorf("%w: order 1", domain.ErrOrderModified))
+
+	// Act
+	err := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: models.StatusProcessing})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrOrderModified)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestStatusesInto(t *testing.T) {
+	assert.Equal(t, []models.Status{models.StatusPending}, statusesInto(models.StatusPending))
+	assert.Equal(t, []models.Status{models.StatusCompleted, models.StatusPartiallyShipped, models.StatusProcessing}, statusesInto(models.StatusCompleted))
+	assert.Equal(t, []models.Status{models.StatusCancelled, models.StatusPending, models.StatusProcessing}, statusesInto(models.StatusCancelled))
+}
+
+func newPatchableOrder() models.OrderWithItems {
+	return models.OrderWithItems{
+		Order: models.Order{
+			ID:           1,
+			CustomerName: "John Doe",
+			TotalAmount:  100.50,
+			Status:       models.StatusPending,
+		},
+	}
+}
+
+func TestOrderService_MergePatchOrder_SetField(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	fixedClock := clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	service := NewOrderServiceWithClock(mockRepo, fixedClock)
+
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, 1).Return(newPatchableOrder(), nil)
+	mockRepo.On("UpdateOrder", ctx, models.Order{
+		ID:           1,
+		CustomerName: "John Doe",
+		Status:       models.StatusProcessing,
+		UpdatedAt:    fixedClock.Now(),
+	}, models.OrderPrecondition{Status: models.StatusPending}).Return(nil)
+
+	// Act
+	result, err := service.MergePatchOrder(ctx, 1, []byte(`{"status":"processing"}`), "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusProcessing, result.Status)
+	assert.Equal(t, "John Doe", result.CustomerName)
+	assert.Equal(t, 100.50, result.TotalAmount)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_MergePatchOrder_UnsetRequiredField(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, 1).Return(newPatchableOrder(), nil)
+
+	// Act
+	_, err := service.MergePatchOrder(ctx, 1, []byte(`{"customer_name":null}`), "")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidPatch)
+	assert.Contains(t, err.Error(), "customer name is required")
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_MergePatchOrder_NoOp(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	current := newPatchableOrder()
+	mockRepo.On("GetOrderById", ctx, 1).Return(current, nil)
+
+	// Act
+	result, err := service.MergePatchOrder(ctx, 1, []byte(`{"status":"pending"}`), "")
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, current, result)
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_MergePatchOrder_ChecksStatusTransition(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, 1).Return(newPatchableOrder(), nil)
+
+	// Act
+	_, err := service.MergePatchOrder(ctx, 1, []byte(`{"status":"completed"}`), "")
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidStatusTransition)
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_MergePatchOrder_RejectsInvalidPatches(t *testing.T) {
+	tests := []struct {
+		name  string
+		patch string
+	}{
+		{name: "read-only field", patch: `{"total_amount":1}`},
+		{name: "not an object", patch: `["status"]`},
+		{name: "unknown status", patch: `{"status":"shipped"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			mockRepo.On("GetOrderById", mock.Anything, 1).Return(newPatchableOrder(), nil).Maybe()
+
+			// Act
+			_, err := service.MergePatchOrder(context.Background(), 1, []byte(tt.patch), "")
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidPatch)
+			mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_PatchOrder_OnlyProvidedFields(t *testing.T) {
+	name := "  Jane Roe "
+	processing := models.StatusProcessing
+	tests := []struct {
+		name     string
+		input    models.PatchOrderInput
+		expected models.Order
+	}{
+		{name: "customer name only", input: models.PatchOrderInput{CustomerName: &name}, expected: models.Order{ID: 1, CustomerName: "Jane Roe"}},
+		{name: "status only", input: models.PatchOrderInput{Status: &processing}, expected: models.Order{ID: 1, Status: models.StatusProcessing}},
+		{name: "both", input: models.PatchOrderInput{CustomerName: &name, Status: &processing}, expected: models.Order{ID: 1, CustomerName: "Jane Roe", Status: models.StatusProcessing}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			fixedClock := clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+			service := NewOrderServiceWithClock(mockRepo, fixedClock)
+			ctx := context.Background()
+
+			expected := tt.expected
+			expected.UpdatedAt = fixedClock.Now()
+			var precondition models.OrderPrecondition
+			if tt.input.Status != nil {
+				precondition.Status = models.StatusPending
+			}
+			mockRepo.On("GetOrderById", ctx, 1).Return(newPatchableOrder(), nil)
+			mockRepo.On("UpdateOrder", ctx, expected, precondition).Return(nil)
+
+			// Act
+			result, err := service.PatchOrder(ctx, 1, tt.input)
+
+			// Assert
+			assert.NoError(t, err)
+			if tt.input.CustomerName != nil {
+				assert.Equal(t, "Jane Roe", result.CustomerName)
+			} else {
+				assert.Equal(t, "John Doe", result.CustomerName)
+			}
+			if tt.input.Status != nil {
+				assert.Equal(t, models.StatusProcessing, result.Status)
+			} else {
+				assert.Equal(t, models.StatusPending, result.Status)
+			}
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_PatchOrder_Rejects(t *testing.T) {
+	blank := "   "
+	completed := models.StatusCompleted
+	unknown := models.Status("shipped")
+	tests := []struct {
+		name     string
+		input    models.PatchOrderInput
+		expected error
+	}{
+		{name: "no fields", input: models.PatchOrderInput{}, expected: domain.ErrInvalidPatch},
+		{name: "blank customer name", input: models.PatchOrderInput{CustomerName: &blank}, expected: domain.ErrInvalidPatch},
+		{name: "disallowed transition", input: models.PatchOrderInput{Status: &completed}, expected: domain.ErrInvalidStatusTransition},
+		{name: "unknown status", input: models.PatchOrderInput{Status: &unknown}, expected: domain.ErrInvalidStatusTransition},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			mockRepo.On("GetOrderById", mock.Anything, 1).Return(newPatchableOrder(), nil).Maybe()
+
+			// Act
+			_, err := service.PatchOrder(context.Background(), 1, tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_GetDailyTotals_FillsGaps(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	from := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	mockRepo.On("GetDailyTotals", ctx, from, to.AddDate(0, 0, 1)).Return([]models.DailyOrderTotal{
+		{Date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Count: 3, Total: 150.75},
+		{Date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Count: 1, Total: 20},
+	}, nil)
+
+	// Act
+	totals, err := service.GetDailyTotals(ctx, models.DailyTotalsInput{From: from, To: to})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, []models.DailyOrderTotal{
+		{Date: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC), Count: 3, Total: 150.75},
+		{Date: time.Date(2025, 3, 3, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC), Count: 1, Total: 20},
+	}, totals)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetDailyTotals_RangeTooLarge(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.AddDate(0, 0, models.MaxDailyTotalsDays)
+
+	// Act
+	_, err := service.GetDailyTotals(context.Background(), models.DailyTotalsInput{From: from, To: to})
+
+	// Assert
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetDailyTotals")
+}
+
+func TestOrderService_GetDailyTotals_InvertedRange(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	from := time.Date(2025, 3, 4, 0, 0, 0, 0, time.UTC)
+
+	// Act
+	_, err := service.GetDailyTotals(context.Background(), models.DailyTotalsInput{From: from, To: from.AddDate(0, 0, -1)})
+
+	// Assert
+	assert.Error(t, err)
+	mockRepo.AssertNotCalled(t, "GetDailyTotals")
+}
+
+// Benchmark tests for performance profiling
+func BenchmarkOrderService_CreateOrder(b *testing.B) {
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	input := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Status:       models.StatusPending,
+		Items: []models.OrderItem{
+			{
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.AnythingOfType("models.Order"), mock.AnythingOfType("[]models.OrderItem")).Return(models.OrderWithItems{}, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = service.CreateOrder(ctx, input)
+	}
+}
+
+func BenchmarkOrderService_GetOrderById(b *testing.B) {
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	orderID := 1
+	expectedOrder := models.OrderWithItems{
+		Order: models.Order{
+			ID:           orderID,
+			CustomerName: "John Doe",
+			TotalAmount:  100.50,
+			Status:       models.StatusPending,
+			CreatedAt:    time.Now(),
+			UpdatedAt:    time.Now(),
+		},
+		Items: []models.OrderItem{
+			{
+				ID:          1,
+				OrderID:     orderID,
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+				CreatedAt:   time.Now(),
+				UpdatedAt:   time.Now(),
+			},
+		},
+	}
+
+	ctx := context.Background()
+	mockRepo.On("GetOrderById", ctx, orderID).Return(expectedOrder, nil)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, _ = service.GetOrderById(ctx, orderID)
+	}
+}
+
+func TestOrderService_ListOrders_PagePastEnd(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	input := models.ListInput{Page: 999999999, Size: 10}
+
+	mockRepo.On("CountOrders", ctx, input).Return(42, nil)
+
+	// Act
+	start := time.Now()
+	result, err := service.ListOrders(ctx, input)
+	elapsed := time.Since(start)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.Data)
+	assert.NotNil(t, result.Data)
+	assert.Equal(t, 42, result.Total)
+	assert.Equal(t, 5, result.TotalPages)
+	assert.Equal(t, 999999999, result.Page)
+	assert.Less(t, elapsed, 100*time.Millisecond)
+	mockRepo.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ListOrders_DeepPageWithinRange(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	input := models.ListInput{Page: 150, Size: 10}
+	expected := &models.ListPaginatedOrders{
+		Data:       []models.OrderWithItems{{Order: models.Order{ID: 1}}},
+		Total:      1500,
+		Page:       150,
+		Size:       10,
+		TotalPages: 150,
+	}
+
+	mockRepo.On("CountOrders", ctx, input).Return(1500, nil)
+	mockRepo.On("ListOrders", ctx, input).Return(expected, nil)
+
+	// Act
+	result, err := service.ListOrders(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, *expected, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ListOrders_ShallowPageSkipsCount(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	input := models.ListInput{Page: 2, Size: 10}
+	expected := &models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 2, Size: 10}
+
+	mockRepo.On("ListOrders", ctx, input).Return(expected, nil)
+
+	// Act
+	_, err := service.ListOrders(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertNotCalled(t, "CountOrders", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ListOrders_FullPageReturnsCursor(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	createdAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	input := models.ListInput{Page: 1, Size: 2}
+	mockRepo.On("ListOrders", ctx, input).Return(&models.ListPaginatedOrders{
+		Data: []models.OrderWithItems{
+			{Order: models.Order{ID: 9, CreatedAt: createdAt.Add(time.Minute)}},
+			{Order: models.Order{ID: 8, CreatedAt: createdAt}},
+		},
+		Page: 1,
+		Size: 2,
+	}, nil)
+
+	// Act
+	result, err := service.ListOrders(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	cursor, err := models.DecodeCursor(result.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, models.SortCreatedDesc, cursor.Sort)
+	assert.Equal(t, 8, cursor.LastID)
+	assert.True(t, cursor.LastTime.Equal(createdAt))
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ListOrders_CursorSkipsDeepPageCheck(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	input := models.ListInput{Page: 999999999, Size: 10, After: &models.ListCursor{Sort: models.SortCreatedDesc, LastID: 8}}
+	mockRepo.On("ListOrders", ctx, input).Return(&models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Size: 10}, nil)
+
+	// Act
+	result, err := service.ListOrders(ctx, input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Empty(t, result.NextCursor)
+	mockRepo.AssertNotCalled(t, "CountOrders", mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_RecentOrders_Limit(t *testing.T) {
+	tests := []struct {
+		name          string
+		limit         int
+		expectedLimit int
+	}{
+		{name: "within range", limit: 25, expectedLimit: 25},
+		{name: "defaulted", limit: 0, expectedLimit: models.DefaultRecentOrdersLimit},
+		{name: "capped", limit: 5000, expectedLimit: models.MaxRecentOrdersLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+
+			expected := []models.Order{{ID: 2}, {ID: 1}}
+			mockRepo.On("RecentOrders", ctx, tt.expectedLimit).Return(expected, nil)
+
+			// Act
+			orders, err := service.RecentOrders(ctx, tt.limit)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, expected, orders)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderService_UpdateItemStatus_CompletesOrder(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+
+	ctx := context.Background()
+	order := models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.StatusProcessing},
+		Items: []models.OrderItem{
+			{ID: 1, Status: models.ItemStatusShipped},
+			{ID: 2, Status: models.ItemStatusShipped},
+		},
+	}
+
+	order.Status = models.StatusCompleted
+
+	mockRepo.On("UpdateOrderItemStatus", ctx, 1, 2, models.ItemStatusShipped, now).Return(nil)
+	mockRepo.On("GetOrderById", ctx, 1).Return(order, nil)
+
+	// Act
+	result, err := service.UpdateItemStatus(ctx, models.UpdateItemStatusInput{OrderID: 1, ItemID: 2, Status: models.ItemStatusShipped})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCompleted, result.Status)
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpdateItemStatus_UnchangedOrderStatus(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	order := models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.StatusPending},
+		Items: []models.OrderItem{{ID: 2, Status: models.ItemStatusBackordered}},
+	}
+
+	mockRepo.On("UpdateOrderItemStatus", ctx, 1, 2, models.ItemStatusBackordered, mock.Anything).Return(nil)
+	mockRepo.On("GetOrderById", ctx, 1).Return(order, nil)
+
+	// Act
+	result, err := service.UpdateItemStatus(ctx, models.UpdateItemStatusInput{OrderID: 1, ItemID: 2, Status: models.ItemStatusBackordered})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPending, result.Status)
+	mockRepo.AssertNotCalled(t, "UpdateOrder", mock.Anything, mock.Anything, mock.Anything)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpdateItemStatus_InvalidStatus(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	_, err := service.UpdateItemStatus(context.Background(), models.UpdateItemStatusInput{OrderID: 1, ItemID: 2, Status: "lost"})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrInvalidItemStatus)
+	mockRepo.AssertNotCalled(t, "UpdateOrderItemStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_AddOrderNote(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+
+	ctx := context.Background()
+	expected := models.OrderNote{OrderID: 4, Author: models.DefaultNoteAuthor, Text: "Leave at the door", CreatedAt: now}
+	mockRepo.On("CreateOrderNote", ctx, expected).Return(models.OrderNote{ID: 3, OrderID: 4, Author: expected.Author, Text: expected.Text, CreatedAt: now}, nil)
+
+	// Act
+	note, err := service.AddOrderNote(ctx, models.CreateNoteInput{OrderID: 4, Author: "  ", Text: "  Leave at the door\n"})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, 3, note.ID)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_AddOrderNote_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    models.CreateNoteInput
+		expected string
+	}{
+		{name: "empty text", input: models.CreateNoteInput{OrderID: 4}, expected: "text is required"},
+		{name: "whitespace text", input: models.CreateNoteInput{OrderID: 4, Text: " \t "}, expected: "text is required"},
+		{name: "text too long", input: models.CreateNoteInput{OrderID: 4, Text: strings.Repeat("é", models.MaxNoteLength+1)}, expected: "text must be at most 2000 characters"},
+		{name: "author too long", input: models.CreateNoteInput{OrderID: 4, Author: strings.Repeat("a", models.MaxNoteAuthorLength+1), Text: "hi"}, expected: "author must be at most 100 characters"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, err := service.AddOrderNote(context.Background(), tt.input)
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidNote)
+			assert.ErrorContains(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "CreateOrderNote", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_AddOrderNote_MaxLengthAccepted(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	mockRepo.On("CreateOrderNote", ctx, mock.Anything).Return(models.OrderNote{ID: 1}, nil)
+
+	// Act
+	_, err := service.AddOrderNote(ctx, models.CreateNoteInput{OrderID: 4, Text: strings.Repeat("é", models.MaxNoteLength)})
+
+	// Assert
+	assert.NoError(t, err)
+}
+
+func TestOrderService_ListOrderNotes(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+
+	notes := []models.OrderNote{{ID: 1, OrderID: 4, Text: "First"}, {ID: 2, OrderID: 4, Text: "Second"}}
+	mockRepo.On("ListOrderNotes", ctx, 4).Return(notes, nil)
+
+	// Act
+	result, err := service.ListOrderNotes(ctx, 4)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, notes, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ShipOrder_Partial(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+
+	ctx := context.Background()
+	shipment := []models.ShipItem{{ItemID: 1, Quantity: 2}}
+	fulfillment := models.Fulfillment{ID: 9, OrderID: 1, Items: shipment, CreatedAt: now}
+	order := models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.StatusPartiallyShipped},
+		Items: []models.OrderItem{
+			{ID: 1, Quantity: 3, ShippedQuantity: 2, Status: models.ItemStatusPending},
+			{ID: 2, Quantity: 1, Status: models.ItemStatusPending},
+		},
+	}
+
+	mockRepo.On("ShipOrderItems", ctx, 1, shipment, now).Return(fulfillment, nil)
+	mockRepo.On("GetOrderById", ctx, 1).Return(order, nil)
+
+	// Act
+	result, err := service.ShipOrder(ctx, models.ShipOrderInput{OrderID: 1, Items: shipment})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusPartiallyShipped, result.Order.Status)
+	assert.Equal(t, fulfillment, result.Fulfillment)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_WritesTimestampsInUTC(t *testing.T) {
+	// Arrange: a host clock in +07:00, which pgx would bind by its wall clock
+	local := time.Date(2025, 6, 1, 19, 0, 0, 0, time.FixedZone("ICT", 7*60*60))
+	inUTC := mock.MatchedBy(func(at time.Time) bool { return at.Location() == time.UTC && at.Equal(local) })
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(local))
+	ctx := context.Background()
+	pending := models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusPending}}
+	shipment := []models.ShipItem{{ItemID: 1, Quantity: 1}}
+
+	mockRepo.On("GetOrderById", ctx, 1).Return(pending, nil)
+	mockRepo.On("UpdateOrder", ctx, mock.MatchedBy(func(order models.Order) bool {
+		return order.UpdatedAt.Location() == time.UTC && order.UpdatedAt.Equal(local)
+	}), mock.Anything).Return(nil)
+	mockRepo.On("CancelOrder", ctx, 1, inUTC).Return(models.StatusPending, nil)
+	mockRepo.On("ShipOrderItems", ctx, 1, shipment, inUTC).Return(models.Fulfillment{}, nil)
+
+	// Act
+	updateErr := service.UpdateOrder(ctx, models.UpdateOrderInput{ID: 1, Status: models.StatusProcessing})
+	_, cancelErr := service.CancelOrder(ctx, 1)
+	_, shipErr := service.ShipOrder(ctx, models.ShipOrderInput{OrderID: 1, Items: shipment})
+
+	// Assert
+	assert.NoError(t, updateErr)
+	assert.NoError(t, cancelErr)
+	assert.NoError(t, shipErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ShipOrder_Full(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+
+	ctx := context.Background()
+	shipment := []models.ShipItem{{ItemID: 1, Quantity: 1}, {ItemID: 2, Quantity: 1}}
+	order := models.OrderWithItems{
+		Order: models.Order{ID: 1, Status: models.StatusCompleted},
+		Items: []models.OrderItem{
+			{ID: 1, Quantity: 3, ShippedQuantity: 3, Status: models.ItemStatusShipped},
+			{ID: 2, Quantity: 1, ShippedQuantity: 1, Status: models.ItemStatusShipped},
+		},
+	}
+
+	mockRepo.On("ShipOrderItems", ctx, 1, shipment, now).Return(models.Fulfillment{ID: 10, OrderID: 1}, nil)
+	mockRepo.On("GetOrderById", ctx, 1).Return(order, nil)
+
+	// Act
+	result, err := service.ShipOrder(ctx, models.ShipOrderInput{OrderID: 1, Items: shipment})
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCompleted, result.Order.Status)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_ShipOrder_InvalidShipment(t *testing.T) {
+	tests := map[string][]models.ShipItem{
+		"empty":         nil,
+		"zero quantity": {{ItemID: 1, Quantity: 0}},
+		"duplicate":     {{ItemID: 1, Quantity: 1}, {ItemID: 1, Quantity: 2}},
+	}
+
+	for name, items := range tests {
+		t.Run(name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, err := service.ShipOrder(context.Background(), models.ShipOrderInput{OrderID: 1, Items: items})
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrInvalidShipment)
+			mockRepo.AssertNotCalled(t, "ShipOrderItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderService_DeleteOrdersByFilter_RefusesEmptyFilter(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	// Act
+	deleted, err := service.DeleteOrdersByFilter(context.Background(), models.DeleteOrdersFilter{})
+
+	// Assert
+	assert.ErrorIs(t, err, domain.ErrEmptyFilter)
+	assert.Equal(t, int64(0), deleted)
+	mockRepo.AssertNotCalled(t, "DeleteOrdersByFilter", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderService_DeleteOrdersByFilter_Success(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+
+	ctx := context.Background()
+	createdBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	filter := models.DeleteOrdersFilter{Status: models.StatusCancelled, CreatedBefore: &createdBefore}
+	mockRepo.On("DeleteOrdersByFilter", ctx, filter, bulkDeleteBatchSize).Return(int64(1200), nil)
+
+	// Act
+	deleted, err := service.DeleteOrdersByFilter(ctx, filter)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1200), deleted)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_GetOrderById_ItemLoadFailure(t *testing.T) {
+	itemsErr := fmt.Errorf("%w: failed to fetch order items: statement timeout", domain.ErrItemsUnavailable)
+	loaded := models.OrderWithItems{Order: models.Order{ID: 7, CustomerName: "Jane", Status: models.StatusPending}}
+
+	t.Run("strict", func(t *testing.T) {
+		// Arrange
+		mockRepo := &MockOrderRepository{}
+		service := NewOrderService(mockRepo)
+		mockRepo.On("GetOrderById", mock.Anything, 7).Return(loaded, itemsErr)
+
+		// Act
+		_, err := service.GetOrderById(context.Background(), 7)
+
+		// Assert
+		assert.ErrorIs(t, err, domain.ErrItemsUnavailable)
+	})
+
+	t.Run("partial", func(t *testing.T) {
+		// Arrange
+		SetStrictItemLoad(false)
+		defer SetStrictItemLoad(true)
+
+		mockRepo := &MockOrderRepository{}
+		service := NewOrderService(mockRepo)
+		mockRepo.On("GetOrderById", mock.Anything, 7).Return(loaded, itemsErr)
+
+		// Act
+		order, err := service.GetOrderById(context.Background(), 7)
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, 7, order.ID)
+		assert.NotNil(t, order.Items)
+		assert.Empty(t, order.Items)
+		assert.Equal(t, []string{itemsUnavailableWarning}, order.Warnings)
+	})
+
+	t.Run("order query failure is never partial", func(t *testing.T) {
+		// Arrange
+		SetStrictItemLoad(false)
+		defer SetStrictItemLoad(true)
+
+		mockRepo := &MockOrderRepository{}
+		service := NewOrderService(mockRepo)
+		mockRepo.On("GetOrderById", mock.Anything, 7).Return(models.OrderWithItems{}, errors.New("connection refused"))
+
+		// Act
+		_, err := service.GetOrderById(context.Background(), 7)
+
+		// Assert
+		assert.EqualError(t, err, "connection refused")
+	})
+}
+
+func TestOrderService_UpsertOrderByNumber(t *testing.T) {
+	// Arrange
+	mockRepo := &MockOrderRepository{}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+	ctx := context.Background()
+
+	input := models.CreateOrderInput{
+		CustomerName: " Jane Doe ",
+		Status:       models.StatusProcessing,
+		OwnerOnly:    true,
+		Items:        []models.OrderItem{{ProductName: "Widget", Quantity: 2, Price: 10}},
+	}
+	upserted := models.OrderWithItems{Order: models.Order{ID: 5, OrderNumber: "ORD-20250601-K7QX2M"}}
+	mockRepo.On("UpsertOrderByNumber", ctx, mock.MatchedBy(func(order models.Order) bool {
+		return order.OrderNumber == "ORD-20250601-K7QX2M" &&
+			order.CustomerName == "Jane Doe" &&
+			order.Status == models.StatusProcessing &&
+			order.TotalAmount == 20 &&
+			order.UpdatedAt.Equal(now)
+	}), mock.AnythingOfType("[]models.OrderItem"), []models.Status{models.StatusPending, models.StatusProcessing}, true).Return(upserted, false, nil)
+
+	// Act
+	result, created, err := service.UpsertOrderByNumber(ctx, "ORD-20250601-K7QX2M", input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.False(t, created)
+	assert.Equal(t, upserted, result)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpsertOrderByNumber_DefaultsToPending(t *testing.T) {
+	// Arrange: without a status a new order is pending and an existing one keeps its status
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+
+	input := models.CreateOrderInput{CustomerName: "Jane", Items: []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 1}}}
+	mockRepo.On("UpsertOrderByNumber", ctx, mock.MatchedBy(func(order models.Order) bool {
+		return order.Status == models.StatusPending
+	}), mock.Anything, []models.Status(nil), false).Return(models.OrderWithItems{}, true, nil)
+
+	// Act
+	_, created, err := service.UpsertOrderByNumber(ctx, "ORD-20250601-K7QX2M", input)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, created)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpsertOrderByNumber_Invalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		orderNumber string
+		input       models.CreateOrderInput
+		expected    string
+	}{
+		{name: "malformed order number", orderNumber: "ORD-1", input: models.CreateOrderInput{CustomerName: "Jane", Items: []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 1}}}, expected: "invalid order number"},
+		{name: "unknown status", orderNumber: "ORD-20250601-K7QX2M", input: models.CreateOrderInput{CustomerName: "Jane", Status: "lost", Items: []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 1}}}, expected: "unknown status"},
+		{name: "missing customer", orderNumber: "ORD-20250601-K7QX2M", input: models.CreateOrderInput{Items: []models.OrderItem{{ProductName: "Widget", Quantity: 1, Price: 1}}}, expected: "customer name is required"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+
+			// Act
+			_, _, err := service.UpsertOrderByNumber(context.Background(), tt.orderNumber, tt.input)
+
+			// Assert
+			assert.ErrorContains(t, err, tt.expected)
+			mockRepo.AssertNotCalled(t, "UpsertOrderByNumber", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+// useCancellationHook installs hook for the duration of the test
+func useCancellationHook(t *testing.T, hook CancellationHook) {
+	previous := cancellationHook
+	SetCancellationHook(hook)
+	t.Cleanup(func() { SetCancellationHook(previous) })
+}
+
+func TestOrderService_CancelOrder_Cancellable(t *testing.T) {
+	for _, status := range []models.Status{models.StatusPending, models.StatusProcessing} {
+		t.Run(string(status), func(t *testing.T) {
+			// Arrange
+			core, logs := observer.New(zapcore.InfoLevel)
+			previous := logger.GetDefault()
+			logger.SetDefault(logger.New(zap.New(core)))
+			t.Cleanup(func() { logger.SetDefault(previous) })
+
+			var hooked []int
+			useCancellationHook(t, func(ctx context.Context, order models.OrderWithItems) error {
+				hooked = append(hooked, order.ID)
+				return errors.New("refund provider down")
+			})
+
+			mockRepo := &MockOrderRepository{}
+			now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			service := NewOrderServiceWithClock(mockRepo, clock.NewMock(now))
+			ctx := context.Background()
+			mockRepo.On("CancelOrder", ctx, 4, now).Return(status, nil)
+			mockRepo.On("GetOrderById", ctx, 4).Return(models.OrderWithItems{Order: models.Order{ID: 4, Status: models.StatusCancelled, UpdatedAt: now}}, nil)
+
+			// Act
+			order, err := service.CancelOrder(ctx, 4)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, models.StatusCancelled, order.Status)
+			assert.Equal(t, []int{4}, hooked)
+			mockRepo.AssertExpectations(t)
+
+			events := logs.FilterMessage("order.cancelled").All()
+			if assert.Len(t, events, 1) {
+				assert.EqualValues(t, status, events[0].ContextMap()["from_status"])
+			}
+		})
+	}
+}
+
+func TestOrderService_CancelOrder_AlreadyCancelled(t *testing.T) {
+	// Arrange
+	hookCalled := false
+	useCancellationHook(t, func(ctx context.Context, order models.OrderWithItems) error {
+		hookCalled = true
+		return nil
+	})
+
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderService(mockRepo)
+	ctx := context.Background()
+	mockRepo.On("CancelOrder", ctx, 4, mock.Anything).Return(models.StatusCancelled, nil)
+	mockRepo.On("GetOrderById", ctx, 4).Return(models.OrderWithItems{Order: models.Order{ID: 4, Status: models.StatusCancelled}}, nil)
+
+	// Act
+	order, err := service.CancelOrder(ctx, 4)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, models.StatusCancelled, order.Status)
+	assert.False(t, hookCalled)
+}
+
+func TestOrderService_CancelOrder_NotCancellable(t *testing.T) {
+	for _, status := range []models.Status{models.StatusCompleted, models.StatusPartiallyShipped} {
+		t.Run(string(status), func(t *testing.T) {
+			// Arrange
+			mockRepo := &MockOrderRepository{}
+			service := NewOrderService(mockRepo)
+			ctx := context.Background()
+			mockRepo.On("CancelOrder", ctx, 4, mock.Anything).Return(models.Status(""), fmt.Errorf("%w: order is %s", domain.ErrOrderNotCancellable, status))
+
+			// Act
+			_, err := service.CancelOrder(ctx, 4)
+
+			// Assert
+			assert.ErrorIs(t, err, domain.ErrOrderNotCancellable)
+			assert.ErrorContains(t, err, "order is "+string(status))
+			mockRepo.AssertNotCalled(t, "GetOrderById", mock.Anything, mock.Anything)
+		})
 	}
 }
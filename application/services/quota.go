@@ -0,0 +1,140 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+)
+
+// customerQuota limits how many orders each customer name may create within a sliding window.
+// Counts are kept in memory, so every instance enforces the quota on its own.
+type customerQuota struct {
+	mu        sync.Mutex
+	max       int
+	window    time.Duration
+	created   map[string][]time.Time
+	nextSweep time.Time
+}
+
+func newCustomerQuota(max int, window time.Duration) *customerQuota {
+	return &customerQuota{
+		max:     max,
+		window:  window,
+		created: make(map[string][]time.Time),
+	}
+}
+
+// quota is the configured per-customer quota; nil disables it
+var (
+	quotaMu sync.RWMutex
+	quota   *customerQuota
+)
+
+// SetCustomerQuota limits each customer to max orders created per window. A max or window of 0
+// or less disables the quota. Reconfiguring it discards the counts recorded so far.
+func SetCustomerQuota(max int, window time.Duration) {
+	quotaMu.Lock()
+	defer quotaMu.Unlock()
+
+	if max <= 0 || window <= 0 {
+		quota = nil
+		return
+	}
+	quota = newCustomerQuota(max, window)
+}
+
+func currentCustomerQuota() *customerQuota {
+	quotaMu.RLock()
+	defer quotaMu.RUnlock()
+	return quota
+}
+
+// reserveOrderQuota counts order against its customer's quota and returns the func that gives the
+// reservation back when creating the order fails. It is called after validation, so rejected input
+// does not use up the quota, and fails with ErrQuotaExceeded when the customer has none left.
+func reserveOrderQuota(serviceLogger *logger.Logger, order models.Order) (release func(), err error) {
+	quota := currentCustomerQuota()
+	if quota == nil {
+		return func() {}, nil
+	}
+	if !quota.reserve(order.CustomerName, order.CreatedAt) {
+		serviceLogger.Warn("Customer order quota exceeded", "customer", order.CustomerName, "max_orders", quota.max, "window", quota.window.String())
+		return nil, fmt.Errorf("%w: at most %d orders per %s", domain.ErrQuotaExceeded, quota.max, quota.window)
+	}
+	return func() { quota.release(order.CustomerName, order.CreatedAt) }, nil
+}
+
+// quotaKey matches customer names case-insensitively, so changing case does not reset the count
+func quotaKey(customerName string) string {
+	return strings.ToLower(customerName)
+}
+
+// reserve records an order for the customer at now and reports false, recording nothing, when
+// the customer already created max orders within the window
+func (q *customerQuota) reserve(customerName string, now time.Time) bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	q.sweep(now)
+
+	key := quotaKey(customerName)
+	recent := q.recent(key, now)
+	if len(recent) >= q.max {
+		q.created[key] = recent
+		return false
+	}
+	q.created[key] = append(recent, now)
+	return true
+}
+
+// release removes an order recorded by reserve at the given time, used when creating it failed
+func (q *customerQuota) release(customerName string, at time.Time) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	key := quotaKey(customerName)
+	created := q.created[key]
+	for i := len(created) - 1; i >= 0; i-- {
+		if created[i].Equal(at) {
+			created = append(created[:i], created[i+1:]...)
+			break
+		}
+	}
+	if len(created) == 0 {
+		delete(q.created, key)
+		return
+	}
+	q.created[key] = created
+}
+
+// recent returns the customer's creation times that are still inside the window
+func (q *customerQuota) recent(key string, now time.Time) []time.Time {
+	created := q.created[key]
+	cutoff := now.Add(-q.window)
+	i := 0
+	for i < len(created) && !created[i].After(cutoff) {
+		i++
+	}
+	return created[i:]
+}
+
+// sweep drops customers without orders in the window, at most once per window, so names that
+// stop ordering do not keep the map growing
+func (q *customerQuota) sweep(now time.Time) {
+	if now.Before(q.nextSweep) {
+		return
+	}
+	for key := range q.created {
+		if recent := q.recent(key, now); len(recent) == 0 {
+			delete(q.created, key)
+		} else {
+			q.created[key] = recent
+		}
+	}
+	q.nextSweep = now.Add(q.window)
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// useCustomerQuota enables the quota for the duration of the test
+func useCustomerQuota(t *testing.T, max int, window time.Duration) {
+	SetCustomerQuota(max, window)
+	t.Cleanup(func() { SetCustomerQuota(0, 0) })
+}
+
+func quotaInput(customerName string) models.CreateOrderInput {
+	return models.CreateOrderInput{
+		CustomerName: customerName,
+		Items:        []models.OrderItem{{ProductName: "Product 1", Quantity: 1, Price: 10}},
+	}
+}
+
+func TestOrderService_CreateOrder_UnderQuota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 2, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, nil)
+
+	// Act
+	_, firstErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+	_, secondErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+	_, otherErr := service.CreateOrder(ctx, quotaInput("Jane Roe"))
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.NoError(t, secondErr)
+	assert.NoError(t, otherErr)
+	mockRepo.AssertNumberOfCalls(t, "CreateOrder", 3)
+}
+
+func TestOrderService_CreateOrder_OverQuota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 2, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	fixedClock := clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	service := NewOrderServiceWithClock(mockRepo, fixedClock)
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, nil)
+
+	_, _ = service.CreateOrder(ctx, quotaInput("John Doe"))
+	fixedClock.Advance(30 * time.Minute)
+	_, _ = service.CreateOrder(ctx, quotaInput("John Doe"))
+
+	// Act: names are matched after trimming and regardless of case
+	_, overErr := service.CreateOrder(ctx, quotaInput("  JOHN DOE "))
+	fixedClock.Advance(31 * time.Minute)
+	_, afterWindowErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+
+	// Assert
+	assert.ErrorIs(t, overErr, domain.ErrQuotaExceeded)
+	assert.NoError(t, afterWindowErr)
+	mockRepo.AssertNumberOfCalls(t, "CreateOrder", 3)
+}
+
+func TestOrderService_CreateOrder_FailedCreateDoesNotCountTowardsQuota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 1, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, errors.New("connection reset")).Once()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, nil).Once()
+
+	// Act
+	_, failedErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+	_, retryErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+
+	// Assert
+	assert.Error(t, failedErr)
+	assert.NoError(t, retryErr)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_CreateOrders_OverQuota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 1, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, nil)
+
+	// Act
+	result, err := service.CreateOrders(ctx, []models.CreateOrderInput{quotaInput("John Doe"), quotaInput("John Doe")}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, result.Created, 1)
+	if assert.Len(t, result.Errors, 1) {
+		assert.Equal(t, 1, result.Errors[0].Index)
+		assert.Contains(t, result.Errors[0].Message, domain.ErrQuotaExceeded.Error())
+	}
+	mockRepo.AssertNumberOfCalls(t, "CreateOrder", 1)
+}
+
+func TestOrderService_CreateOrders_AtomicOverQuotaReleasesReservations(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 1, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("CreateOrder", ctx, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, nil)
+
+	// Act
+	_, batchErr := service.CreateOrders(ctx, []models.CreateOrderInput{quotaInput("John Doe"), quotaInput("John Doe")}, true)
+	_, retryErr := service.CreateOrder(ctx, quotaInput("John Doe"))
+
+	// Assert
+	assert.ErrorIs(t, batchErr, domain.ErrBatchInvalid)
+	assert.NoError(t, retryErr)
+	mockRepo.AssertNotCalled(t, "CreateOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderService_BulkCreateOrders_OverQuota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 1, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("BulkCreateOrders", ctx, mock.MatchedBy(func(orders []models.OrderWithItems) bool { return len(orders) == 1 })).
+		Return([]models.OrderWithItems{{}}, nil)
+
+	// Act
+	results, err := service.BulkCreateOrders(ctx, []models.CreateOrderInput{quotaInput("John Doe"), quotaInput("John Doe")}, false)
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, results, 2) {
+		assert.NotNil(t, results[0].Order)
+		assert.Contains(t, results[1].Error, domain.ErrQuotaExceeded.Error())
+	}
+	mockRepo.AssertExpectations(t)
+}
+
+func TestOrderService_UpsertOrderByNumber_Quota(t *testing.T) {
+	// Arrange
+	useCustomerQuota(t, 1, time.Hour)
+	mockRepo := &MockOrderRepository{}
+	service := NewOrderServiceWithClock(mockRepo, clock.NewMock(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)))
+	ctx := context.Background()
+	mockRepo.On("UpsertOrderByNumber", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, false, nil).Once()
+	mockRepo.On("UpsertOrderByNumber", ctx, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, true, nil).Once()
+
+	// Act: replacing an existing order gives the reservation back, creating one keeps it
+	_, _, updateErr := service.UpsertOrderByNumber(ctx, "ORD-20250601-ABCDEF", quotaInput("John Doe"))
+	_, _, createErr := service.UpsertOrderByNumber(ctx, "ORD-20250601-ABCDEG", quotaInput("John Doe"))
+	_, _, overErr := service.UpsertOrderByNumber(ctx, "ORD-20250601-ABCDEH", quotaInput("John Doe"))
+
+	// Assert
+	assert.NoError(t, updateErr)
+	assert.NoError(t, createErr)
+	assert.ErrorIs(t, overErr, domain.ErrQuotaExceeded)
+	mockRepo.AssertNumberOfCalls(t, "UpsertOrderByNumber", 2)
+}
+
+func TestCustomerQuota_SweepDropsIdleCustomers(t *testing.T) {
+	// Arrange
+	q := newCustomerQuota(1, time.Minute)
+	start := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.True(t, q.reserve("John Doe", start))
+
+	// Act
+	assert.True(t, q.reserve("Jane Roe", start.Add(2*time.Minute)))
+
+	// Assert
+	assert.NotContains(t, q.created, "john doe")
+	assert.Contains(t, q.created, "jane roe")
+}
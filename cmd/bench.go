@@ -0,0 +1,384 @@
+package cmd
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/spf13/cobra"
+)
+
+var ClientBenchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Benchmark the CreateOrder endpoint of a running server and write a report",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := runBenchCommand(benchURLFlag, benchDurationFlag, benchConcurrencyFlag, benchOutputFlag, benchBaselineFlag); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Benchmark failed: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+var (
+	benchURLFlag         string
+	benchDurationFlag    time.Duration
+	benchConcurrencyFlag int
+	benchOutputFlag      string
+	benchBaselineFlag    string
+
+	// benchOrderPool is how many generated orders the workers cycle through
+	benchOrderPool = 1000
+)
+
+func init() {
+	ClientBenchCmd.Flags().StringVar(&benchURLFlag, "url", "http://localhost:3333/api/v1/orders", "Target API endpoint")
+	ClientBenchCmd.Flags().DurationVar(&benchDurationFlag, "duration", 30*time.Second, "How long to send requests")
+	ClientBenchCmd.Flags().IntVar(&benchConcurrencyFlag, "concurrency", 10, "Number of concurrent workers")
+	ClientBenchCmd.Flags().StringVar(&benchOutputFlag, "output", "bench-report.json", "Report file; a .csv extension writes CSV, anything else JSON")
+	ClientBenchCmd.Flags().StringVar(&benchBaselineFlag, "baseline", "", "JSON report of a previous run to compare against")
+	rootCmd.AddCommand(ClientBenchCmd)
+}
+
+// LatencySummary holds request latency percentiles in milliseconds
+type LatencySummary struct {
+	Mean float64 `json:"mean"`
+	P50  float64 `json:"p50"`
+	P90  float64 `json:"p90"`
+	P95  float64 `json:"p95"`
+	P99  float64 `json:"p99"`
+	Max  float64 `json:"max"`
+}
+
+// BenchDelta compares one metric of a run against the baseline
+type BenchDelta struct {
+	Metric        string  `json:"metric"`
+	Baseline      float64 `json:"baseline"`
+	Current       float64 `json:"current"`
+	ChangePercent float64 `json:"change_percent"`
+}
+
+// BenchReport is the result of a benchmark run. Errors counts failed requests by HTTP status,
+// or "transport" when no response was received.
+type BenchReport struct {
+	URL             string         `json:"url"`
+	StartedAt       time.Time      `json:"started_at"`
+	DurationSeconds float64        `json:"duration_seconds"`
+	Concurrency     int            `json:"concurrency"`
+	Requests        int            `json:"requests"`
+	Succeeded       int            `json:"succeeded"`
+	Failed          int            `json:"failed"`
+	Throughput      float64        `json:"throughput_rps"`
+	LatencyMs       LatencySummary `json:"latency_ms"`
+	Errors          map[string]int `json:"errors"`
+	Comparison      []BenchDelta   `json:"comparison,omitempty"`
+}
+
+// benchSample is the outcome of one request; errorKind is empty on success
+type benchSample struct {
+	latency   time.Duration
+	errorKind string
+}
+
+// validateBenchOptions rejects values that would make the run hang or measure nothing
+func validateBenchOptions(duration time.Duration, concurrency int) error {
+	var problems []string
+	if duration <= 0 {
+		problems = append(problems, fmt.Sprintf("--duration must be positive, got %s", duration))
+	}
+	if concurrency <= 0 {
+		problems = append(problems, fmt.Sprintf("--concurrency must be positive, got %d", concurrency))
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func runBenchCommand(apiURL string, duration time.Duration, concurrency int, output, baselinePath string) error {
+	if err := validateBenchOptions(duration, concurrency); err != nil {
+		return err
+	}
+
+	// Load the baseline first so a bad path fails before the run instead of after it
+	var baseline *BenchReport
+	if baselinePath != "" {
+		loaded, err := loadBenchReport(baselinePath)
+		if err != nil {
+			return err
+		}
+		baseline = &loaded
+	}
+
+	report := RunBench(apiURL, duration, concurrency)
+	if baseline != nil {
+		report.Comparison = compareBenchReports(report, *baseline)
+	}
+
+	logBenchReport(report)
+	if err := writeBenchReport(output, report); err != nil {
+		return err
+	}
+	logger.Infof("Report written to %s", output)
+	return nil
+}
+
+// RunBench sends orders from concurrency workers until duration elapses and summarizes the results.
+// Requests are not retried, so 429s are reported as errors; requests cut off by the end of the
+// run are not counted.
+func RunBench(apiURL string, duration time.Duration, concurrency int) BenchReport {
+	logger.Infof("Benchmarking %s for %s with %d workers...", apiURL, duration, concurrency)
+
+	orders := generateDummyOrders(benchOrderPool, true)
+	ctx, cancel := context.WithTimeout(context.Background(), duration)
+	defer cancel()
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		samples []benchSample
+		next    int
+	)
+
+	startedAt := time.Now()
+	for w := 0; w < concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var local []benchSample
+			for ctx.Err() == nil {
+				mu.Lock()
+				order := orders[next%len(orders)]
+				next++
+				mu.Unlock()
+
+				start := time.Now()
+				err := sendBulkOrderRequest(ctx, order, apiURL)
+				latency := time.Since(start)
+				if err != nil && ctx.Err() != nil {
+					break
+				}
+				local = append(local, benchSample{latency: latency, errorKind: benchErrorKind(err)})
+			}
+
+			mu.Lock()
+			samples = append(samples, local...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	report := buildBenchReport(samples, time.Since(startedAt))
+	report.URL = apiURL
+	report.StartedAt = startedAt.UTC()
+	report.Concurrency = concurrency
+	return report
+}
+
+// benchErrorKind groups a request error for the report's error breakdown
+func benchErrorKind(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr *errAPIStatus
+	var throttled *errThrottled
+	switch {
+	case errors.As(err, &apiErr):
+		return strconv.Itoa(apiErr.statusCode)
+	case errors.As(err, &throttled):
+		return "429"
+	default:
+		return "transport"
+	}
+}
+
+// buildBenchReport computes throughput, latency percentiles and the error breakdown of a run
+func buildBenchReport(samples []benchSample, elapsed time.Duration) BenchReport {
+	report := BenchReport{
+		DurationSeconds: elapsed.Seconds(),
+		Requests:        len(samples),
+		Errors:          map[string]int{},
+	}
+	if len(samples) == 0 {
+		return report
+	}
+
+	latencies := make([]time.Duration, len(samples))
+	var total time.Duration
+	for i, sample := range samples {
+		latencies[i] = sample.latency
+		total += sample.latency
+		if sample.errorKind == "" {
+			report.Succeeded++
+		} else {
+			report.Failed++
+			report.Errors[sample.errorKind]++
+		}
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+	if elapsed > 0 {
+		report.Throughput = float64(report.Requests) / elapsed.Seconds()
+	}
+	report.LatencyMs = LatencySummary{
+		Mean: milliseconds(total / time.Duration(len(latencies))),
+		P50:  milliseconds(percentile(latencies, 50)),
+		P90:  milliseconds(percentile(latencies, 90)),
+		P95:  milliseconds(percentile(latencies, 95)),
+		P99:  milliseconds(percentile(latencies, 99)),
+		Max:  milliseconds(latencies[len(latencies)-1]),
+	}
+	return report
+}
+
+// percentile returns the nearest-rank percentile p of sorted, which must not be empty
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func milliseconds(d time.Duration) float64 {
+	return float64(d) / float64(time.Millisecond)
+}
+
+// benchMetrics lists the compared and CSV-exported metrics in a fixed order
+func benchMetrics(report BenchReport) []BenchDelta {
+	return []BenchDelta{
+		{Metric: "requests", Current: float64(report.Requests)},
+		{Metric: "succeeded", Current: float64(report.Succeeded)},
+		{Metric: "failed", Current: float64(report.Failed)},
+		{Metric: "throughput_rps", Current: report.Throughput},
+		{Metric: "latency_mean_ms", Current: report.LatencyMs.Mean},
+		{Metric: "latency_p50_ms", Current: report.LatencyMs.P50},
+		{Metric: "latency_p90_ms", Current: report.LatencyMs.P90},
+		{Metric: "latency_p95_ms", Current: report.LatencyMs.P95},
+		{Metric: "latency_p99_ms", Current: report.LatencyMs.P99},
+		{Metric: "latency_max_ms", Current: report.LatencyMs.Max},
+	}
+}
+
+// compareBenchReports returns each metric of current next to baseline; ChangePercent is 0 when
+// the baseline value is 0
+func compareBenchReports(current, baseline BenchReport) []BenchDelta {
+	deltas := benchMetrics(current)
+	for i, base := range benchMetrics(baseline) {
+		deltas[i].Baseline = base.Current
+		if base.Current != 0 {
+			deltas[i].ChangePercent = (deltas[i].Current - base.Current) / base.Current * 100
+		}
+	}
+	return deltas
+}
+
+func logBenchReport(report BenchReport) {
+	logger.Infof("\n--- Benchmark Summary ---")
+	logger.Infof("Requests: %d (%d succeeded, %d failed)", report.Requests, report.Succeeded, report.Failed)
+	logger.Infof("Throughput: %.2f req/s", report.Throughput)
+	logger.Infof("Latency (ms): mean=%.2f p50=%.2f p90=%.2f p95=%.2f p99=%.2f max=%.2f",
+		report.LatencyMs.Mean, report.LatencyMs.P50, report.LatencyMs.P90, report.LatencyMs.P95, report.LatencyMs.P99, report.LatencyMs.Max)
+
+	kinds := make([]string, 0, len(report.Errors))
+	for kind := range report.Errors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		logger.Infof("Errors %s: %d", kind, report.Errors[kind])
+	}
+
+	for _, delta := range report.Comparison {
+		logger.Infof("%s: %.2f -> %.2f (%+.1f%%)", delta.Metric, delta.Baseline, delta.Current, delta.ChangePercent)
+	}
+}
+
+// writeBenchReport writes report as CSV when path ends in .csv and as JSON otherwise
+func writeBenchReport(path string, report BenchReport) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report: %w", err)
+	}
+	defer file.Close()
+
+	if strings.EqualFold(filepath.Ext(path), ".csv") {
+		err = writeBenchCSV(file, report)
+	} else {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		err = encoder.Encode(report)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+	return file.Close()
+}
+
+// writeBenchCSV writes one metric per row; error counts appear as errors_<kind> and, with a
+// baseline, the baseline value and change are added as columns
+func writeBenchCSV(file *os.File, report BenchReport) error {
+	writer := csv.NewWriter(file)
+	format := func(v float64) string { return strconv.FormatFloat(v, 'f', -1, 64) }
+
+	rows := benchMetrics(report)
+	if len(report.Comparison) > 0 {
+		rows = report.Comparison
+	}
+
+	header := []string{"metric", "value"}
+	if len(report.Comparison) > 0 {
+		header = append(header, "baseline", "change_percent")
+	}
+	if err := writer.Write(header); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		record := []string{row.Metric, format(row.Current)}
+		if len(report.Comparison) > 0 {
+			record = append(record, format(row.Baseline), format(row.ChangePercent))
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	kinds := make([]string, 0, len(report.Errors))
+	for kind := range report.Errors {
+		kinds = append(kinds, kind)
+	}
+	sort.Strings(kinds)
+	for _, kind := range kinds {
+		if err := writer.Write([]string{"errors_" + kind, strconv.Itoa(report.Errors[kind])}); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// loadBenchReport reads a JSON report written by a previous run
+func loadBenchReport(path string) (BenchReport, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return BenchReport{}, fmt.Errorf("failed to read baseline: %w", err)
+	}
+
+	var report BenchReport
+	if err := json.Unmarshal(data, &report); err != nil {
+		return BenchReport{}, fmt.Errorf("baseline %s is not a JSON benchmark report: %w", path, err)
+	}
+	return report, nil
+}
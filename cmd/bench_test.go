@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuildBenchReport(t *testing.T) {
+	// Arrange: latencies of 1..100ms, every tenth request failing
+	samples := make([]benchSample, 100)
+	for i := range samples {
+		samples[i] = benchSample{latency: time.Duration(i+1) * time.Millisecond}
+		if i%10 == 0 {
+			samples[i].errorKind = "500"
+		}
+	}
+	samples[1].errorKind = "transport"
+
+	// Act
+	report := buildBenchReport(samples, 2*time.Second)
+
+	// Assert
+	assert.Equal(t, 100, report.Requests)
+	assert.Equal(t, 89, report.Succeeded)
+	assert.Equal(t, 11, report.Failed)
+	assert.Equal(t, map[string]int{"500": 10, "transport": 1}, report.Errors)
+	assert.Equal(t, 50.0, report.Throughput)
+	assert.Equal(t, LatencySummary{Mean: 50.5, P50: 50, P90: 90, P95: 95, P99: 99, Max: 100}, report.LatencyMs)
+}
+
+func TestBuildBenchReport_NoSamples(t *testing.T) {
+	report := buildBenchReport(nil, time.Second)
+
+	assert.Equal(t, 0, report.Requests)
+	assert.Equal(t, LatencySummary{}, report.LatencyMs)
+	assert.Empty(t, report.Errors)
+}
+
+func TestCompareBenchReports(t *testing.T) {
+	baseline := BenchReport{Requests: 200, Throughput: 100, LatencyMs: LatencySummary{P99: 20}}
+	current := BenchReport{Requests: 300, Throughput: 150, LatencyMs: LatencySummary{P99: 10}}
+
+	deltas := compareBenchReports(current, baseline)
+
+	byMetric := make(map[string]BenchDelta, len(deltas))
+	for _, delta := range deltas {
+		byMetric[delta.Metric] = delta
+	}
+	assert.Equal(t, BenchDelta{Metric: "throughput_rps", Baseline: 100, Current: 150, ChangePercent: 50}, byMetric["throughput_rps"])
+	assert.Equal(t, BenchDelta{Metric: "latency_p99_ms", Baseline: 20, Current: 10, ChangePercent: -50}, byMetric["latency_p99_ms"])
+	assert.Equal(t, 0.0, byMetric["failed"].ChangePercent)
+}
+
+func TestWriteBenchReport_JSONRoundTrip(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "report.json")
+	report := BenchReport{URL: "http://example", Requests: 3, Succeeded: 2, Failed: 1, Errors: map[string]int{"429": 1}, LatencyMs: LatencySummary{P50: 1.5}}
+
+	// Act
+	assert.NoError(t, writeBenchReport(path, report))
+	loaded, err := loadBenchReport(path)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, report, loaded)
+}
+
+func TestWriteBenchReport_CSV(t *testing.T) {
+	// Arrange
+	path := filepath.Join(t.TempDir(), "report.csv")
+	report := BenchReport{Requests: 3, Failed: 1, Errors: map[string]int{"503": 1}}
+	report.Comparison = compareBenchReports(report, BenchReport{Requests: 2})
+
+	// Act
+	err := writeBenchReport(path, report)
+
+	// Assert
+	assert.NoError(t, err)
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Equal(t, "metric,value,baseline,change_percent", lines[0])
+	assert.Equal(t, "requests,3,2,50", lines[1])
+	assert.Equal(t, "errors_503,1", lines[len(lines)-1])
+}
+
+func TestLoadBenchReport_RejectsNonJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "report.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("metric,value\n"), 0o644))
+
+	_, err := loadBenchReport(path)
+
+	assert.ErrorContains(t, err, "is not a JSON benchmark report")
+}
+
+func TestValidateBenchOptions(t *testing.T) {
+	assert.NoError(t, validateBenchOptions(time.Second, 1))
+	assert.EqualError(t, validateBenchOptions(0, 0), "--duration must be positive, got 0s; --concurrency must be positive, got 0")
+}
+
+func TestRunBench_AgainstServer(t *testing.T) {
+	// Arrange: every third request fails
+	var count atomic.Int64
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if count.Add(1)%3 == 0 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	// Act
+	report := RunBench(server.URL, 200*time.Millisecond, 2)
+
+	// Assert
+	assert.Positive(t, report.Requests)
+	assert.Equal(t, report.Requests, report.Succeeded+report.Failed)
+	assert.Equal(t, report.Failed, report.Errors["503"])
+	assert.Positive(t, report.Throughput)
+	assert.Equal(t, 2, report.Concurrency)
+	assert.Equal(t, server.URL, report.URL)
+}
+
+func TestRunBenchCommand_MissingBaselineFailsBeforeRun(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "report.json")
+
+	err := runBenchCommand("http://127.0.0.1:0", time.Second, 1, output, filepath.Join(t.TempDir(), "missing.json"))
+
+	assert.ErrorContains(t, err, "failed to read baseline")
+	assert.NoFileExists(t, output)
+}
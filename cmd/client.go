@@ -4,15 +4,20 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math/rand"
 	"net/http"
+	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/Testzyler/order-management-go/application/models"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	faker "github.com/bxcodec/faker/v4"
+	"github.com/google/uuid"
 	"github.com/spf13/cobra"
 )
 
@@ -20,32 +25,103 @@ var ClientStressTestCmd = &cobra.Command{
 	Use:   "stress-test",
 	Short: "Start Stress Test for Online Order Management System API",
 	Run: func(cmd *cobra.Command, args []string) {
-		RunStressTest(numOrdersFlag, batchSizeFlag, concurrencyFlag, apiURLFlag)
+		if err := RunStressTest(numOrdersFlag, batchSizeFlag, concurrencyFlag, apiURLFlag); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Stress test failed: %v\n", err)
+			os.Exit(1)
+		}
 	},
 }
 var (
-	numOrdersFlag   int
-	batchSizeFlag   int
-	concurrencyFlag int
-	apiURLFlag      string
-	totalTimeout    = 5 * time.Minute // Total timeout for the stress test
+	numOrdersFlag     int
+	batchSizeFlag     int
+	concurrencyFlag   int
+	apiURLFlag        string
+	maxRetriesFlag    int
+	uniqueNamesFlag   bool
+	failureSampleFlag int
+	totalTimeout      = 5 * time.Minute // Total timeout for the stress test
+
+	// apiClient is shared by every request so connections are reused instead of dialed per order
+	apiClient = &http.Client{
+		Transport: &http.Transport{
+			MaxConnsPerHost:     500,
+			MaxIdleConns:        500,
+			MaxIdleConnsPerHost: 500,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		Timeout: 10 * time.Second,
+	}
+
+	// defaultRetryAfter is used when a 429 response has no usable Retry-After header
+	defaultRetryAfter = 1 * time.Second
 )
 
+// requestResult is the outcome of sending one order, including time spent waiting on 429s
+type requestResult struct {
+	index         int
+	err           error
+	throttled     int
+	throttleDelay time.Duration
+}
+
+// errAPIStatus is returned by sendBulkOrderRequest for non-2xx responses other than 429
+type errAPIStatus struct {
+	statusCode int
+	requestID  string
+	body       string
+}
+
+func (e *errAPIStatus) Error() string {
+	return fmt.Sprintf("API returned non-2xx status: %d - %s", e.statusCode, e.body)
+}
+
 func init() {
 	ClientStressTestCmd.Flags().IntVar(&numOrdersFlag, "num", 1000, "Total number of orders to create")
 	ClientStressTestCmd.Flags().IntVar(&batchSizeFlag, "batch", 100, "Number of orders per request batch")
 	ClientStressTestCmd.Flags().IntVar(&concurrencyFlag, "concurrency", 10, "Number of concurrent requests")
 	ClientStressTestCmd.Flags().StringVar(&apiURLFlag, "url", "http://localhost:3333/api/v1/orders", "Target API endpoint")
+	ClientStressTestCmd.Flags().IntVar(&maxRetriesFlag, "max-retries", 3, "Maximum retries per order after a 429 response")
+	ClientStressTestCmd.Flags().BoolVar(&uniqueNamesFlag, "unique-names", false, "Append a unique suffix to generated customer names")
+	ClientStressTestCmd.Flags().IntVar(&failureSampleFlag, "failure-samples", 10, "Maximum number of failed requests to list in the summary")
 	rootCmd.AddCommand(ClientStressTestCmd)
 }
 
-func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) {
+// validateStressTestOptions rejects values that would hang the run (a zero-size semaphore blocks
+// forever) or make it meaningless
+func validateStressTestOptions(numOrders, batchSize, concurrency, maxRetries, failureSamples int) error {
+	var problems []string
+	if numOrders <= 0 {
+		problems = append(problems, fmt.Sprintf("--num must be positive, got %d", numOrders))
+	}
+	if batchSize <= 0 {
+		problems = append(problems, fmt.Sprintf("--batch must be positive, got %d", batchSize))
+	}
+	if concurrency <= 0 {
+		problems = append(problems, fmt.Sprintf("--concurrency must be positive, got %d", concurrency))
+	}
+	if maxRetries < 0 {
+		problems = append(problems, fmt.Sprintf("--max-retries must not be negative, got %d", maxRetries))
+	}
+	if failureSamples < 0 {
+		problems = append(problems, fmt.Sprintf("--failure-samples must not be negative, got %d", failureSamples))
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) error {
+	if err := validateStressTestOptions(numOrders, batchSize, concurrency, maxRetriesFlag, failureSampleFlag); err != nil {
+		return err
+	}
+
 	logger.Info("Starting stress test for Online Order Management System API...")
 
 	ctx, cancel := context.WithTimeout(context.Background(), totalTimeout)
 	defer cancel()
 
-	ordersToCreate := generateDummyOrders(numOrders)
+	ordersToCreate := generateDummyOrders(numOrders, uniqueNamesFlag)
 	logger.Infof("Generated %d dummy orders.", len(ordersToCreate))
 
 	var orderBatches [][]models.CreateOrderInput
@@ -59,7 +135,7 @@ func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) {
 	logger.Infof("Divided orders into %d batches.", len(orderBatches))
 
 	var wg sync.WaitGroup
-	results := make(chan error, numOrders)
+	results := make(chan requestResult, numOrders)
 	sem := make(chan struct{}, concurrency)
 
 	startTime := time.Now()
@@ -77,14 +153,14 @@ func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) {
 			reqCtx, cancel := context.WithTimeout(ctx, totalTimeout)
 			defer cancel()
 
-			err := sendBulkOrderRequest(reqCtx, order, apiURL)
-			if err != nil {
-				logger.Errorf("Error sending order %d: %v", index+1, err)
-				results <- err
+			result := sendOrderWithRetry(reqCtx, order, apiURL, maxRetriesFlag)
+			result.index = index
+			if result.err != nil {
+				logger.Errorf("Error sending order %d: %v", index+1, result.err)
 			} else {
 				logger.Infof("Successfully sent order %d.", index+1)
-				results <- nil
 			}
+			results <- result
 		}(i, order)
 	}
 
@@ -93,13 +169,22 @@ func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) {
 		close(results)
 	}()
 
-	successCount, errorCount := 0, 0
-	for err := range results {
-		if err != nil {
+	successCount, errorCount, throttledCount := 0, 0, 0
+	var (
+		throttleDelay time.Duration
+		failures      []requestResult
+	)
+	for result := range results {
+		if result.err != nil {
 			errorCount++
+			if len(failures) < failureSampleFlag {
+				failures = append(failures, result)
+			}
 		} else {
 			successCount++
 		}
+		throttledCount += result.throttled
+		throttleDelay += result.throttleDelay
 	}
 
 	duration := time.Since(startTime)
@@ -108,12 +193,55 @@ func RunStressTest(numOrders, batchSize, concurrency int, apiURL string) {
 	logger.Infof("Total Orders Sent: %d", numOrders)
 	logger.Infof("Successful Orders: %d", successCount)
 	logger.Infof("Failed Orders: %d", errorCount)
+	logger.Infof("Throttled Responses (429): %d", throttledCount)
+	logger.Infof("Total Retry-After Delay: %s", throttleDelay)
 	logger.Infof("Total Duration: %s", duration)
+
+	if len(failures) > 0 {
+		logger.Infof("Sample of Failed Orders (%d of %d):", len(failures), errorCount)
+		for _, failure := range failures {
+			logger.Infof("  %s", describeFailure(failure))
+		}
+	}
+	return nil
+}
+
+// describeFailure formats a failed request with the details needed to find it in the server logs
+func describeFailure(result requestResult) string {
+	status, requestID := "-", "-"
+
+	var apiErr *errAPIStatus
+	var throttled *errThrottled
+	switch {
+	case errors.As(result.err, &apiErr):
+		status = strconv.Itoa(apiErr.statusCode)
+		if apiErr.requestID != "" {
+			requestID = apiErr.requestID
+		}
+	case errors.As(result.err, &throttled):
+		status = strconv.Itoa(http.StatusTooManyRequests)
+	}
+
+	return fmt.Sprintf("order=%d status=%s request_id=%s error=%v", result.index+1, status, requestID, result.err)
 }
 
-func generateDummyOrders(count int) []models.CreateOrderInput {
+// requestIDFromResponse returns the request_id from an error response body, falling back to the X-Request-ID header
+func requestIDFromResponse(body []byte, header http.Header) string {
+	var payload struct {
+		RequestID string `json:"request_id"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil && payload.RequestID != "" {
+		return payload.RequestID
+	}
+	return header.Get("X-Request-ID")
+}
+
+// generateDummyOrders builds count random orders. faker.Name() repeats, so with uniqueNames
+// every customer name gets a "-<run id>-<index>" suffix that is unique within and across runs.
+func generateDummyOrders(count int, uniqueNames bool) []models.CreateOrderInput {
 	orders := make([]models.CreateOrderInput, count)
 	productNames := []string{"Widget", "Gadget", "Thingamajig", "Doodad", "Gizmo", "Contraption"}
+	runID := uuid.NewString()[:8]
 
 	for i := 0; i < count; i++ {
 		items := make([]models.OrderItem, rand.Intn(3)+1) // 1-3 items per order
@@ -125,8 +253,13 @@ func generateDummyOrders(count int) []models.CreateOrderInput {
 			}
 		}
 
+		customerName := faker.Name()
+		if uniqueNames {
+			customerName = fmt.Sprintf("%s-%s-%d", customerName, runID, i+1)
+		}
+
 		orders[i] = models.CreateOrderInput{
-			CustomerName: faker.Name(),
+			CustomerName: customerName,
 			Items:        items,
 		}
 	}
@@ -134,6 +267,67 @@ func generateDummyOrders(count int) []models.CreateOrderInput {
 	return orders
 }
 
+// errThrottled is returned by sendBulkOrderRequest when the API responds with 429
+type errThrottled struct {
+	retryAfter time.Duration
+}
+
+func (e *errThrottled) Error() string {
+	return fmt.Sprintf("API returned 429, retry after %s", e.retryAfter)
+}
+
+// sendOrderWithRetry sends the order, sleeping for Retry-After and retrying on 429 up to maxRetries times
+func sendOrderWithRetry(ctx context.Context, order models.CreateOrderInput, apiURL string, maxRetries int) requestResult {
+	var result requestResult
+	for attempt := 0; ; attempt++ {
+		err := sendBulkOrderRequest(ctx, order, apiURL)
+
+		var throttled *errThrottled
+		if !errors.As(err, &throttled) {
+			result.err = err
+			return result
+		}
+
+		result.throttled++
+		if attempt >= maxRetries {
+			result.err = fmt.Errorf("giving up after %d retries: %w", attempt, err)
+			return result
+		}
+
+		select {
+		case <-ctx.Done():
+			result.err = fmt.Errorf("request cancelled while waiting to retry: %w", ctx.Err())
+			return result
+		case <-time.After(throttled.retryAfter):
+			result.throttleDelay += throttled.retryAfter
+		}
+	}
+}
+
+// parseRetryAfter parses a Retry-After header given either in seconds or as an HTTP-date
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return defaultRetryAfter
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return defaultRetryAfter
+		}
+		return time.Duration(seconds) * time.Second
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		if wait := date.Sub(now); wait > 0 {
+			return wait
+		}
+		return 0
+	}
+
+	return defaultRetryAfter
+}
+
 func sendBulkOrderRequest(ctx context.Context, order models.CreateOrderInput, apiURL string) error {
 	payload, err := json.Marshal(order)
 	if err != nil {
@@ -146,16 +340,7 @@ func sendBulkOrderRequest(ctx context.Context, order models.CreateOrderInput, ap
 	}
 	req.Header.Set("Content-Type", "application/json")
 
-	client := &http.Client{
-		Transport: &http.Transport{
-			MaxConnsPerHost:     500,
-			MaxIdleConns:        500,
-			MaxIdleConnsPerHost: 500,
-			IdleConnTimeout:     90 * time.Second,
-		},
-		Timeout: 10 * time.Second,
-	}
-	resp, err := client.Do(req)
+	resp, err := apiClient.Do(req)
 	if err != nil {
 		if ctx.Err() != nil {
 			return fmt.Errorf("request cancelled or timed out: %w", ctx.Err())
@@ -164,10 +349,18 @@ func sendBulkOrderRequest(ctx context.Context, order models.CreateOrderInput, ap
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return &errThrottled{retryAfter: parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())}
+	}
+
 	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
 		var responseBody bytes.Buffer
 		responseBody.ReadFrom(resp.Body)
-		return fmt.Errorf("API returned non-2xx status: %d - %s", resp.StatusCode, responseBody.String())
+		return &errAPIStatus{
+			statusCode: resp.StatusCode,
+			requestID:  requestIDFromResponse(responseBody.Bytes(), resp.Header),
+			body:       responseBody.String(),
+		}
 	}
 	return nil
 }
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseRetryAfter_Seconds(t *testing.T) {
+	assert.Equal(t, 3*time.Second, parseRetryAfter("3", time.Now()))
+	assert.Equal(t, time.Duration(0), parseRetryAfter("0", time.Now()))
+}
+
+func TestParseRetryAfter_HTTPDate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	header := now.Add(5 * time.Second).Format(http.TimeFormat)
+
+	assert.Equal(t, 5*time.Second, parseRetryAfter(header, now))
+}
+
+func TestParseRetryAfter_PastDate(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	header := now.Add(-time.Minute).Format(http.TimeFormat)
+
+	assert.Equal(t, time.Duration(0), parseRetryAfter(header, now))
+}
+
+func TestParseRetryAfter_MissingOrInvalid(t *testing.T) {
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("", time.Now()))
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("soon", time.Now()))
+	assert.Equal(t, defaultRetryAfter, parseRetryAfter("-1", time.Now()))
+}
+
+func TestGenerateDummyOrders_UniqueNames(t *testing.T) {
+	// Act
+	orders := generateDummyOrders(500, true)
+
+	// Assert
+	seen := make(map[string]bool, len(orders))
+	for _, order := range orders {
+		assert.False(t, seen[order.CustomerName], "duplicate customer name %q", order.CustomerName)
+		seen[order.CustomerName] = true
+		assert.NotEmpty(t, order.Items)
+	}
+}
+
+func TestGenerateDummyOrders_PlainNames(t *testing.T) {
+	// Act
+	orders := generateDummyOrders(10, false)
+
+	// Assert
+	assert.Len(t, orders, 10)
+	for _, order := range orders {
+		assert.NotRegexp(t, `-[0-9a-f]{8}-\d+$`, order.CustomerName)
+	}
+}
+
+func TestSendBulkOrderRequest_CapturesRequestID(t *testing.T) {
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"Internal server error","request_id":"req-123"}`))
+	}))
+	defer server.Close()
+
+	// Act
+	err := sendBulkOrderRequest(context.Background(), models.CreateOrderInput{CustomerName: "Jane"}, server.URL)
+
+	// Assert
+	var apiErr *errAPIStatus
+	if assert.ErrorAs(t, err, &apiErr) {
+		assert.Equal(t, http.StatusInternalServerError, apiErr.statusCode)
+		assert.Equal(t, "req-123", apiErr.requestID)
+	}
+	assert.Equal(t, "order=5 status=500 request_id=req-123 error="+err.Error(), describeFailure(requestResult{index: 4, err: err}))
+}
+
+func TestRequestIDFromResponse_FallsBackToHeader(t *testing.T) {
+	header := http.Header{}
+	header.Set("X-Request-ID", "req-header")
+
+	assert.Equal(t, "req-body", requestIDFromResponse([]byte(`{"request_id":"req-body"}`), header))
+	assert.Equal(t, "req-header", requestIDFromResponse([]byte(`not json`), header))
+	assert.Equal(t, "", requestIDFromResponse([]byte(`{}`), http.Header{}))
+}
+
+func TestDescribeFailure_WithoutResponse(t *testing.T) {
+	result := requestResult{index: 0, err: errors.New("failed to send request: connection refused")}
+
+	assert.Equal(t, "order=1 status=- request_id=- error=failed to send request: connection refused", describeFailure(result))
+}
+
+func TestValidateStressTestOptions(t *testing.T) {
+	tests := []struct {
+		name        string
+		num         int
+		batch       int
+		concurrency int
+		maxRetries  int
+		samples     int
+		expected    string
+	}{
+		{name: "valid", num: 10, batch: 1, concurrency: 5, maxRetries: 0, samples: 0},
+		{name: "zero num", num: 0, batch: 1, concurrency: 5, expected: "--num must be positive, got 0"},
+		{name: "zero batch", num: 10, batch: 0, concurrency: 5, expected: "--batch must be positive, got 0"},
+		{name: "negative concurrency", num: 10, batch: 1, concurrency: -2, expected: "--concurrency must be positive, got -2"},
+		{name: "negative retries", num: 10, batch: 1, concurrency: 5, maxRetries: -1, expected: "--max-retries must not be negative"},
+		{name: "negative samples", num: 10, batch: 1, concurrency: 5, samples: -1, expected: "--failure-samples must not be negative"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateStressTestOptions(tt.num, tt.batch, tt.concurrency, tt.maxRetries, tt.samples)
+
+			if tt.expected == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.expected)
+			}
+		})
+	}
+}
+
+func TestValidateStressTestOptions_ReportsEveryProblem(t *testing.T) {
+	err := validateStressTestOptions(0, 0, 0, 0, 0)
+
+	assert.EqualError(t, err, "--num must be positive, got 0; --batch must be positive, got 0; --concurrency must be positive, got 0")
+}
+
+func TestRunStressTest_RejectsZeroConcurrency(t *testing.T) {
+	done := make(chan error, 1)
+	go func() { done <- RunStressTest(10, 1, 0, "http://127.0.0.1:0") }()
+
+	select {
+	case err := <-done:
+		assert.ErrorContains(t, err, "--concurrency must be positive")
+	case <-time.After(time.Second):
+		t.Fatal("RunStressTest blocked with zero concurrency")
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"os"
 	"sync"
 
+	"github.com/Testzyler/order-management-go/infrastructure/utils/buildinfo"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	"github.com/spf13/cobra"
 )
@@ -19,6 +20,12 @@ var rootCmd = &cobra.Command{
 	},
 }
 
+// SetBuildInfo records the version and commit of the running binary
+func SetBuildInfo(version, commit string) {
+	buildinfo.Set(version, commit)
+	rootCmd.Version = version + " (" + commit + ")"
+}
+
 func Execute() {
 	if err := rootCmd.Execute(); err != nil {
 		logger.Warnf("Error executing root command: %v", err)
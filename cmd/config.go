@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/http"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var ConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Inspect the configuration",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+var ConfigValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Validate the config file without starting the server",
+	Run: func(cmd *cobra.Command, args []string) {
+		if problems := validateConfig(viper.GetViper()); len(problems) > 0 {
+			printConfigProblems(cmd.ErrOrStderr(), problems)
+			os.Exit(1)
+		}
+		fmt.Fprintf(cmd.OutOrStdout(), "Config %s is valid\n", viper.ConfigFileUsed())
+	},
+}
+
+// requiredConfigKeys must be set for the server to start
+var requiredConfigKeys = []string{
+	"HttpServer.Port",
+	"Database.Username",
+	"Database.Password",
+	"Database.Host",
+	"Database.Port",
+	"Database.DatabaseName",
+}
+
+// durationConfigKeys are read with viper.GetDuration, which silently returns 0 for unparseable values
+var durationConfigKeys = []string{
+	"HttpServer.RequestTimeout",
+	"HttpServer.ServerTimeout",
+	"HttpServer.IdleTimeout",
+	"HttpServer.ShutdownTimeout",
+	"Readiness.WarmupPeriod",
+	"Readiness.DrainDelay",
+	"Readiness.SaturationGrace",
+	"Health.WriteStaleWindow",
+	"Database.QueryTimeout",
+	"Database.SlowTransactionThreshold",
+	"Database.AnalyzeInterval",
+	"Database.ConnectTimeout",
+	"Database.ReadyTimeout",
+	"Database.ConnectRetryBackoff",
+	"Database.PoolStatsInterval",
+	"Idempotency.Lifetime",
+	"OrderExpiry.Interval",
+	"OrderExpiry.MaxAge",
+	"Order.CustomerQuota.Window",
+}
+
+// validateConfig checks the loaded configuration without connecting to anything
+// and returns every problem found
+func validateConfig(v *viper.Viper) []string {
+	var problems []string
+
+	for _, key := range requiredConfigKeys {
+		if !v.IsSet(key) || v.GetString(key) == "" {
+			problems = append(problems, fmt.Sprintf("%s is required", key))
+		}
+	}
+
+	for _, key := range durationConfigKeys {
+		if !v.IsSet(key) {
+			continue
+		}
+		if _, err := time.ParseDuration(v.GetString(key)); err != nil {
+			problems = append(problems, fmt.Sprintf("%s: invalid duration %q", key, v.GetString(key)))
+		}
+	}
+
+	if level := v.GetString("Logger.Level"); level != "" && !logger.IsValidLevel(level) {
+		problems = append(problems, fmt.Sprintf("Logger.Level: unknown level %q", level))
+	}
+	if format := v.GetString("Logger.Format"); format != "" && format != logger.FormatJSON && format != logger.FormatCompact && format != logger.FormatAuto {
+		problems = append(problems, fmt.Sprintf("Logger.Format: must be json, compact or auto, got %q", format))
+	}
+	if err := logger.ValidateTimeFormat(v.GetString("Logger.TimeFormat")); err != nil {
+		problems = append(problems, fmt.Sprintf("Logger.TimeFormat: %v", err))
+	}
+
+	if version := v.GetInt("HttpServer.DefaultResponseVersion"); v.IsSet("HttpServer.DefaultResponseVersion") && version != middleware.ResponseVersion1 && version != middleware.ResponseVersion2 {
+		problems = append(problems, fmt.Sprintf("HttpServer.DefaultResponseVersion: must be 1 or 2, got %q", v.GetString("HttpServer.DefaultResponseVersion")))
+	}
+	if v.GetBool("Auth.Enabled") && v.GetString("Auth.Secret") == "" {
+		problems = append(problems, "Auth.Secret is required when Auth.Enabled is true")
+	}
+	if strategy := models.IDStrategy(v.GetString("Order.IDStrategy")); strategy != "" && !strategy.IsValid() {
+		problems = append(problems, fmt.Sprintf("Order.IDStrategy: must be serial or uuid, got %q", strategy))
+	}
+	if strategy := models.DuplicateItemStrategy(v.GetString("Order.DuplicateItems")); strategy != "" && !strategy.IsValid() {
+		problems = append(problems, fmt.Sprintf("Order.DuplicateItems: must be allow, merge or reject, got %q", strategy))
+	}
+
+	if _, err := http.PIICipherFromViper(v); err != nil {
+		problems = append(problems, fmt.Sprintf("Security: %v", err))
+	}
+
+	dbConfig := database.ConfigFromViper(v)
+	if err := database.ValidateSchema(dbConfig.DatabaseSchema); err != nil {
+		problems = append(problems, fmt.Sprintf("Database.DatabaseSchema: %v", err))
+	}
+	if dbConfig.ConnectRetries < 0 {
+		problems = append(problems, fmt.Sprintf("Database.ConnectRetries: must not be negative, got %d", dbConfig.ConnectRetries))
+	}
+	if _, err := pgxpool.ParseConfig(dbConfig.DSN()); err != nil {
+		problems = append(problems, fmt.Sprintf("Database: invalid connection settings: %v", err))
+	}
+
+	return problems
+}
+
+// printConfigProblems reports validation problems to out; the logger is not initialized yet
+func printConfigProblems(out io.Writer, problems []string) {
+	fmt.Fprintf(out, "Config %s is invalid:\n", viper.ConfigFileUsed())
+	for _, problem := range problems {
+		fmt.Fprintf(out, "  - %s\n", problem)
+	}
+}
+
+func init() {
+	ConfigCmd.AddCommand(ConfigValidateCmd)
+	rootCmd.AddCommand(ConfigCmd)
+}
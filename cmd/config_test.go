@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func loadTestConfig(t *testing.T, yaml string) *viper.Viper {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	assert.NoError(t, v.ReadConfig(strings.NewReader(yaml)))
+	return v
+}
+
+const validTestConfig = `
+HttpServer:
+  Port: 3333
+  RequestTimeout: 30s
+Database:
+  Username: dborder
+  Password: secret
+  Host: localhost
+  Port: 5432
+  DatabaseName: store
+  DatabaseSchema: store
+Logger:
+  Level: info
+  Format: json
+`
+
+func TestValidateConfig_Valid(t *testing.T) {
+	// Arrange
+	v := loadTestConfig(t, validTestConfig)
+
+	// Act
+	problems := validateConfig(v)
+
+	// Assert
+	assert.Empty(t, problems)
+}
+
+func TestValidateConfig_Invalid(t *testing.T) {
+	// Arrange
+	v := loadTestConfig(t, `
+HttpServer:
+  Port: 3333
+  RequestTimeout: thirty seconds
+Database:
+  Username: dborder
+  Host: localhost
+  Port: not-a-port
+  DatabaseName: store
+  ConnectRetries: -1
+Logger:
+  Level: verbose
+  Format: xml
+  TimeFormat: hh:mm
+Auth:
+  Enabled: true
+`)
+
+	// Act
+	problems := validateConfig(v)
+
+	// Assert
+	report := strings.Join(problems, "\n")
+	assert.Contains(t, report, "Database.Password is required")
+	assert.Contains(t, report, "HttpServer.RequestTimeout: invalid duration")
+	assert.Contains(t, report, `Logger.Level: unknown level "verbose"`)
+	assert.Contains(t, report, "Logger.Format: must be json, compact or auto")
+	assert.Contains(t, report, `Logger.TimeFormat: invalid log time format "hh:mm"`)
+	assert.Contains(t, report, "Database: invalid connection settings")
+	assert.Contains(t, report, "Database.ConnectRetries: must not be negative")
+	assert.Contains(t, report, "Auth.Secret is required when Auth.Enabled is true")
+	assert.NotContains(t, report, "secret")
+}
+
+func TestValidateConfig_ShippedConfigs(t *testing.T) {
+	for _, path := range []string{"../config/config.yaml", "../config/config.docker.yaml"} {
+		t.Run(path, func(t *testing.T) {
+			// Arrange
+			v := viper.New()
+			v.SetConfigFile(path)
+			assert.NoError(t, v.ReadInConfig())
+
+			// Act
+			problems := validateConfig(v)
+
+			// Assert
+			assert.Empty(t, problems)
+		})
+	}
+}
+
+func TestPrintConfigProblems_WritesToGivenWriter(t *testing.T) {
+	// Arrange
+	var out bytes.Buffer
+
+	// Act
+	printConfigProblems(&out, []string{"HttpServer.Port is required", "Logger.Level is invalid"})
+
+	// Assert
+	assert.Contains(t, out.String(), "is invalid:\n")
+	assert.Contains(t, out.String(), "  - HttpServer.Port is required\n")
+	assert.Contains(t, out.String(), "  - Logger.Level is invalid\n")
+}
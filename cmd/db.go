@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var DBCmd = &cobra.Command{
+	Use:   "db",
+	Short: "Database utilities",
+	Run: func(cmd *cobra.Command, args []string) {
+		cmd.Usage()
+	},
+}
+
+var DBPingCmd = &cobra.Command{
+	Use:   "ping",
+	Short: "Connect to the database, run a ping and the health-check query, then exit",
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := initLogger(); err != nil {
+			logger.Fatalf("Failed to initialize logger: %v", err)
+		}
+
+		if err := runDBPing(cmd.Context(), cmd.OutOrStdout()); err != nil {
+			fmt.Fprintf(cmd.ErrOrStderr(), "Database check failed: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+// poolConfigurer is implemented by the pool returned from InitializeDatabase
+type poolConfigurer interface {
+	PoolConfig() *pgxpool.Config
+}
+
+// runDBPing initializes the pool and reports connect, ping and health-check latency to out
+func runDBPing(ctx context.Context, out io.Writer) error {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	dbConfig := database.ConfigFromViper(viper.GetViper())
+	fmt.Fprintf(out, "Database: %s\n", database.RedactDSN(dbConfig.DSN()))
+
+	start := time.Now()
+	db, err := database.InitializeDatabase()
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+	fmt.Fprintf(out, "Connect: %s\n", time.Since(start).Round(time.Microsecond))
+
+	if p, ok := db.(database.Pinger); ok {
+		start = time.Now()
+		if err := p.Ping(ctx); err != nil {
+			return fmt.Errorf("ping failed: %w", err)
+		}
+		fmt.Fprintf(out, "Ping: %s\n", time.Since(start).Round(time.Microsecond))
+	}
+
+	query := database.HealthCheckQuery()
+	start = time.Now()
+	if err := database.HealthCheck(ctx, db, query); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "Health check (%s): %s\n", query, time.Since(start).Round(time.Microsecond))
+
+	if p, ok := db.(poolConfigurer); ok {
+		config := p.PoolConfig()
+		fmt.Fprintf(out, "Pool: max_conns=%d min_conns=%d max_conn_lifetime=%s max_conn_idle_time=%s health_check_period=%s\n",
+			config.MaxConns, config.MinConns, config.MaxConnLifetime, config.MaxConnIdleTime, config.HealthCheckPeriod)
+	}
+
+	fmt.Fprintln(out, "Database OK")
+	return nil
+}
+
+func init() {
+	DBCmd.AddCommand(DBPingCmd)
+	rootCmd.AddCommand(DBCmd)
+}
@@ -9,9 +9,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/Testzyler/order-management-go/application/repositories"
+	"github.com/Testzyler/order-management-go/application/services"
 	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/Testzyler/order-management-go/infrastructure/http"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/Testzyler/order-management-go/infrastructure/worker"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -20,6 +24,12 @@ var ServeCmd = &cobra.Command{
 	Use:   "http-serve",
 	Short: "serve http server",
 	Run: func(cmd *cobra.Command, args []string) {
+		// Refuse to start with an incomplete or invalid configuration
+		if problems := validateConfig(viper.GetViper()); len(problems) > 0 {
+			printConfigProblems(cmd.ErrOrStderr(), problems)
+			os.Exit(1)
+		}
+
 		// Initialize logger first
 		if err := initLogger(); err != nil {
 			logger.Fatalf("Failed to initialize logger: %v", err)
@@ -27,6 +37,7 @@ var ServeCmd = &cobra.Command{
 
 		appLogger := logger.GetDefault()
 		appLogger.Info("Starting order management application")
+		startedAt := time.Now()
 
 		// Create main context for the application
 		ctx, cancel := context.WithCancel(context.Background())
@@ -35,6 +46,9 @@ var ServeCmd = &cobra.Command{
 		// Initialize services
 		initPostgresql()
 		initHttpServer(ctx)
+		initOrderExpiryWorker(ctx)
+		initPoolStatsLogger(ctx)
+		markReadyAfterWarmup(ctx)
 
 		appLogger.Info("All services initialized successfully")
 
@@ -43,10 +57,20 @@ var ServeCmd = &cobra.Command{
 		signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
 
 		select {
-		case <-quit:
-			appLogger.Info("Received shutdown signal")
+		case sig := <-quit:
+			// SIGINT is usually an operator Ctrl-C, SIGTERM an orchestrator stopping the container
+			appLogger.Info("Received shutdown signal", "signal", sig.String(), "uptime", time.Since(startedAt).Round(time.Second).String())
 		case <-ctx.Done():
-			appLogger.Info("Application context cancelled")
+			appLogger.Info("Application context cancelled", "uptime", time.Since(startedAt).Round(time.Second).String())
+		}
+
+		// Report not-ready first so load balancers stop routing before connections close
+		if err := readiness.Transition(readiness.StateDraining); err != nil {
+			appLogger.Warn("Failed to mark service as draining", "error", err)
+		}
+		if drainDelay := viper.GetDuration("Readiness.DrainDelay"); drainDelay > 0 {
+			appLogger.Info("Draining before shutdown", "delay", drainDelay.String())
+			time.Sleep(drainDelay)
 		}
 
 		appLogger.Info("Shutting down server...")
@@ -66,6 +90,8 @@ var ServeCmd = &cobra.Command{
 		shutdownDone := make(chan struct{})
 		go func() {
 			defer close(shutdownDone)
+			// Stop the listener before closing the pool so in-flight queries can drain;
+			// anything arriving after the pool closes gets a 503 instead of a raw pgx error
 			shutdownHttpServer()
 			shutdownPostgresql()
 			wg.Wait()
@@ -73,6 +99,9 @@ var ServeCmd = &cobra.Command{
 
 		select {
 		case <-shutdownDone:
+			if err := readiness.Transition(readiness.StateStopped); err != nil {
+				appLogger.Warn("Failed to mark service as stopped", "error", err)
+			}
 			appLogger.Info("Server gracefully stopped")
 		case <-shutdownCtx.Done():
 			appLogger.Error("Shutdown timed out, forcing exit")
@@ -105,12 +134,6 @@ func initConfig() {
 		fmt.Printf("Error reading config file: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Verify database configuration
-	if !viper.IsSet("Database.Username") || !viper.IsSet("Database.Password") {
-		fmt.Println("Database configuration is missing or incomplete")
-		os.Exit(1)
-	}
 }
 
 func initLogger() error {
@@ -141,12 +164,112 @@ func initHttpServer(ctx context.Context) {
 	}()
 }
 
+func initOrderExpiryWorker(ctx context.Context) {
+	var expiryConfig worker.OrderExpiryConfig
+	if err := viper.UnmarshalKey("OrderExpiry", &expiryConfig); err != nil {
+		logger.Errorf("Failed to unmarshal order expiry config: %v", err)
+		return
+	}
+	if !expiryConfig.Enabled {
+		logger.Info("Order expiry worker disabled")
+		return
+	}
+
+	// Set defaults if not provided
+	if expiryConfig.Interval <= 0 {
+		expiryConfig.Interval = time.Minute
+	}
+	if expiryConfig.MaxAge <= 0 {
+		expiryConfig.MaxAge = 24 * time.Hour
+	}
+
+	repo := repositories.NewOrderRepository(database.DatabasePool)
+	service := services.NewOrderService(repo)
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		worker.RunOrderExpiryWorker(ctx, service, expiryConfig)
+	}()
+}
+
+// initPoolStatsLogger periodically logs pool statistics when Database.PoolStatsInterval is set
+func initPoolStatsLogger(ctx context.Context) {
+	interval := viper.GetDuration("Database.PoolStatsInterval")
+	if interval <= 0 {
+		return
+	}
+
+	pool, ok := database.DatabasePool.(worker.PoolStatter)
+	if !ok {
+		logger.Warn("Database pool does not expose stats, pool stats logging disabled")
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		worker.RunPoolStatsLogger(ctx, pool, interval)
+	}()
+}
+
+// readinessRetryInterval is how long to wait before checking the database again when the
+// startup check fails
+const readinessRetryInterval = time.Second
+
+// markReadyAfterWarmup reports ready once the configured warm-up period has passed and the
+// database passes the startup check, retrying the check until it does
+func markReadyAfterWarmup(ctx context.Context) {
+	warmup := viper.GetDuration("Readiness.WarmupPeriod")
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		check := func(ctx context.Context) error { return database.CheckStartup(ctx, database.DatabasePool) }
+		if !waitUntilReady(ctx, warmup, readinessRetryInterval, check) {
+			return
+		}
+
+		if err := readiness.Transition(readiness.StateReady); err != nil {
+			logger.Warn("Failed to mark service as ready", "error", err)
+			return
+		}
+		logger.Info("Service is ready to receive traffic")
+	}()
+}
+
+// waitUntilReady waits at least warmup, then runs check every retry until it succeeds. It reports
+// false when ctx is cancelled first.
+func waitUntilReady(ctx context.Context, warmup, retry time.Duration, check func(context.Context) error) bool {
+	select {
+	case <-ctx.Done():
+		return false
+	case <-time.After(warmup):
+	}
+
+	for {
+		err := check(ctx)
+		if err == nil {
+			return true
+		}
+		logger.Warn("Startup check failed, not ready yet", "error", err, "retry_in", retry.String())
+
+		select {
+		case <-ctx.Done():
+			return false
+		case <-time.After(retry):
+		}
+	}
+}
+
 func shutdownHttpServer() {
 	http.ShutdownHttpServer()
 }
 
 func initPostgresql() {
-	database.NewDatabaseConnection()
+	if _, err := database.NewDatabaseConnection(); err != nil {
+		logger.Fatal("DB connection failed", "error", err)
+	}
 }
 
 func shutdownPostgresql() {
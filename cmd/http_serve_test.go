@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWaitUntilReady_RetriesUntilTheCheckPasses(t *testing.T) {
+	// Arrange
+	calls := 0
+	check := func(context.Context) error {
+		calls++
+		if calls < 3 {
+			return errors.New("connection refused")
+		}
+		return nil
+	}
+
+	// Act
+	start := time.Now()
+	ready := waitUntilReady(context.Background(), 20*time.Millisecond, time.Millisecond, check)
+
+	// Assert
+	assert.True(t, ready)
+	assert.Equal(t, 3, calls)
+	assert.GreaterOrEqual(t, time.Since(start), 20*time.Millisecond, "the warm-up is a minimum")
+}
+
+func TestWaitUntilReady_StopsWhenCancelled(t *testing.T) {
+	// Arrange
+	ctx, cancel := context.WithCancel(context.Background())
+	check := func(context.Context) error {
+		cancel()
+		return errors.New("relation \"orders\" does not exist")
+	}
+
+	// Act
+	ready := waitUntilReady(ctx, 0, time.Hour, check)
+
+	// Assert
+	assert.False(t, ready)
+}
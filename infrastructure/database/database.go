@@ -2,7 +2,11 @@ package database
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
@@ -12,14 +16,37 @@ import (
 )
 
 var DatabasePool DatabaseInterface
-var DBConfig = struct {
+
+const (
+	// DefaultConnectTimeout bounds establishing a single connection
+	DefaultConnectTimeout = 10 * time.Second
+	// DefaultReadyTimeout bounds waiting at startup for the health-check query to succeed
+	DefaultReadyTimeout = 30 * time.Second
+	// DefaultConnectRetryBackoff is the wait before the first connect retry; it doubles per attempt
+	DefaultConnectRetryBackoff = 1 * time.Second
+	// maxConnectRetryBackoff caps the doubled wait between connect retries
+	maxConnectRetryBackoff = 30 * time.Second
+)
+
+// ErrInvalidConfig is returned for configuration problems that retrying cannot fix
+var ErrInvalidConfig = errors.New("invalid database config")
+
+// DatabaseConfig holds the connection settings; its String method redacts the password
+type DatabaseConfig struct {
 	Username       string
 	Password       string
 	Host           string
 	Port           int
 	DatabaseName   string
 	DatabaseSchema string
-}{
+	ConnectTimeout time.Duration
+	ReadyTimeout   time.Duration
+	// ConnectRetries is how many times a failed initial connect is retried, 0 fails immediately
+	ConnectRetries      int
+	ConnectRetryBackoff time.Duration
+}
+
+var DBConfig = DatabaseConfig{
 	Username:       viper.GetString("Database.Username"),
 	Password:       viper.GetString("Database.Password"),
 	Host:           viper.GetString("Database.Host"),
@@ -28,45 +55,116 @@ var DBConfig = struct {
 	DatabaseSchema: viper.GetString("Database.DatabaseSchema"),
 }
 
+// ConfigFromViper reads the Database section from v
+func ConfigFromViper(v *viper.Viper) DatabaseConfig {
+	return DatabaseConfig{
+		Username:       v.GetString("Database.Username"),
+		Password:       v.GetString("Database.Password"),
+		Host:           v.GetString("Database.Host"),
+		Port:           v.GetInt("Database.Port"),
+		DatabaseName:   v.GetString("Database.DatabaseName"),
+		DatabaseSchema: v.GetString("Database.DatabaseSchema"),
+		ConnectTimeout: durationOrDefault(v.GetDuration("Database.ConnectTimeout"), DefaultConnectTimeout),
+		ReadyTimeout:   durationOrDefault(v.GetDuration("Database.ReadyTimeout"), DefaultReadyTimeout),
+
+		ConnectRetries:      v.GetInt("Database.ConnectRetries"),
+		ConnectRetryBackoff: durationOrDefault(v.GetDuration("Database.ConnectRetryBackoff"), DefaultConnectRetryBackoff),
+	}
+}
+
+func durationOrDefault(d, fallback time.Duration) time.Duration {
+	if d <= 0 {
+		return fallback
+	}
+	return d
+}
+
+// poolConfig parses the DSN, applies the connect timeout to every new connection and
+// installs the tracer that feeds request query timers
+func (c DatabaseConfig) poolConfig() (*pgxpool.Config, error) {
+	poolConfig, err := pgxpool.ParseConfig(c.DSN())
+	if err != nil {
+		return nil, err
+	}
+	poolConfig.ConnConfig.ConnectTimeout = c.ConnectTimeout
+	poolConfig.ConnConfig.Tracer = queryTimingTracer{}
+	return poolConfig, nil
+}
+
+// schemaNamePattern matches an unquoted Postgres identifier
+var schemaNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// ValidateSchema reports whether schema is empty or a comma-separated list of plain identifiers
+func ValidateSchema(schema string) error {
+	if schema == "" {
+		return nil
+	}
+	for _, name := range strings.Split(schema, ",") {
+		if !schemaNamePattern.MatchString(strings.TrimSpace(name)) {
+			return fmt.Errorf("invalid schema name %q", strings.TrimSpace(name))
+		}
+	}
+	return nil
+}
+
+// DSN builds the postgres connection string; use RedactDSN before logging it.
+// search_path is only set when a schema is configured, otherwise the server default (public) applies.
+func (c DatabaseConfig) DSN() string {
+	dsn := fmt.Sprintf(
+		"postgres://%s:%s@%s:%d/%s?sslmode=disable",
+		c.Username, c.Password, c.Host, c.Port, c.DatabaseName,
+	)
+	if schema := strings.TrimSpace(c.DatabaseSchema); schema != "" {
+		dsn += "&search_path=" + url.QueryEscape(schema)
+	}
+	return dsn
+}
+
 func InitializeDatabase() (DatabaseInterface, error) {
 	log := logger.GetDefault()
 	log.Info("Initializing database connection...")
 
 	// Ensure configuration is loaded
-	userName := viper.GetString("Database.Username")
-	password := viper.GetString("Database.Password")
-	host := viper.GetString("Database.Host")
-	port := viper.GetInt("Database.Port")
-	databaseName := viper.GetString("Database.DatabaseName")
-	databaseSchema := viper.GetString("Database.DatabaseSchema")
-
-	connStr := fmt.Sprintf(
-		"postgres://%s:%s@%s:%d/%s?sslmode=disable&search_path=%s",
-		userName, password, host, port, databaseName, databaseSchema,
-	)
+	config := ConfigFromViper(viper.GetViper())
+	if err := ValidateSchema(config.DatabaseSchema); err != nil {
+		return nil, fmt.Errorf("%w: Database.DatabaseSchema: %v", ErrInvalidConfig, err)
+	}
+	if config.DatabaseSchema == "" {
+		log.Warn("Database.DatabaseSchema is not set, using the server default search_path (public)")
+	}
+	log.Debug("Connecting to database", "dsn", RedactDSN(config.DSN()), "connect_timeout", config.ConnectTimeout.String(), "ready_timeout", config.ReadyTimeout.String())
+
+	poolConfig, err := config.poolConfig()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidConfig, err)
+	}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	ctx, cancel := context.WithTimeout(context.Background(), config.ConnectTimeout)
 	defer cancel()
-	db, err := pgxpool.New(ctx, connStr)
+	db, err := pgxpool.NewWithConfig(ctx, poolConfig)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
-	// Test the connection
-	if err := waitForDatabase(db, 30*time.Second); err != nil {
-		logger.Fatal("DB connection failed", "error", err)
+	pool := newGuardedPool(db)
+
+	// Test the connection and schema access
+	if err := waitForDatabase(pool, HealthCheckQuery(), config.ReadyTimeout); err != nil {
+		pool.Close()
+		return nil, err
 	}
 
 	db.Config().MaxConns = 500
 	db.Config().MinIdleConns = 250
 	db.Config().MaxConnLifetime = 180 * time.Second
 	log.Info("Database connection established successfully.")
-	return db, nil
+	return pool, nil
 }
 
 func NewDatabaseConnection() (DatabaseInterface, error) {
 	if DatabasePool == nil {
-		db, err := InitializeDatabase()
+		config := ConfigFromViper(viper.GetViper())
+		db, err := connectWithRetry(InitializeDatabase, config.ConnectRetries, config.ConnectRetryBackoff, time.Sleep)
 		if err != nil {
 			return nil, fmt.Errorf("error initializing database: %w", err)
 		}
@@ -78,6 +176,26 @@ func NewDatabaseConnection() (DatabaseInterface, error) {
 	return DatabasePool, nil
 }
 
+// connectWithRetry calls connect until it succeeds or retries are used up, doubling the wait
+// between attempts up to maxConnectRetryBackoff. Configuration errors are returned immediately.
+func connectWithRetry(connect func() (DatabaseInterface, error), retries int, backoff time.Duration, sleep func(time.Duration)) (DatabaseInterface, error) {
+	log := logger.GetDefault()
+
+	for attempt := 0; ; attempt++ {
+		db, err := connect()
+		if err == nil {
+			return db, nil
+		}
+		if attempt >= retries || errors.Is(err, ErrInvalidConfig) {
+			return nil, err
+		}
+
+		log.Warn("Database connect failed, retrying", "error", err, "attempt", attempt+1, "retries", retries, "backoff", backoff.String())
+		sleep(backoff)
+		backoff = min(backoff*2, maxConnectRetryBackoff)
+	}
+}
+
 func ShutdownDatabase() error {
 	if DatabasePool != nil {
 		DatabasePool.Close()
@@ -86,15 +204,14 @@ func ShutdownDatabase() error {
 	return nil
 }
 
-func waitForDatabase(pool *pgxpool.Pool, timeout time.Duration) error {
+// waitForDatabase runs the health-check query until it succeeds or the timeout passes
+func waitForDatabase(db DatabaseInterface, query string, timeout time.Duration) error {
 	log := logger.GetDefault()
-	log.Info("Waiting for database to be ready...")
+	log.Info("Waiting for database to be ready...", "query", query)
 
 	deadline := time.Now().Add(timeout)
 	for {
-		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
-		err := pool.Ping(ctx)
-		cancel()
+		err := HealthCheck(context.Background(), db, query)
 
 		if err == nil {
 			return nil
@@ -104,7 +221,7 @@ func waitForDatabase(pool *pgxpool.Pool, timeout time.Duration) error {
 			return fmt.Errorf("database not ready after %s: %w", timeout, err)
 		}
 
-		log.Info("Database not ready, retrying in 1 s...")
+		log.Info("Database not ready, retrying in 1 s...", "error", err)
 		time.Sleep(1 * time.Second)
 	}
 }
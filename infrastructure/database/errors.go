@@ -0,0 +1,80 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Stable error codes returned to clients for database failures. They tell dashboards and clients
+// what class of failure occurred without exposing the underlying error message.
+const (
+	ErrorCodeConstraint  = "DB_CONSTRAINT"
+	ErrorCodeUnavailable = "DB_UNAVAILABLE"
+	ErrorCodeTimeout     = "DB_TIMEOUT"
+	ErrorCodeConflict    = "DB_CONFLICT"
+	ErrorCodeQuery       = "DB_QUERY"
+	ErrorCodeInternal    = "DB_ERROR"
+)
+
+// pgUnavailableCodes are the server errors raised while it is shutting down, starting up,
+// read-only or out of resources
+var pgUnavailableCodes = map[string]bool{
+	"25006": true, // read_only_sql_transaction
+	"57P01": true, // admin_shutdown
+	"57P02": true, // crash_shutdown
+	"57P03": true, // cannot_connect_now
+}
+
+// pgTimeoutCodes are the server errors raised when a statement or lock wait timed out
+var pgTimeoutCodes = map[string]bool{
+	"57014": true, // query_canceled, including statement_timeout
+	"55P03": true, // lock_not_available, including lock_timeout
+}
+
+// ErrorCode classifies a database error into one of the ErrorCode constants. It returns an empty
+// string for errors that did not come from the database.
+func ErrorCode(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErrorCode(pgErr.Code)
+	}
+
+	var connectErr *pgconn.ConnectError
+	switch {
+	case errors.Is(err, ErrPoolClosed), errors.As(err, &connectErr):
+		return ErrorCodeUnavailable
+	case pgconn.Timeout(err), errors.Is(err, context.DeadlineExceeded):
+		return ErrorCodeTimeout
+	}
+	return ""
+}
+
+// pgErrorCode maps a SQLSTATE to an error code, by its class where the exact code is not listed
+func pgErrorCode(code string) string {
+	switch {
+	case pgUnavailableCodes[code]:
+		return ErrorCodeUnavailable
+	case pgTimeoutCodes[code]:
+		return ErrorCodeTimeout
+	}
+
+	switch {
+	case strings.HasPrefix(code, "23"): // integrity constraint violation
+		return ErrorCodeConstraint
+	case strings.HasPrefix(code, "08"), strings.HasPrefix(code, "53"): // connection exception, insufficient resources
+		return ErrorCodeUnavailable
+	case strings.HasPrefix(code, "40"): // serialization failure, deadlock
+		return ErrorCodeConflict
+	case strings.HasPrefix(code, "42"), strings.HasPrefix(code, "22"): // syntax or access rule violation, data exception
+		return ErrorCodeQuery
+	default:
+		return ErrorCodeInternal
+	}
+}
@@ -0,0 +1,48 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestErrorCode(t *testing.T) {
+	pgError := func(code string) error {
+		return fmt.Errorf("failed to insert order: %w", &pgconn.PgError{Code: code})
+	}
+
+	cases := []struct {
+		name string
+		err  error
+		want string
+	}{
+		{name: "unique violation", err: pgError("23505"), want: ErrorCodeConstraint},
+		{name: "foreign key violation", err: pgError("23503"), want: ErrorCodeConstraint},
+		{name: "check violation", err: pgError("23514"), want: ErrorCodeConstraint},
+		{name: "connection failure", err: pgError("08006"), want: ErrorCodeUnavailable},
+		{name: "too many connections", err: pgError("53300"), want: ErrorCodeUnavailable},
+		{name: "admin shutdown", err: pgError("57P01"), want: ErrorCodeUnavailable},
+		{name: "read only", err: pgError("25006"), want: ErrorCodeUnavailable},
+		{name: "statement timeout", err: pgError("57014"), want: ErrorCodeTimeout},
+		{name: "lock timeout", err: pgError("55P03"), want: ErrorCodeTimeout},
+		{name: "serialization failure", err: pgError("40001"), want: ErrorCodeConflict},
+		{name: "deadlock", err: pgError("40P01"), want: ErrorCodeConflict},
+		{name: "undefined table", err: pgError("42P01"), want: ErrorCodeQuery},
+		{name: "numeric overflow", err: pgError("22003"), want: ErrorCodeQuery},
+		{name: "other server error", err: pgError("XX000"), want: ErrorCodeInternal},
+		{name: "pool closed", err: fmt.Errorf("%w: closed pool", ErrPoolClosed), want: ErrorCodeUnavailable},
+		{name: "context deadline", err: fmt.Errorf("failed to query order: %w", context.DeadlineExceeded), want: ErrorCodeTimeout},
+		{name: "not a database error", err: errors.New("order not found"), want: ""},
+		{name: "nil", err: nil, want: ""},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, ErrorCode(tc.err))
+		})
+	}
+}
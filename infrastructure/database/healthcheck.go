@@ -0,0 +1,72 @@
+package database
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// DefaultHealthCheckQuery only verifies that a connection can run a statement
+	DefaultHealthCheckQuery = "SELECT 1"
+	// DefaultHealthCheckTimeout bounds a single health check run
+	DefaultHealthCheckTimeout = 2 * time.Second
+	// schemaCheckQuery fails unless the tables orders are served from exist and can be read
+	schemaCheckQuery = "SELECT (SELECT 1 FROM orders LIMIT 0), (SELECT 1 FROM order_items LIMIT 0)"
+)
+
+// HealthCheckQuery returns the configured Database.HealthCheckQuery, e.g. "SELECT 1 FROM orders LIMIT 1"
+// to also verify schema access, falling back to DefaultHealthCheckQuery
+func HealthCheckQuery() string {
+	if query := viper.GetString("Database.HealthCheckQuery"); query != "" {
+		return query
+	}
+	return DefaultHealthCheckQuery
+}
+
+// HealthCheck runs the health-check query against db and reports whether it succeeded
+func HealthCheck(ctx context.Context, db DatabaseInterface, query string) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+	defer cancel()
+
+	if _, err := db.Exec(ctx, query); err != nil {
+		return fmt.Errorf("health check query failed: %w", err)
+	}
+	return nil
+}
+
+// Pinger is implemented by connections that can check reachability without running a query,
+// such as the pool returned by InitializeDatabase
+type Pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// CheckStartup verifies that the service can serve orders from db: it answers a ping and the
+// health-check query, the order tables are readable and no failed migration left the schema dirty
+func CheckStartup(ctx context.Context, db DatabaseInterface) error {
+	if pinger, ok := db.(Pinger); ok {
+		pingCtx, cancel := context.WithTimeout(ctx, DefaultHealthCheckTimeout)
+		err := pinger.Ping(pingCtx)
+		cancel()
+		if err != nil {
+			return fmt.Errorf("database ping failed: %w", err)
+		}
+	}
+	if err := HealthCheck(ctx, db, HealthCheckQuery()); err != nil {
+		return err
+	}
+	if err := HealthCheck(ctx, db, schemaCheckQuery); err != nil {
+		return fmt.Errorf("schema check failed: %w", err)
+	}
+
+	version, err := GetSchemaVersion(ctx, db)
+	if err != nil {
+		return err
+	}
+	if version != nil && version.Dirty {
+		return fmt.Errorf("schema version %d is dirty, a migration did not finish", version.Version)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDatabase records the executed query and returns execErr from Exec; other methods panic
+type stubDatabase struct {
+	DatabaseInterface
+	execErr   error
+	execQuery string
+}
+
+func (s *stubDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	s.execQuery = sql
+	return pgconn.CommandTag{}, s.execErr
+}
+
+func TestHealthCheck_Success(t *testing.T) {
+	// Arrange
+	db := &stubDatabase{}
+
+	// Act
+	err := HealthCheck(context.Background(), db, DefaultHealthCheckQuery)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, DefaultHealthCheckQuery, db.execQuery)
+}
+
+func TestHealthCheck_QueryFails(t *testing.T) {
+	// Arrange: the pool connects but the role cannot see the orders table
+	pgErr := &pgconn.PgError{Code: "42P01", Message: `relation "orders" does not exist`}
+	db := &stubDatabase{execErr: pgErr}
+
+	// Act
+	err := HealthCheck(context.Background(), db, "SELECT 1 FROM orders LIMIT 1")
+
+	// Assert
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, pgErr)
+	assert.Equal(t, "SELECT 1 FROM orders LIMIT 1", db.execQuery)
+}
+
+func TestWaitForDatabase_TimesOutOnFailingQuery(t *testing.T) {
+	// Arrange
+	pgErr := &pgconn.PgError{Code: "42501", Message: "permission denied for table orders"}
+	db := &stubDatabase{execErr: pgErr}
+
+	// Act
+	err := waitForDatabase(db, "SELECT 1 FROM orders LIMIT 1", 0)
+
+	// Assert
+	assert.ErrorIs(t, err, pgErr)
+}
+
+func TestWaitForDatabase_UsesReadyTimeout(t *testing.T) {
+	// Arrange
+	db := &stubDatabase{execErr: errors.New("connection refused")}
+
+	// Act
+	start := time.Now()
+	err := waitForDatabase(db, DefaultHealthCheckQuery, 50*time.Millisecond)
+
+	// Assert
+	assert.ErrorContains(t, err, "database not ready after 50ms")
+	assert.Less(t, time.Since(start), 3*time.Second)
+}
+
+// startupDatabase answers the startup check: pingErr from Ping, execErrs by query from Exec and
+// the given schema version from the migrations table
+type startupDatabase struct {
+	DatabaseInterface
+	pingErr  error
+	execErrs map[string]error
+	version  *SchemaVersion
+	executed []string
+}
+
+func (s *startupDatabase) Ping(ctx context.Context) error { return s.pingErr }
+
+func (s *startupDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	s.executed = append(s.executed, sql)
+	return pgconn.CommandTag{}, s.execErrs[sql]
+}
+
+func (s *startupDatabase) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if s.version == nil {
+		return errRow{err: pgx.ErrNoRows}
+	}
+	return versionRow{version: *s.version}
+}
+
+type versionRow struct{ version SchemaVersion }
+
+func (r versionRow) Scan(dest ...any) error {
+	*dest[0].(*int64) = r.version.Version
+	*dest[1].(*bool) = r.version.Dirty
+	return nil
+}
+
+func TestCheckStartup(t *testing.T) {
+	tests := []struct {
+		name    string
+		db      *startupDatabase
+		wantErr string
+	}{
+		{name: "ready", db: &startupDatabase{version: &SchemaVersion{Version: 3}}},
+		{name: "no migrations recorded", db: &startupDatabase{}},
+		{name: "ping fails", db: &startupDatabase{pingErr: ErrPoolClosed}, wantErr: "database ping failed"},
+		{name: "tables missing", db: &startupDatabase{execErrs: map[string]error{schemaCheckQuery: &pgconn.PgError{Code: "42P01"}}}, wantErr: "schema check failed"},
+		{name: "dirty schema", db: &startupDatabase{version: &SchemaVersion{Version: 4, Dirty: true}}, wantErr: "schema version 4 is dirty"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Act
+			err := CheckStartup(context.Background(), tt.db)
+
+			// Assert
+			if tt.wantErr == "" {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{DefaultHealthCheckQuery, schemaCheckQuery}, tt.db.executed)
+				return
+			}
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
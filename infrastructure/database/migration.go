@@ -0,0 +1,37 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// MigrationsTable holds the currently applied schema version
+const MigrationsTable = "schema_migrations"
+
+// SchemaVersion describes the migration state of the database
+type SchemaVersion struct {
+	Version int64 `json:"version"`
+	Dirty   bool  `json:"dirty"`
+}
+
+// GetSchemaVersion reads the current schema version from the migrations table.
+// It returns nil when no migration has been recorded yet.
+func GetSchemaVersion(ctx context.Context, db DatabaseInterface) (*SchemaVersion, error) {
+	query := fmt.Sprintf("SELECT version, dirty FROM %s ORDER BY version DESC LIMIT 1", MigrationsTable)
+
+	var version SchemaVersion
+	err := db.QueryRow(ctx, query).Scan(&version.Version, &version.Dirty)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.Is(err, pgx.ErrNoRows) || (errors.As(err, &pgErr) && pgErr.Code == "42P01") {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to read schema version: %w", err)
+	}
+
+	return &version, nil
+}
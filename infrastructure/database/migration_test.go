@@ -0,0 +1,45 @@
+package database
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestMigrations_MatchInitSchemaVersion checks that the upgrade scripts are numbered without gaps,
+// that each records its own version, and that init.sql records the version of the last one
+func TestMigrations_MatchInitSchemaVersion(t *testing.T) {
+	// Arrange
+	files, err := filepath.Glob("../../migrations/*.up.sql")
+	assert.NoError(t, err)
+	initSQL, err := os.ReadFile("../../init.sql")
+	assert.NoError(t, err)
+	recorded := regexp.MustCompile(`INSERT INTO store\.schema_migrations \(version, dirty\) VALUES \((\d+), FALSE\)`)
+
+	// Act
+	var versions []int
+	for _, file := range files {
+		version, err := strconv.Atoi(filepath.Base(file)[:6])
+		assert.NoError(t, err, file)
+		versions = append(versions, version)
+
+		script, err := os.ReadFile(file)
+		assert.NoError(t, err)
+		if match := recorded.FindSubmatch(script); assert.NotNil(t, match, file) {
+			assert.Equal(t, strconv.Itoa(version), string(match[1]), file)
+		}
+	}
+	initVersion := recorded.FindSubmatch(initSQL)
+
+	// Assert
+	if assert.NotEmpty(t, versions) && assert.NotNil(t, initVersion) {
+		for i, version := range versions {
+			assert.Equal(t, i+2, version, "migrations start at version 2 and have no gaps")
+		}
+		assert.Equal(t, strconv.Itoa(versions[len(versions)-1]), string(initVersion[1]))
+	}
+}
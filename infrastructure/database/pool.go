@@ -0,0 +1,115 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/jackc/puddle/v2"
+)
+
+// ErrPoolClosed is returned for queries issued while the pool is shutting down
+var ErrPoolClosed = errors.New("database pool is closed")
+
+// guardedPool wraps pgxpool.Pool so that queries against a closing pool fail with ErrPoolClosed
+type guardedPool struct {
+	pool   *pgxpool.Pool
+	closed atomic.Bool
+}
+
+func newGuardedPool(pool *pgxpool.Pool) *guardedPool {
+	return &guardedPool{pool: pool}
+}
+
+func (p *guardedPool) Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error) {
+	if p.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+	rows, err := p.pool.Query(ctx, sql, args...)
+	return rows, translatePoolError(err)
+}
+
+func (p *guardedPool) QueryRow(ctx context.Context, sql string, args ...any) pgx.Row {
+	if p.closed.Load() {
+		return errRow{err: ErrPoolClosed}
+	}
+	return guardedRow{row: p.pool.QueryRow(ctx, sql, args...)}
+}
+
+func (p *guardedPool) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	if p.closed.Load() {
+		return pgconn.CommandTag{}, ErrPoolClosed
+	}
+	tag, err := p.pool.Exec(ctx, sql, args...)
+	return tag, translatePoolError(err)
+}
+
+func (p *guardedPool) Begin(ctx context.Context) (pgx.Tx, error) {
+	if p.closed.Load() {
+		return nil, ErrPoolClosed
+	}
+	tx, err := p.pool.Begin(ctx)
+	return tx, translatePoolError(err)
+}
+
+// Close rejects new queries and waits for acquired connections to be released
+func (p *guardedPool) Close() {
+	p.closed.Store(true)
+	p.pool.Close()
+}
+
+func (p *guardedPool) Ping(ctx context.Context) error {
+	if p.closed.Load() {
+		return ErrPoolClosed
+	}
+	return translatePoolError(p.pool.Ping(ctx))
+}
+
+// PoolConfig returns the configuration of the underlying pgx pool
+func (p *guardedPool) PoolConfig() *pgxpool.Config {
+	return p.pool.Config()
+}
+
+// Stat returns a snapshot of the underlying pool statistics
+func (p *guardedPool) Stat() *pgxpool.Stat {
+	return p.pool.Stat()
+}
+
+// AvailableConns returns how many connections db can hand out without waiting: the idle ones plus
+// those the pool may still open. ok is false when db does not expose pool statistics.
+func AvailableConns(db DatabaseInterface) (available int, ok bool) {
+	statter, ok := db.(interface{ Stat() *pgxpool.Stat })
+	if !ok {
+		return 0, false
+	}
+	stat := statter.Stat()
+	return int(stat.IdleConns() + stat.MaxConns() - stat.TotalConns()), true
+}
+
+// translatePoolError maps pgxpool's closed pool error to ErrPoolClosed
+func translatePoolError(err error) error {
+	if err != nil && errors.Is(err, puddle.ErrClosedPool) {
+		return fmt.Errorf("%w: %v", ErrPoolClosed, err)
+	}
+	return err
+}
+
+type guardedRow struct {
+	row pgx.Row
+}
+
+func (r guardedRow) Scan(dest ...any) error {
+	return translatePoolError(r.row.Scan(dest...))
+}
+
+type errRow struct {
+	err error
+}
+
+func (r errRow) Scan(dest ...any) error {
+	return r.err
+}
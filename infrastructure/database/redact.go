@@ -0,0 +1,43 @@
+package database
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+)
+
+const redactedPassword = "xxxxx"
+
+// keyValuePasswordPattern matches password=... in key/value DSNs, quoted or not
+var keyValuePasswordPattern = regexp.MustCompile(`(?i)(password\s*=\s*)('(?:[^'\\]|\\.)*'|\S+)`)
+
+// RedactDSN masks the password of a postgres URL or key/value connection string
+// so it can be logged safely. Host, port and database name are kept.
+func RedactDSN(dsn string) string {
+	if u, err := url.Parse(dsn); err == nil && u.Scheme != "" && u.Host != "" {
+		if _, hasPassword := u.User.Password(); hasPassword {
+			u.User = url.UserPassword(u.User.Username(), redactedPassword)
+		}
+		query := u.Query()
+		if query.Has("password") {
+			query.Set("password", redactedPassword)
+			u.RawQuery = query.Encode()
+		}
+		return u.String()
+	}
+
+	return keyValuePasswordPattern.ReplaceAllString(dsn, "${1}"+redactedPassword)
+}
+
+// String implements fmt.Stringer so the password never ends up in logs
+func (c DatabaseConfig) String() string {
+	return fmt.Sprintf(
+		"{Username:%s Password:%s Host:%s Port:%d DatabaseName:%s DatabaseSchema:%s}",
+		c.Username, redactedPassword, c.Host, c.Port, c.DatabaseName, c.DatabaseSchema,
+	)
+}
+
+// GoString implements fmt.GoStringer for %#v
+func (c DatabaseConfig) GoString() string {
+	return "database.DatabaseConfig" + c.String()
+}
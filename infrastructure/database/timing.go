@@ -0,0 +1,50 @@
+package database
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// QueryTimer accumulates the time spent in database queries issued with its context
+type QueryTimer struct {
+	nanos atomic.Int64
+}
+
+type queryTimerKey struct{}
+
+type queryStartKey struct{}
+
+// WithQueryTimer returns a context whose queries add their duration to the returned timer
+func WithQueryTimer(ctx context.Context) (context.Context, *QueryTimer) {
+	timer := &QueryTimer{}
+	return context.WithValue(ctx, queryTimerKey{}, timer), timer
+}
+
+// Elapsed returns the total query time recorded so far
+func (t *QueryTimer) Elapsed() time.Duration {
+	return time.Duration(t.nanos.Load())
+}
+
+// queryTimingTracer records each query's duration on the QueryTimer of its context, if any.
+// For Query the duration runs until the rows are closed, so it includes reading the results.
+type queryTimingTracer struct{}
+
+func (queryTimingTracer) TraceQueryStart(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryStartData) context.Context {
+	if _, ok := ctx.Value(queryTimerKey{}).(*QueryTimer); !ok {
+		return ctx
+	}
+	return context.WithValue(ctx, queryStartKey{}, time.Now())
+}
+
+func (queryTimingTracer) TraceQueryEnd(ctx context.Context, _ *pgx.Conn, _ pgx.TraceQueryEndData) {
+	timer, ok := ctx.Value(queryTimerKey{}).(*QueryTimer)
+	if !ok {
+		return
+	}
+	if start, ok := ctx.Value(queryStartKey{}).(time.Time); ok {
+		timer.nanos.Add(int64(time.Since(start)))
+	}
+}
@@ -0,0 +1,44 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryTimingTracer_AccumulatesOnTimer(t *testing.T) {
+	// Arrange
+	tracer := queryTimingTracer{}
+	ctx, timer := WithQueryTimer(context.Background())
+
+	// Act: two queries of at least 2ms each
+	for i := 0; i < 2; i++ {
+		queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+		time.Sleep(2 * time.Millisecond)
+		tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{})
+	}
+
+	// Assert
+	assert.GreaterOrEqual(t, timer.Elapsed(), 4*time.Millisecond)
+}
+
+func TestQueryTimingTracer_WithoutTimer(t *testing.T) {
+	tracer := queryTimingTracer{}
+	ctx := context.Background()
+
+	queryCtx := tracer.TraceQueryStart(ctx, nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+	tracer.TraceQueryEnd(queryCtx, nil, pgx.TraceQueryEndData{})
+
+	assert.Equal(t, ctx, queryCtx)
+}
+
+func TestPoolConfig_InstallsQueryTimingTracer(t *testing.T) {
+	poolConfig, err := DatabaseConfig{Host: "localhost", Port: 5432}.poolConfig()
+
+	if assert.NoError(t, err) {
+		assert.IsType(t, queryTimingTracer{}, poolConfig.ConnConfig.Tracer)
+	}
+}
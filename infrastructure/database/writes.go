@@ -0,0 +1,74 @@
+package database
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// WriteTracker remembers when writes last finished and when one last committed. Times are kept
+// as Unix nanoseconds so they can be read and updated without a lock, 0 means never.
+type WriteTracker struct {
+	lastAttempt atomic.Int64
+	lastSuccess atomic.Int64
+}
+
+func NewWriteTracker() *WriteTracker {
+	return &WriteTracker{}
+}
+
+// RecordAttempt notes a write that finished at the given time without committing
+func (t *WriteTracker) RecordAttempt(at time.Time) {
+	storeLatest(&t.lastAttempt, at)
+}
+
+// RecordSuccess notes a write that committed at the given time
+func (t *WriteTracker) RecordSuccess(at time.Time) {
+	storeLatest(&t.lastAttempt, at)
+	storeLatest(&t.lastSuccess, at)
+}
+
+// LastSuccessfulWrite returns when a write last committed, ok is false when none has yet
+func (t *WriteTracker) LastSuccessfulWrite() (at time.Time, ok bool) {
+	return loadTime(&t.lastSuccess)
+}
+
+// Degraded reports whether writes were attempted within window of now but none committed in it.
+// Without any attempt in the window, such as on an idle service, writes are not degraded.
+func (t *WriteTracker) Degraded(now time.Time, window time.Duration) bool {
+	if window <= 0 {
+		return false
+	}
+	since := now.Add(-window)
+	attempt, ok := loadTime(&t.lastAttempt)
+	if !ok || attempt.Before(since) {
+		return false
+	}
+	success, ok := loadTime(&t.lastSuccess)
+	return !ok || success.Before(since)
+}
+
+// storeLatest stores at unless a later time was already stored by a concurrent write
+func storeLatest(v *atomic.Int64, at time.Time) {
+	nanos := at.UnixNano()
+	for {
+		current := v.Load()
+		if current >= nanos || v.CompareAndSwap(current, nanos) {
+			return
+		}
+	}
+}
+
+func loadTime(v *atomic.Int64) (time.Time, bool) {
+	nanos := v.Load()
+	if nanos == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(0, nanos).UTC(), true
+}
+
+var defaultWriteTracker = NewWriteTracker()
+
+// Writes returns the application wide write tracker, updated by the repositories
+func Writes() *WriteTracker {
+	return defaultWriteTracker
+}
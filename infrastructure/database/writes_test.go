@@ -0,0 +1,89 @@
+package database
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWriteTracker_RecordsLastSuccessfulWrite(t *testing.T) {
+	// Arrange
+	tracker := NewWriteTracker()
+	committed := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+
+	_, before := tracker.LastSuccessfulWrite()
+
+	// Act
+	tracker.RecordSuccess(committed)
+	tracker.RecordSuccess(committed.Add(-time.Minute))
+	tracker.RecordAttempt(committed.Add(time.Minute))
+
+	// Assert
+	assert.False(t, before)
+	at, ok := tracker.LastSuccessfulWrite()
+	assert.True(t, ok)
+	assert.Equal(t, committed, at)
+}
+
+func TestWriteTracker_Degraded(t *testing.T) {
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	window := 5 * time.Minute
+
+	cases := []struct {
+		name     string
+		record   func(*WriteTracker)
+		window   time.Duration
+		degraded bool
+	}{
+		{name: "no writes", record: func(*WriteTracker) {}, window: window},
+		{
+			name:   "recent success",
+			record: func(w *WriteTracker) { w.RecordSuccess(now.Add(-time.Minute)) },
+			window: window,
+		},
+		{
+			name:   "idle since the last success",
+			record: func(w *WriteTracker) { w.RecordSuccess(now.Add(-time.Hour)) },
+			window: window,
+		},
+		{
+			name:   "old attempt",
+			record: func(w *WriteTracker) { w.RecordAttempt(now.Add(-time.Hour)) },
+			window: window,
+		},
+		{
+			name: "failing since the last success",
+			record: func(w *WriteTracker) {
+				w.RecordSuccess(now.Add(-time.Hour))
+				w.RecordAttempt(now.Add(-time.Minute))
+			},
+			window:   window,
+			degraded: true,
+		},
+		{
+			name:     "never succeeded",
+			record:   func(w *WriteTracker) { w.RecordAttempt(now.Add(-time.Minute)) },
+			window:   window,
+			degraded: true,
+		},
+		{
+			name:   "window disabled",
+			record: func(w *WriteTracker) { w.RecordAttempt(now.Add(-time.Minute)) },
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			tracker := NewWriteTracker()
+			tc.record(tracker)
+
+			// Act
+			degraded := tracker.Degraded(now, tc.window)
+
+			// Assert
+			assert.Equal(t, tc.degraded, degraded)
+		})
+	}
+}
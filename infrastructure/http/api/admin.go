@@ -0,0 +1,128 @@
+package api
+
+import (
+	"crypto/subtle"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Testzyler/order-management-go/application/constants"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/buildinfo"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// adminToken is the bearer token guarding state-changing admin endpoints
+var adminToken atomic.Value
+
+// SetAdminToken sets the bearer token required by POST /admin/drain. An empty token disables the endpoint.
+func SetAdminToken(token string) {
+	adminToken.Store(token)
+}
+
+type AdminHandler struct {
+	db      database.DatabaseInterface
+	tracker *readiness.Tracker
+}
+
+func NewAdminHandler() *AdminHandler {
+	return &AdminHandler{tracker: readiness.Default()}
+}
+
+// Initialize implements HandlerInitializer interface
+func (h *AdminHandler) Initialize() {
+	h.db = route.GetDatabasePool()
+}
+
+// GetRouteDefinition implements HandlerInitializer interface
+func (h *AdminHandler) GetRouteDefinition() route.RouteDefinition {
+	return route.RouteDefinition{
+		Routes: route.Routes{
+			route.Route{
+				Name:        "Version",
+				Path:        "/version",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.Version,
+			},
+			route.Route{
+				Name:        "Drain",
+				Path:        "/drain",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.Drain,
+			},
+		},
+		Prefix: "admin",
+		Public: true,
+	}
+}
+
+func init() {
+	route.RegisterHandler(NewAdminHandler())
+}
+
+func (h *AdminHandler) Version(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	info := buildinfo.Get()
+	response := fiber.Map{
+		"version":        info.Version,
+		"commit":         info.Commit,
+		"schema_version": nil,
+	}
+
+	if h.db == nil {
+		requestLogger.Warn("Database pool not available for schema version")
+		return c.JSON(response)
+	}
+
+	schemaVersion, err := database.GetSchemaVersion(ctx, h.db)
+	if err != nil {
+		requestLogger.WithError(err).Error("Failed to read schema version")
+		return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+			"message": "Failed to read schema version",
+		})
+	}
+	response["schema_version"] = schemaVersion
+
+	return c.JSON(response)
+}
+
+// authorized reports whether the request carries the configured admin bearer token
+func authorized(c *fiber.Ctx) bool {
+	token, _ := adminToken.Load().(string)
+	if token == "" {
+		return false
+	}
+	provided, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+	return ok && subtle.ConstantTimeCompare([]byte(provided), []byte(token)) == 1
+}
+
+// Drain marks the service as draining so /readyz returns 503 and load balancers stop routing new
+// traffic. In-flight requests finish normally and the process keeps running until it is signalled.
+func (h *AdminHandler) Drain(c *fiber.Ctx) error {
+	requestLogger := logger.LoggerWithRequestIDFromContext(c.UserContext())
+
+	if !authorized(c) {
+		requestLogger.Warn("Unauthorized drain request", "ip", middleware.ClientIP(c))
+		return c.Status(fiber.ErrUnauthorized.Code).JSON(fiber.Map{
+			"message": "Unauthorized",
+		})
+	}
+
+	if err := h.tracker.Transition(readiness.StateDraining); err != nil {
+		requestLogger.WithError(err).Warn("Failed to mark service as draining")
+		return c.Status(fiber.ErrConflict.Code).JSON(fiber.Map{
+			"message": err.Error(),
+			"status":  h.tracker.State(),
+		})
+	}
+
+	requestLogger.Info("Service marked as draining by admin request")
+	return c.Status(fiber.StatusAccepted).JSON(fiber.Map{
+		"status": h.tracker.State(),
+	})
+}
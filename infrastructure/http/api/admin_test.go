@@ -0,0 +1,113 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// newDrainApp serves /readyz and /admin/drain backed by a tracker that is already ready
+func newDrainApp(t *testing.T) (*fiber.App, *readiness.Tracker) {
+	tracker := readiness.NewTracker()
+	assert.NoError(t, tracker.Transition(readiness.StateReady))
+
+	admin := &AdminHandler{tracker: tracker}
+	ready := &ReadinessHandler{db: &stubDatabase{}, tracker: tracker, state: readiness.NewHealthState(), now: time.Now}
+	app := fiber.New()
+	app.Get("/readyz", ready.ReadinessCheck)
+	app.Post("/admin/drain", admin.Drain)
+	return app, tracker
+}
+
+// useAdminToken sets the admin token for the duration of the test
+func useAdminToken(t *testing.T, token string) {
+	previous, _ := adminToken.Load().(string)
+	SetAdminToken(token)
+	t.Cleanup(func() { SetAdminToken(previous) })
+}
+
+func drainRequest(token string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/admin/drain", nil)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return req
+}
+
+func TestAdminHandler_Drain_FlipsReadiness(t *testing.T) {
+	// Arrange
+	useAdminToken(t, "secret")
+	app, tracker := newDrainApp(t)
+
+	before, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, before.StatusCode)
+
+	// Act
+	resp, err := app.Test(drainRequest("secret"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, readiness.StateDraining, tracker.State())
+
+	after, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, after.StatusCode)
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(after.Body).Decode(&body))
+	assert.Equal(t, "draining", body["status"])
+
+	// Draining again is a no-op
+	again, err := app.Test(drainRequest("secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusAccepted, again.StatusCode)
+}
+
+func TestAdminHandler_Drain_Unauthorized(t *testing.T) {
+	tests := []struct {
+		name       string
+		configured string
+		provided   string
+	}{
+		{name: "missing token", configured: "secret"},
+		{name: "wrong token", configured: "secret", provided: "guess"},
+		{name: "endpoint disabled", configured: "", provided: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			useAdminToken(t, tt.configured)
+			app, tracker := newDrainApp(t)
+
+			// Act
+			resp, err := app.Test(drainRequest(tt.provided))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			assert.Equal(t, readiness.StateReady, tracker.State())
+		})
+	}
+}
+
+func TestAdminHandler_Drain_AfterStop(t *testing.T) {
+	// Arrange
+	useAdminToken(t, "secret")
+	app, tracker := newDrainApp(t)
+	assert.NoError(t, tracker.Transition(readiness.StateStopped))
+
+	// Act
+	resp, err := app.Test(drainRequest("secret"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
@@ -12,7 +12,7 @@ func AddRoute(router *fiber.Router) {
 	v1.AddRoute(&v1Route)
 }
 
-// Add root level routes (no prefix)
-func AddRootRoutes(router *fiber.Router) {
-	route.AddRoutesPrefix(router)
+// Add root level routes (no prefix). Routes that are not public run the protect handlers first.
+func AddRootRoutes(router *fiber.Router, protect ...fiber.Handler) {
+	route.AddRootRoutes(router, protect...)
 }
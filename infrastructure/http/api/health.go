@@ -1,22 +1,51 @@
 package api
 
 import (
+	"sync/atomic"
+	"time"
+
 	"github.com/Testzyler/order-management-go/application/constants"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	"github.com/gofiber/fiber/v2"
 )
 
-type HealthHandler struct{}
+// DefaultWriteStaleWindow is how long writes may keep failing before the detailed health check
+// reports degraded
+const DefaultWriteStaleWindow = 5 * time.Minute
+
+// writeStaleWindow is the configured window, 0 until SetWriteStaleWindow is called
+var writeStaleWindow atomic.Int64
+
+// SetWriteStaleWindow sets how long writes may be attempted without one committing before
+// GET /healthz?detailed=true reports degraded. 0 or less falls back to DefaultWriteStaleWindow.
+func SetWriteStaleWindow(window time.Duration) {
+	writeStaleWindow.Store(int64(window))
+}
+
+func currentWriteStaleWindow() time.Duration {
+	if window := time.Duration(writeStaleWindow.Load()); window > 0 {
+		return window
+	}
+	return DefaultWriteStaleWindow
+}
+
+// HealthHandler serves the liveness probe. It only confirms that the process is up and serving,
+// so a database outage removes the instance from the load balancer through /readyz instead of
+// restarting it.
+type HealthHandler struct {
+	writes *database.WriteTracker
+	state  *readiness.HealthState
+}
 
 func NewHealthHandler() *HealthHandler {
-	return &HealthHandler{}
+	return &HealthHandler{writes: database.Writes(), state: readiness.DefaultHealthState()}
 }
 
 // Initialize implements HandlerInitializer interface
-func (h *HealthHandler) Initialize() {
-	// No initialization needed for health check
-}
+func (h *HealthHandler) Initialize() {}
 
 // GetRouteDefinition implements HandlerInitializer interface
 func (h *HealthHandler) GetRouteDefinition() route.RouteDefinition {
@@ -30,6 +59,7 @@ func (h *HealthHandler) GetRouteDefinition() route.RouteDefinition {
 			},
 		},
 		Prefix: "",
+		Public: true,
 	}
 }
 
@@ -37,6 +67,8 @@ func init() {
 	route.RegisterHandler(NewHealthHandler())
 }
 
+// HealthCheck is the liveness probe and does not touch the database. With ?detailed=true it
+// reports when the database was last reached and when a write last committed.
 func (h *HealthHandler) HealthCheck(c *fiber.Ctx) error {
 	// Get logger with request ID from context
 	requestLogger := logger.LoggerWithRequestIDFromContext(c.Context())
@@ -47,7 +79,42 @@ func (h *HealthHandler) HealthCheck(c *fiber.Ctx) error {
 		"status":  "OK",
 		"message": "Service is healthy",
 	}
+	if c.QueryBool("detailed") {
+		h.addPingHealth(response)
+		h.addWriteHealth(response, time.Now())
+	}
 
 	requestLogger.Info("Health check completed successfully")
 	return c.JSON(response)
 }
+
+// addPingHealth adds when the readiness probe last reached the database to the health response
+func (h *HealthHandler) addPingHealth(response fiber.Map) {
+	if h.state == nil {
+		return
+	}
+
+	response["last_successful_ping"] = nil
+	if at, ok := h.state.LastSuccessfulPing(); ok {
+		response["last_successful_ping"] = at.Format(time.RFC3339Nano)
+	}
+}
+
+// addWriteHealth adds when a write last committed to the health response, and marks the service
+// degraded when writes were attempted within the window but none of them committed
+func (h *HealthHandler) addWriteHealth(response fiber.Map, now time.Time) {
+	if h.writes == nil {
+		return
+	}
+
+	response["last_successful_write"] = nil
+	if at, ok := h.writes.LastSuccessfulWrite(); ok {
+		response["last_successful_write"] = at.Format(time.RFC3339Nano)
+	}
+
+	window := currentWriteStaleWindow()
+	if h.writes.Degraded(now, window) {
+		response["status"] = "degraded"
+		response["message"] = "No database write has succeeded in the last " + window.String()
+	}
+}
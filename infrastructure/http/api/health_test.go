@@ -0,0 +1,116 @@
+package api
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newHealthApp(writes *database.WriteTracker) *fiber.App {
+	health := &HealthHandler{writes: writes}
+	app := fiber.New()
+	app.Get("/healthz", health.HealthCheck)
+	return app
+}
+
+func decodeJSON(t *testing.T, resp *http.Response) map[string]any {
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestHealthHandler_HealthCheck_Detailed(t *testing.T) {
+	committed := time.Now().Add(-time.Second).UTC()
+
+	cases := []struct {
+		name       string
+		record     func(*database.WriteTracker)
+		wantStatus string
+		wantWrite  any
+	}{
+		{
+			name:       "no writes yet",
+			record:     func(*database.WriteTracker) {},
+			wantStatus: "OK",
+			wantWrite:  nil,
+		},
+		{
+			name:       "write committed",
+			record:     func(w *database.WriteTracker) { w.RecordSuccess(committed) },
+			wantStatus: "OK",
+			wantWrite:  committed.Format(time.RFC3339Nano),
+		},
+		{
+			name: "writes failing since the last commit",
+			record: func(w *database.WriteTracker) {
+				w.RecordSuccess(committed.Add(-time.Hour))
+				w.RecordAttempt(committed)
+			},
+			wantStatus: "degraded",
+			wantWrite:  committed.Add(-time.Hour).Format(time.RFC3339Nano),
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			writes := database.NewWriteTracker()
+			tc.record(writes)
+			app := newHealthApp(writes)
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz?detailed=true", nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			body := decodeJSON(t, resp)
+			assert.Equal(t, tc.wantStatus, body["status"])
+			assert.Contains(t, body, "last_successful_write")
+			assert.Equal(t, tc.wantWrite, body["last_successful_write"])
+		})
+	}
+}
+
+func TestHealthHandler_HealthCheck_NotDetailedByDefault(t *testing.T) {
+	// Arrange
+	writes := database.NewWriteTracker()
+	writes.RecordAttempt(time.Now())
+	app := newHealthApp(writes)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{"status": "OK", "message": "Service is healthy"}, decodeJSON(t, resp))
+}
+
+func TestHealthHandler_HealthCheck_DetailedReportsLastPing(t *testing.T) {
+	// Arrange
+	pinged := time.Now().Add(-time.Second).UTC()
+	state := readiness.NewHealthState()
+	health := &HealthHandler{state: state}
+	app := fiber.New()
+	app.Get("/healthz", health.HealthCheck)
+
+	// Act
+	before, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz?detailed=true", nil))
+	assert.NoError(t, err)
+	state.RecordPing(pinged)
+	after, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz?detailed=true", nil))
+	assert.NoError(t, err)
+
+	// Assert
+	beforeBody := decodeJSON(t, before)
+	assert.Contains(t, beforeBody, "last_successful_ping")
+	assert.Nil(t, beforeBody["last_successful_ping"])
+	assert.Equal(t, pinged.Format(time.RFC3339Nano), decodeJSON(t, after)["last_successful_ping"])
+}
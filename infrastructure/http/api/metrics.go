@@ -0,0 +1,54 @@
+package api
+
+import (
+	"github.com/Testzyler/order-management-go/application/constants"
+	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/adaptor"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// MetricsHandler exposes the Prometheus metrics for scraping
+type MetricsHandler struct {
+	handler fiber.Handler
+}
+
+func NewMetricsHandler() *MetricsHandler {
+	return &MetricsHandler{
+		handler: adaptor.HTTPHandler(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})),
+	}
+}
+
+// Initialize implements HandlerInitializer interface. The pool gauges are only exported when
+// the database pool exposes pgxpool statistics.
+func (h *MetricsHandler) Initialize() {
+	if pool, ok := route.GetDatabasePool().(metrics.PoolStatter); ok {
+		metrics.SetPool(pool)
+	}
+}
+
+// GetRouteDefinition implements HandlerInitializer interface
+func (h *MetricsHandler) GetRouteDefinition() route.RouteDefinition {
+	return route.RouteDefinition{
+		Routes: route.Routes{
+			route.Route{
+				Name:        "Metrics",
+				Path:        "/metrics",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.Metrics,
+			},
+		},
+		Prefix: "",
+		Public: true,
+	}
+}
+
+func init() {
+	route.RegisterHandler(NewMetricsHandler())
+}
+
+// Metrics serves the registered metrics in the Prometheus text format
+func (h *MetricsHandler) Metrics(c *fiber.Ctx) error {
+	return h.handler(c)
+}
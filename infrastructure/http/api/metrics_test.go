@@ -0,0 +1,34 @@
+package api
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/metrics"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsHandler_ServesPrometheusFormat(t *testing.T) {
+	// Arrange
+	handler := NewMetricsHandler()
+	app := fiber.New()
+	app.Get("/metrics", handler.Metrics)
+	metrics.ObserveRequest(http.MethodGet, "/api/v1/orders/:id", http.StatusOK, 10*time.Millisecond)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, resp.Header.Get(fiber.HeaderContentType), "text/plain")
+	body, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(body), `order_management_http_requests_total{method="GET",path="/api/v1/orders/:id",status="200"}`)
+	assert.Contains(t, string(body), "order_management_http_request_duration_seconds_bucket")
+	assert.Contains(t, string(body), "go_goroutines")
+}
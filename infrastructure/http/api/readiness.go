@@ -0,0 +1,149 @@
+package api
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/constants"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultSaturationGrace is how long the pool may have no connection available before /readyz
+// reports not ready
+const DefaultSaturationGrace = 5 * time.Second
+
+// saturationGrace is the configured grace period, 0 until SetSaturationGrace is called
+var saturationGrace atomic.Int64
+
+// SetSaturationGrace sets how long the pool may stay saturated before GET /readyz returns 503.
+// 0 or less falls back to DefaultSaturationGrace.
+func SetSaturationGrace(grace time.Duration) {
+	saturationGrace.Store(int64(grace))
+}
+
+func currentSaturationGrace() time.Duration {
+	if grace := time.Duration(saturationGrace.Load()); grace > 0 {
+		return grace
+	}
+	return DefaultSaturationGrace
+}
+
+// ReadinessHandler serves the readiness probe, which takes the instance out of the load balancer
+// while it is warming up, draining, or cannot use the database
+type ReadinessHandler struct {
+	db      database.DatabaseInterface
+	tracker *readiness.Tracker
+	state   *readiness.HealthState
+	// handlersReady reports whether the HTTP handlers were initialized, nil skips the check
+	handlersReady func() bool
+	// availableConns reports the connections the pool can hand out without waiting, nil or
+	// ok false skips the saturation check
+	availableConns func() (available int, ok bool)
+	now            func() time.Time
+}
+
+func NewReadinessHandler() *ReadinessHandler {
+	return &ReadinessHandler{
+		tracker:       readiness.Default(),
+		state:         readiness.DefaultHealthState(),
+		handlersReady: route.HandlersInitialized,
+		now:           time.Now,
+	}
+}
+
+// Initialize implements HandlerInitializer interface
+func (h *ReadinessHandler) Initialize() {
+	h.db = route.GetDatabasePool()
+	h.availableConns = func() (int, bool) { return database.AvailableConns(h.db) }
+}
+
+// GetRouteDefinition implements HandlerInitializer interface
+func (h *ReadinessHandler) GetRouteDefinition() route.RouteDefinition {
+	return route.RouteDefinition{
+		Routes: route.Routes{
+			route.Route{
+				Name:        "ReadinessCheck",
+				Path:        "/readyz",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.ReadinessCheck,
+			},
+		},
+		Prefix: "",
+		Public: true,
+	}
+}
+
+func init() {
+	route.RegisterHandler(NewReadinessHandler())
+}
+
+// ReadinessCheck reports 200 only once warm-up is complete and until draining starts, while the
+// handlers are initialized and the database health-check query succeeds. A pool with no
+// connection available is tolerated for the saturation grace period; within it the query is
+// skipped, since it would only queue behind the requests holding the connections.
+func (h *ReadinessHandler) ReadinessCheck(c *fiber.Ctx) error {
+	requestLogger := logger.LoggerWithRequestIDFromContext(c.UserContext())
+
+	state := h.tracker.State()
+	if state != readiness.StateReady {
+		requestLogger.Debug("Readiness check failed", "state", state)
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status": state,
+		})
+	}
+
+	if h.handlersReady != nil && !h.handlersReady() {
+		requestLogger.Warn("Readiness check failed, handlers not initialized")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status":   state,
+			"handlers": "uninitialized",
+		})
+	}
+
+	if h.db == nil {
+		requestLogger.Warn("Readiness check failed, database pool not initialized")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status":   state,
+			"database": "unavailable",
+		})
+	}
+
+	now := h.now()
+	if h.availableConns != nil {
+		if available, ok := h.availableConns(); ok {
+			grace := currentSaturationGrace()
+			if h.state.ObservePool(now, available > 0, grace) {
+				requestLogger.Warn("Readiness check failed, database pool saturated", "grace", grace.String())
+				return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+					"status":   state,
+					"database": "saturated",
+				})
+			}
+			if available == 0 {
+				return c.JSON(fiber.Map{
+					"status":   state,
+					"database": "saturated",
+				})
+			}
+		}
+	}
+
+	// A pool that connects but cannot run the health-check query is not ready
+	if err := database.HealthCheck(c.UserContext(), h.db, database.HealthCheckQuery()); err != nil {
+		requestLogger.WithError(err).Warn("Readiness check failed, database unhealthy")
+		return c.Status(fiber.StatusServiceUnavailable).JSON(fiber.Map{
+			"status":   state,
+			"database": "unhealthy",
+		})
+	}
+	h.state.RecordPing(now)
+
+	return c.JSON(fiber.Map{
+		"status":   state,
+		"database": "up",
+	})
+}
@@ -0,0 +1,143 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/Testzyler/order-management-go/infrastructure/readiness"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubDatabase answers Exec with execErr; other methods panic
+type stubDatabase struct {
+	database.DatabaseInterface
+	execErr error
+}
+
+func (s *stubDatabase) Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error) {
+	return pgconn.CommandTag{}, s.execErr
+}
+
+// newReadyHandler returns a handler whose tracker is already ready, reading the time from now
+func newReadyHandler(t *testing.T, db database.DatabaseInterface, now *time.Time) *ReadinessHandler {
+	tracker := readiness.NewTracker()
+	assert.NoError(t, tracker.Transition(readiness.StateReady))
+	return &ReadinessHandler{
+		db:      db,
+		tracker: tracker,
+		state:   readiness.NewHealthState(),
+		now:     func() time.Time { return *now },
+	}
+}
+
+func checkReady(t *testing.T, handler *ReadinessHandler) (int, map[string]any) {
+	app := fiber.New()
+	app.Get("/readyz", handler.ReadinessCheck)
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil))
+	assert.NoError(t, err)
+	return resp.StatusCode, decodeJSON(t, resp)
+}
+
+func TestReadinessHandler_ReadyRecordsPing(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := newReadyHandler(t, &stubDatabase{}, &now)
+
+	// Act
+	status, body := checkReady(t, handler)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, map[string]any{"status": "ready", "database": "up"}, body)
+	at, ok := handler.state.LastSuccessfulPing()
+	assert.True(t, ok)
+	assert.Equal(t, now, at)
+}
+
+func TestReadinessHandler_DatabaseNotReady(t *testing.T) {
+	cases := []struct {
+		name         string
+		db           database.DatabaseInterface
+		wantDatabase string
+	}{
+		{name: "pool not initialized", db: nil, wantDatabase: "unavailable"},
+		{name: "health check fails", db: &stubDatabase{execErr: errors.New("connection refused")}, wantDatabase: "unhealthy"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+			handler := newReadyHandler(t, tc.db, &now)
+
+			// Act
+			status, body := checkReady(t, handler)
+
+			// Assert
+			assert.Equal(t, http.StatusServiceUnavailable, status)
+			assert.Equal(t, tc.wantDatabase, body["database"])
+			_, pinged := handler.state.LastSuccessfulPing()
+			assert.False(t, pinged)
+		})
+	}
+}
+
+func TestReadinessHandler_HandlersNotInitialized(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := newReadyHandler(t, &stubDatabase{}, &now)
+	initialized := false
+	handler.handlersReady = func() bool { return initialized }
+
+	// Act
+	beforeStatus, beforeBody := checkReady(t, handler)
+	initialized = true
+	afterStatus, _ := checkReady(t, handler)
+
+	// Assert
+	assert.Equal(t, http.StatusServiceUnavailable, beforeStatus)
+	assert.Equal(t, map[string]any{"status": "ready", "handlers": "uninitialized"}, beforeBody)
+	assert.Equal(t, http.StatusOK, afterStatus)
+}
+
+func TestReadinessHandler_PoolSaturationTransitions(t *testing.T) {
+	// Arrange
+	SetSaturationGrace(5 * time.Second)
+	t.Cleanup(func() { SetSaturationGrace(0) })
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	handler := newReadyHandler(t, &stubDatabase{}, &now)
+	available := 0
+	handler.availableConns = func() (int, bool) { return available, true }
+
+	// Act & Assert: saturated within the grace period stays ready
+	status, body := checkReady(t, handler)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "saturated", body["database"])
+
+	// Saturated past the grace period is not ready
+	now = now.Add(6 * time.Second)
+	status, body = checkReady(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+	assert.Equal(t, "saturated", body["database"])
+
+	// A free connection makes it ready again
+	available = 3
+	now = now.Add(time.Second)
+	status, body = checkReady(t, handler)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "up", body["database"])
+
+	// A new saturation gets the full grace period again
+	available = 0
+	now = now.Add(time.Second)
+	status, _ = checkReady(t, handler)
+	assert.Equal(t, http.StatusOK, status)
+}
@@ -1,6 +1,13 @@
 package route
 
 import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync/atomic"
+
 	"github.com/Testzyler/order-management-go/application/constants"
 	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +25,8 @@ type Route struct {
 type RouteDefinition struct {
 	Routes Routes
 	Prefix string
+	// Public routes, such as health checks and metrics, are served at the root without authentication
+	Public bool
 }
 
 var RouteDefinitions = make([]RouteDefinition, 0)
@@ -42,38 +51,185 @@ func RegisterHandler(handler HandlerInitializer) {
 	registry.handlers = append(registry.handlers, handler)
 }
 
+// ErrDuplicateRoute is returned when two handlers register the same method and path
+var ErrDuplicateRoute = errors.New("duplicate route")
+
+// ErrUnknownHandler is returned when the enabled list names a handler that is not registered
+var ErrUnknownHandler = errors.New("unknown handler")
+
+// enabledHandlers restricts InitializeAllHandlers to the named handlers; nil enables all of them
+var enabledHandlers map[string]bool
+
+// SetEnabledHandlers limits the served routes to the named handlers, e.g. "health" and "order"
+// for HealthHandler and OrderHandler. An empty list enables every registered handler.
+func SetEnabledHandlers(names []string) {
+	if len(names) == 0 {
+		enabledHandlers = nil
+		return
+	}
+	enabledHandlers = make(map[string]bool, len(names))
+	for _, name := range names {
+		enabledHandlers[strings.ToLower(strings.TrimSpace(name))] = true
+	}
+}
+
+// HandlerName is the name used to enable a handler: its type name without the Handler suffix, lowercased
+func HandlerName(handler HandlerInitializer) string {
+	handlerType := reflect.TypeOf(handler)
+	if handlerType.Kind() == reflect.Pointer {
+		handlerType = handlerType.Elem()
+	}
+	name := strings.TrimSuffix(handlerType.Name(), "Handler")
+	return strings.ToLower(strings.TrimSuffix(name, "handler"))
+}
+
+// handlersInitialized is set once InitializeAllHandlers has completed without error
+var handlersInitialized atomic.Bool
+
+// HandlersInitialized reports whether InitializeAllHandlers has completed without error
+func HandlersInitialized() bool {
+	return handlersInitialized.Load()
+}
+
 // InitializeAllHandlers initializes all registered handlers
-// This should be called after the database connection is established
-func InitializeAllHandlers() {
+// This should be called after the database connection is established.
+// Handlers left out of SetEnabledHandlers are skipped. It fails when two routes resolve
+// to the same method and path or when an enabled handler is not registered.
+func InitializeAllHandlers() error {
 	// Clear existing route definitions
 	RouteDefinitions = make([]RouteDefinition, 0)
+	handlersInitialized.Store(false)
+
+	if err := checkEnabledHandlers(); err != nil {
+		return err
+	}
+
+	// registered maps "METHOD /prefix/path" to the handler and route that claimed it
+	registered := make(map[string]string)
 
 	// Initialize all registered handlers
 	for _, handler := range registry.handlers {
+		if enabledHandlers != nil && !enabledHandlers[HandlerName(handler)] {
+			continue
+		}
 		handler.Initialize()
 		routeDefinition := handler.GetRouteDefinition()
+
+		for _, route := range routeDefinition.Routes {
+			owner := fmt.Sprintf("%T.%s", handler, route.Name)
+			for _, method := range routeMethods(route.Method) {
+				key := routeKey(method, routeDefinition.Prefix, route.Path)
+				if existing, ok := registered[key]; ok {
+					return fmt.Errorf("%w %s: registered by %s and %s", ErrDuplicateRoute, key, existing, owner)
+				}
+				registered[key] = owner
+			}
+		}
+
 		RouteDefinitions = append(RouteDefinitions, routeDefinition)
 	}
+	handlersInitialized.Store(true)
+	return nil
+}
+
+// checkEnabledHandlers reports enabled names that match no registered handler, catching typos
+// that would otherwise silently drop routes
+func checkEnabledHandlers() error {
+	known := make(map[string]bool, len(registry.handlers))
+	for _, handler := range registry.handlers {
+		known[HandlerName(handler)] = true
+	}
+
+	var unknown []string
+	for name := range enabledHandlers {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	if len(unknown) > 0 {
+		sort.Strings(unknown)
+		return fmt.Errorf("%w: %s", ErrUnknownHandler, strings.Join(unknown, ", "))
+	}
+	return nil
+}
+
+// routeMethods returns the methods a route is served on; METHOD_ALL claims every method
+// so it collides with any other route on the same path
+func routeMethods(method string) []string {
+	if strings.EqualFold(method, constants.METHOD_ALL) {
+		return []string{constants.METHOD_GET, constants.METHOD_POST, constants.METHOD_PUT, constants.METHOD_DELETE, constants.METHOD_PATCH}
+	}
+	return []string{method}
+}
+
+// routeKey normalizes a route so that paths Fiber would treat as equal collide,
+// e.g. trailing slashes and differently named parameters
+func routeKey(method, prefix, path string) string {
+	var segments []string
+	for _, part := range []string{prefix, path} {
+		for _, segment := range strings.Split(strings.Trim(part, "/"), "/") {
+			if segment == "" {
+				continue
+			}
+			if strings.HasPrefix(segment, ":") {
+				segment = ":"
+			}
+			segments = append(segments, segment)
+		}
+	}
+	return strings.ToUpper(method) + " /" + strings.Join(segments, "/")
 }
 
 func GetDatabasePool() database.DatabaseInterface {
 	return database.DatabasePool
 }
 
+// AddRoutesPrefix registers every route under its definition's prefix. Each fiber route is
+// named after Route.Name so middleware can read it from c.Route().Name.
 func AddRoutesPrefix(router *fiber.Router) fiber.Router {
 	for _, routeDefinition := range RouteDefinitions {
-		routerWithPrefix := (*router).Group(routeDefinition.Prefix)
-		for _, route := range routeDefinition.Routes {
-			if route.Method == constants.METHOD_GET {
-				routerWithPrefix.Get(route.Path, route.HandlerFunc)
-			} else if route.Method == constants.METHOD_POST {
-				routerWithPrefix.Post(route.Path, route.HandlerFunc)
-			} else if route.Method == constants.METHOD_DELETE {
-				routerWithPrefix.Delete(route.Path, route.HandlerFunc)
-			} else if route.Method == constants.METHOD_PUT {
-				routerWithPrefix.Put(route.Path, route.HandlerFunc)
-			}
+		addRoutes(*router, routeDefinition)
+	}
+	return *router
+}
+
+// AddRootRoutes registers the public routes as they are, and every other route behind the protect
+// handlers, such as authentication, so no route is reachable at the root without the checks it
+// gets under /api.
+func AddRootRoutes(router *fiber.Router, protect ...fiber.Handler) fiber.Router {
+	for _, routeDefinition := range RouteDefinitions {
+		if routeDefinition.Public {
+			addRoutes(*router, routeDefinition)
+			continue
 		}
+		addRoutes(*router, routeDefinition, protect...)
 	}
 	return *router
 }
+
+// addRoutes registers the routes of one definition, running middleware before each handler.
+// The middleware is attached per route rather than to a group so it never runs for other routes
+// sharing the prefix.
+func addRoutes(router fiber.Router, routeDefinition RouteDefinition, middleware ...fiber.Handler) {
+	routerWithPrefix := router.Group(routeDefinition.Prefix)
+	for _, route := range routeDefinition.Routes {
+		handlers := append(append([]fiber.Handler{}, middleware...), fiber.Handler(route.HandlerFunc))
+		var registered fiber.Router
+		if route.Method == constants.METHOD_GET {
+			registered = routerWithPrefix.Get(route.Path, handlers...)
+		} else if route.Method == constants.METHOD_POST {
+			registered = routerWithPrefix.Post(route.Path, handlers...)
+		} else if route.Method == constants.METHOD_DELETE {
+			registered = routerWithPrefix.Delete(route.Path, handlers...)
+		} else if route.Method == constants.METHOD_PUT {
+			registered = routerWithPrefix.Put(route.Path, handlers...)
+		} else if route.Method == constants.METHOD_PATCH {
+			registered = routerWithPrefix.Patch(route.Path, handlers...)
+		} else if route.Method == constants.METHOD_ALL {
+			registered = routerWithPrefix.All(route.Path, handlers...)
+		}
+		if registered != nil && route.Name != "" {
+			registered.Name(route.Name)
+		}
+	}
+}
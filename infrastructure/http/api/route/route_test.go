@@ -0,0 +1,246 @@
+package route
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/constants"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+type ordersHandler struct{}
+
+func (h *ordersHandler) Initialize() {}
+
+func (h *ordersHandler) GetRouteDefinition() RouteDefinition {
+	return RouteDefinition{
+		Routes: Routes{
+			Route{Name: "GetOrder", Path: "/:id", Method: constants.METHOD_GET, HandlerFunc: noopHandler},
+			Route{Name: "DeleteOrder", Path: "/:id", Method: constants.METHOD_DELETE, HandlerFunc: noopHandler},
+		},
+		Prefix: "orders",
+	}
+}
+
+type legacyOrdersHandler struct{}
+
+func (h *legacyOrdersHandler) Initialize() {}
+
+func (h *legacyOrdersHandler) GetRouteDefinition() RouteDefinition {
+	return RouteDefinition{
+		Routes: Routes{
+			Route{Name: "FetchOrder", Path: "/:orderId/", Method: constants.METHOD_GET, HandlerFunc: noopHandler},
+		},
+		Prefix: "/orders",
+	}
+}
+
+func noopHandler(c *fiber.Ctx) error { return nil }
+
+// useRegistry swaps the global registry for the duration of the test
+func useRegistry(t *testing.T, handlers ...HandlerInitializer) {
+	previous := registry
+	registry = &HandlerRegistry{handlers: handlers}
+	t.Cleanup(func() { registry = previous })
+}
+
+func TestInitializeAllHandlers_NoDuplicates(t *testing.T) {
+	// Arrange
+	useRegistry(t, &ordersHandler{})
+
+	// Act
+	err := InitializeAllHandlers()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, RouteDefinitions, 1)
+	assert.True(t, HandlersInitialized())
+}
+
+func TestInitializeAllHandlers_DuplicateRoute(t *testing.T) {
+	// Arrange
+	useRegistry(t, &ordersHandler{}, &legacyOrdersHandler{})
+
+	// Act
+	err := InitializeAllHandlers()
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDuplicateRoute)
+	assert.Contains(t, err.Error(), "GET /orders/:")
+	assert.Contains(t, err.Error(), "*route.ordersHandler.GetOrder")
+	assert.Contains(t, err.Error(), "*route.legacyOrdersHandler.FetchOrder")
+	assert.False(t, HandlersInitialized())
+}
+
+func TestRouteKey(t *testing.T) {
+	assert.Equal(t, "GET /orders", routeKey("GET", "orders", "/"))
+	assert.Equal(t, "GET /orders/:", routeKey("get", "/orders/", "/:id"))
+	assert.Equal(t, "GET /healthz", routeKey("GET", "", "/healthz"))
+}
+
+func TestAddRoutesPrefix_NamesRoutes(t *testing.T) {
+	// Arrange
+	useRegistry(t, &ordersHandler{})
+	assert.NoError(t, InitializeAllHandlers())
+
+	app := fiber.New()
+	var router fiber.Router = app.Group("/api/v1")
+
+	// Act
+	AddRoutesPrefix(&router)
+
+	// Assert
+	getOrder := app.GetRoute("GetOrder")
+	assert.Equal(t, fiber.MethodGet, getOrder.Method)
+	assert.Equal(t, "/api/v1/orders/:id", getOrder.Path)
+
+	deleteOrder := app.GetRoute("DeleteOrder")
+	assert.Equal(t, fiber.MethodDelete, deleteOrder.Method)
+	assert.Equal(t, "/api/v1/orders/:id", deleteOrder.Path)
+}
+
+type webhookHandler struct{}
+
+func (h *webhookHandler) Initialize() {}
+
+func (h *webhookHandler) GetRouteDefinition() RouteDefinition {
+	return RouteDefinition{
+		Routes: Routes{
+			Route{Name: "PatchOrder", Path: "/:id", Method: constants.METHOD_PATCH, HandlerFunc: func(c *fiber.Ctx) error { return c.SendString("patched") }},
+			Route{Name: "Webhook", Path: "/webhook", Method: constants.METHOD_ALL, HandlerFunc: func(c *fiber.Ctx) error { return c.SendString(c.Method()) }},
+		},
+		Prefix: "orders",
+	}
+}
+
+func TestAddRoutesPrefix_PatchAndAllRoutes(t *testing.T) {
+	// Arrange
+	useRegistry(t, &webhookHandler{})
+	assert.NoError(t, InitializeAllHandlers())
+
+	app := fiber.New()
+	var router fiber.Router = app.Group("/api/v1")
+	AddRoutesPrefix(&router)
+
+	// Act
+	patchResp, err := app.Test(httptest.NewRequest(http.MethodPatch, "/api/v1/orders/7", nil))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, patchResp.StatusCode)
+	patchBody, _ := io.ReadAll(patchResp.Body)
+	assert.Equal(t, "patched", string(patchBody))
+
+	for _, method := range []string{http.MethodGet, http.MethodPost, http.MethodPut, http.MethodDelete, http.MethodPatch} {
+		resp, err := app.Test(httptest.NewRequest(method, "/api/v1/orders/webhook", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode, method)
+	}
+}
+
+type catchAllOrdersHandler struct{}
+
+func (h *catchAllOrdersHandler) Initialize() {}
+
+func (h *catchAllOrdersHandler) GetRouteDefinition() RouteDefinition {
+	return RouteDefinition{
+		Routes: Routes{
+			Route{Name: "Proxy", Path: "/:orderId", Method: constants.METHOD_ALL, HandlerFunc: noopHandler},
+		},
+		Prefix: "orders",
+	}
+}
+
+func TestInitializeAllHandlers_AllCollidesWithEveryMethod(t *testing.T) {
+	// Arrange
+	useRegistry(t, &ordersHandler{}, &catchAllOrdersHandler{})
+
+	// Act
+	err := InitializeAllHandlers()
+
+	// Assert
+	assert.ErrorIs(t, err, ErrDuplicateRoute)
+	assert.Contains(t, err.Error(), "*route.catchAllOrdersHandler.Proxy")
+}
+
+type healthHandler struct{ initialized bool }
+
+func (h *healthHandler) Initialize() { h.initialized = true }
+
+func (h *healthHandler) GetRouteDefinition() RouteDefinition {
+	return RouteDefinition{
+		Routes: Routes{
+			Route{Name: "HealthCheck", Path: "/healthz", Method: constants.METHOD_GET, HandlerFunc: func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }},
+		},
+		Prefix: "",
+	}
+}
+
+// useEnabledHandlers restricts the enabled handlers for the duration of the test
+func useEnabledHandlers(t *testing.T, names ...string) {
+	previous := enabledHandlers
+	SetEnabledHandlers(names)
+	t.Cleanup(func() { enabledHandlers = previous })
+}
+
+func TestHandlerName(t *testing.T) {
+	assert.Equal(t, "health", HandlerName(&healthHandler{}))
+	assert.Equal(t, "orders", HandlerName(&ordersHandler{}))
+	assert.Equal(t, "legacyorders", HandlerName(&legacyOrdersHandler{}))
+}
+
+func TestInitializeAllHandlers_OnlyHealthEnabled(t *testing.T) {
+	// Arrange
+	health := &healthHandler{}
+	orders := &ordersHandler{}
+	useRegistry(t, health, orders)
+	useEnabledHandlers(t, " Health ")
+
+	// Act
+	err := InitializeAllHandlers()
+	app := fiber.New()
+	var router fiber.Router = app.Group("")
+	AddRoutesPrefix(&router)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.True(t, health.initialized)
+	assert.Len(t, RouteDefinitions, 1)
+
+	healthResp, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, healthResp.StatusCode)
+
+	orderResp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, orderResp.StatusCode)
+}
+
+func TestInitializeAllHandlers_AllEnabledByDefault(t *testing.T) {
+	// Arrange
+	useRegistry(t, &healthHandler{}, &ordersHandler{})
+	useEnabledHandlers(t)
+
+	// Act
+	err := InitializeAllHandlers()
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Len(t, RouteDefinitions, 2)
+}
+
+func TestInitializeAllHandlers_UnknownEnabledHandler(t *testing.T) {
+	// Arrange
+	useRegistry(t, &healthHandler{})
+	useEnabledHandlers(t, "health", "ordr")
+
+	// Act
+	err := InitializeAllHandlers()
+
+	// Assert
+	assert.ErrorIs(t, err, ErrUnknownHandler)
+	assert.Contains(t, err.Error(), "ordr")
+}
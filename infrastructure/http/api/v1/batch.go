@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBatchOrders caps how many orders one batch request may create
+const maxBatchOrders = 100
+
+type createOrdersRequest struct {
+	Orders []models.CreateOrderInput `json:"orders"`
+}
+
+// CreateOrders creates a batch of orders. By default valid orders are created and invalid ones
+// are listed per index; with ?atomic=true any invalid order fails the batch with 422 and nothing
+// is created.
+func (h *OrderHandler) CreateOrders(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	var request createOrdersRequest
+	if err := c.BodyParser(&request); err != nil {
+		requestLogger.WithError(err).Error("Failed to parse batch request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	if len(request.Orders) == 0 || len(request.Orders) > maxBatchOrders {
+		requestLogger.Warn("Invalid batch size", "orders", len(request.Orders))
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": fmt.Sprintf("orders must contain between 1 and %d orders", maxBatchOrders),
+		})
+	}
+	atomic := c.QueryBool("atomic")
+
+	result, err := h.service.CreateOrders(ctx, request.Orders, atomic)
+	if err != nil {
+		if errors.Is(err, domain.ErrBatchInvalid) {
+			requestLogger.WithError(err).Warn("Atomic batch rejected")
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+				"errors":  result.Errors,
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, batch not created")
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to create order batch", "orders", len(request.Orders))
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	requestLogger.Info("Order batch processed", "orders", len(request.Orders), "created", len(result.Created), "failed", len(result.Errors), "atomic", atomic)
+	if len(result.Created) == 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "No orders created",
+			"data":    result,
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": fmt.Sprintf("%d of %d orders created", len(result.Created), len(request.Orders)),
+		"data":    result,
+	})
+}
@@ -0,0 +1,119 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mixedBatchBody = `{"orders":[
+	{"customer_name":"Alice","items":[{"product_name":"Widget","quantity":1,"price":10}]},
+	{"customer_name":"","items":[{"product_name":"Widget","quantity":1,"price":10}]}
+]}`
+
+func newBatchApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders/batch", handler.CreateOrders)
+	return app
+}
+
+func batchRequest(query, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/orders/batch"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+type batchResponse struct {
+	Message string                   `json:"message"`
+	Errors  []models.BatchOrderError `json:"errors"`
+	Data    models.BatchCreateResult `json:"data"`
+}
+
+func TestOrderHandler_CreateOrders_BestEffort(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBatchApp(mockService)
+
+	result := models.BatchCreateResult{
+		Created: []models.OrderWithItems{{Order: models.Order{ID: 1, CustomerName: "Alice"}}},
+		Errors:  []models.BatchOrderError{{Index: 1, Message: "customer name is required"}},
+	}
+	mockService.On("CreateOrders", mock.Anything, mock.MatchedBy(func(orders []models.CreateOrderInput) bool { return len(orders) == 2 }), false).Return(result, nil)
+
+	// Act
+	resp, err := app.Test(batchRequest("", mixedBatchBody))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var body batchResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "1 of 2 orders created", body.Message)
+	assert.Len(t, body.Data.Created, 1)
+	assert.Equal(t, result.Errors, body.Data.Errors)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrders_Atomic(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBatchApp(mockService)
+
+	result := models.BatchCreateResult{Errors: []models.BatchOrderError{{Index: 1, Message: "customer name is required"}}}
+	mockService.On("CreateOrders", mock.Anything, mock.Anything, true).
+		Return(result, fmt.Errorf("%w: 1 of 2 orders failed validation", domain.ErrBatchInvalid))
+
+	// Act
+	resp, err := app.Test(batchRequest("?atomic=true", mixedBatchBody))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var body batchResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, result.Errors, body.Errors)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrders_NoneCreated(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBatchApp(mockService)
+
+	result := models.BatchCreateResult{Errors: []models.BatchOrderError{{Index: 0, Message: "customer name is required"}}}
+	mockService.On("CreateOrders", mock.Anything, mock.Anything, false).Return(result, nil)
+
+	// Act
+	resp, err := app.Test(batchRequest("", `{"orders":[{"customer_name":""}]}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+}
+
+func TestOrderHandler_CreateOrders_InvalidBatchSize(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBatchApp(mockService)
+	tooMany := `{"orders":[` + strings.TrimSuffix(strings.Repeat(`{"customer_name":"A"},`, maxBatchOrders+1), ",") + `]}`
+
+	for _, body := range []string{`{"orders":[]}`, tooMany} {
+		// Act
+		resp, err := app.Test(batchRequest("", body))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+	mockService.AssertNotCalled(t, "CreateOrders", mock.Anything, mock.Anything, mock.Anything)
+}
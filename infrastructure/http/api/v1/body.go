@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+)
+
+// errUnsupportedBody is returned for Content-Types order creation does not accept
+var errUnsupportedBody = errors.New("unsupported Content-Type")
+
+// acceptFormBody controls whether order creation also accepts application/x-www-form-urlencoded
+var acceptFormBody atomic.Bool
+
+// SetAcceptFormBody sets whether CreateOrder accepts form-encoded bodies next to JSON
+func SetAcceptFormBody(accept bool) {
+	acceptFormBody.Store(accept)
+}
+
+// supportedCreateContentTypes lists the accepted Content-Types for the 415 message
+func supportedCreateContentTypes() string {
+	if acceptFormBody.Load() {
+		return fiber.MIMEApplicationJSON + " or " + fiber.MIMEApplicationForm
+	}
+	return fiber.MIMEApplicationJSON
+}
+
+// parseCreateOrderBody decodes the request body by Content-Type. It returns errUnsupportedBody
+// for any other type so the caller can answer 415.
+func parseCreateOrderBody(c *fiber.Ctx) (models.CreateOrderInput, error) {
+	var input models.CreateOrderInput
+
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0]))
+	switch {
+	case contentType == fiber.MIMEApplicationJSON:
+		err := c.BodyParser(&input)
+		return input, err
+	case contentType == fiber.MIMEApplicationForm && acceptFormBody.Load():
+		values, err := url.ParseQuery(string(c.Body()))
+		if err != nil {
+			return input, err
+		}
+		return models.ParseCreateOrderForm(values)
+	default:
+		return input, errUnsupportedBody
+	}
+}
@@ -0,0 +1,131 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func useFormBody(t *testing.T, accept bool) {
+	previous := acceptFormBody.Load()
+	SetAcceptFormBody(accept)
+	t.Cleanup(func() { SetAcceptFormBody(previous) })
+}
+
+func newCreateOrderApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+	return app
+}
+
+func TestOrderHandler_CreateOrder_JSONWithCharset(t *testing.T) {
+	// Arrange
+	useFormBody(t, true)
+	mockService := &MockOrderService{}
+	app := newCreateOrderApp(mockService)
+
+	expected := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items:        []models.OrderItem{{ProductName: "Widget", Quantity: 2, Price: 10.5}},
+	}
+	mockService.On("CreateOrder", mock.Anything, expected).Return(models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+
+	// Act
+	body := `{"customer_name":"John Doe","items":[{"product_name":"Widget","quantity":2,"price":10.5}]}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_Form(t *testing.T) {
+	// Arrange
+	useFormBody(t, true)
+	mockService := &MockOrderService{}
+	app := newCreateOrderApp(mockService)
+
+	expected := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Status:       models.StatusPending,
+		Items: []models.OrderItem{
+			{ProductName: "Widget", Quantity: 2, Price: 10.5},
+			{ProductName: "Gadget", Quantity: 1, Price: 3},
+		},
+	}
+	mockService.On("CreateOrder", mock.Anything, expected).Return(models.OrderWithItems{Order: models.Order{ID: 1}}, nil)
+
+	// Act
+	body := "customer_name=John+Doe&status=pending" +
+		"&items%5B0%5D%5Bproduct_name%5D=Widget&items%5B0%5D%5Bquantity%5D=2&items%5B0%5D%5Bprice%5D=10.5" +
+		"&items[1][product_name]=Gadget&items[1][quantity]=1&items[1][price]=3"
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_FormInvalidItem(t *testing.T) {
+	// Arrange
+	useFormBody(t, true)
+	mockService := &MockOrderService{}
+	app := newCreateOrderApp(mockService)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("customer_name=John&items[0][quantity]=two"))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CreateOrder_UnsupportedMediaType(t *testing.T) {
+	tests := []struct {
+		name        string
+		acceptForm  bool
+		contentType string
+	}{
+		{name: "text", acceptForm: true, contentType: "text/plain"},
+		{name: "xml", acceptForm: true, contentType: "application/xml"},
+		{name: "missing", acceptForm: true, contentType: ""},
+		{name: "form disabled", acceptForm: false, contentType: "application/x-www-form-urlencoded"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			useFormBody(t, tt.acceptForm)
+			mockService := &MockOrderService{}
+			app := newCreateOrderApp(mockService)
+
+			// Act
+			req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader("customer_name=John"))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+			mockService.AssertNotCalled(t, "CreateOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
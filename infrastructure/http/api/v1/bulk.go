@@ -0,0 +1,74 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxBulkOrders caps how many orders one bulk request may create
+const maxBulkOrders = 1000
+
+// BulkCreateOrders creates the orders of a JSON array body in a single transaction and reports
+// the outcome of each one by index. Invalid orders do not stop the valid ones from being created
+// unless ?atomic=true is set, in which case any invalid order fails the request with 422.
+func (h *OrderHandler) BulkCreateOrders(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	var inputs []models.CreateOrderInput
+	if err := c.BodyParser(&inputs); err != nil {
+		requestLogger.WithError(err).Error("Failed to parse bulk request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	if len(inputs) == 0 || len(inputs) > maxBulkOrders {
+		requestLogger.Warn("Invalid bulk size", "orders", len(inputs))
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": fmt.Sprintf("body must be an array of between 1 and %d orders", maxBulkOrders),
+		})
+	}
+	atomic := c.QueryBool("atomic")
+
+	results, err := h.service.BulkCreateOrders(ctx, inputs, atomic)
+	if err != nil {
+		if errors.Is(err, domain.ErrBatchInvalid) {
+			requestLogger.WithError(err).Warn("Atomic bulk create rejected")
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+				"data":    results,
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, bulk orders not created")
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to bulk create orders", "orders", len(inputs))
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	created := 0
+	for _, result := range results {
+		if result.Order != nil {
+			created++
+		}
+	}
+	requestLogger.Info("Bulk create processed", "orders", len(inputs), "created", created, "failed", len(inputs)-created, "atomic", atomic)
+	if created == 0 {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+			"message": "No orders created",
+			"data":    results,
+		})
+	}
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": fmt.Sprintf("%d of %d orders created", created, len(inputs)),
+		"data":    results,
+	})
+}
@@ -0,0 +1,89 @@
+package v1
+
+import (
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// allowBulkDelete guards DELETE /orders, which is refused with 403 unless enabled
+var allowBulkDelete atomic.Bool
+
+// SetAllowBulkDelete enables or disables the bulk delete-by-filter endpoint
+func SetAllowBulkDelete(allow bool) {
+	allowBulkDelete.Store(allow)
+}
+
+// DeleteOrders deletes every order matching the status and created_before query filters.
+// At least one filter is required; created_before accepts RFC3339 or YYYY-MM-DD.
+func (h *OrderHandler) DeleteOrders(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	if !allowBulkDelete.Load() {
+		requestLogger.Warn("Bulk delete attempted while disabled")
+		return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+			"message": "Bulk delete is disabled",
+		})
+	}
+
+	var filter models.DeleteOrdersFilter
+	if status := c.Query("status"); status != "" {
+		filter.Status = models.Status(status)
+		if !filter.Status.IsValid() {
+			requestLogger.Error("Invalid status filter", "status", status)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid status",
+			})
+		}
+	}
+	if createdBefore := c.Query("created_before"); createdBefore != "" {
+		parsed, err := parseCreatedBefore(createdBefore)
+		if err != nil {
+			requestLogger.WithError(err).Error("Invalid created_before parameter", "created_before", createdBefore)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid created_before, expected RFC3339 timestamp or YYYY-MM-DD",
+			})
+		}
+		filter.CreatedBefore = &parsed
+	}
+
+	deleted, err := h.service.DeleteOrdersByFilter(ctx, filter)
+	if err != nil {
+		if errors.Is(err, domain.ErrEmptyFilter) {
+			requestLogger.Warn("Bulk delete refused without a filter")
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "At least one filter (status, created_before) is required",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, bulk delete stopped", "deleted", deleted)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+				"deleted": deleted,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to bulk delete orders", "deleted", deleted)
+		body := internalErrorBody(c, err)
+		body["deleted"] = deleted
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(body)
+	}
+
+	requestLogger.Info("Orders bulk deleted", "deleted", deleted)
+	return c.JSON(fiber.Map{
+		"message": "Orders deleted successfully",
+		"deleted": deleted,
+	})
+}
+
+func parseCreatedBefore(value string) (time.Time, error) {
+	if parsed, err := time.Parse(time.RFC3339, value); err == nil {
+		return parsed, nil
+	}
+	return time.Parse(time.DateOnly, value)
+}
@@ -0,0 +1,107 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func useBulkDelete(t *testing.T, allow bool) {
+	previous := allowBulkDelete.Load()
+	SetAllowBulkDelete(allow)
+	t.Cleanup(func() { SetAllowBulkDelete(previous) })
+}
+
+func TestOrderHandler_DeleteOrders_Disabled(t *testing.T) {
+	// Arrange
+	useBulkDelete(t, false)
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Delete("/orders", handler.DeleteOrders)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/orders?status=cancelled", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	mockService.AssertNotCalled(t, "DeleteOrdersByFilter", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_DeleteOrders_EmptyFilter(t *testing.T) {
+	// Arrange
+	useBulkDelete(t, true)
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Delete("/orders", handler.DeleteOrders)
+
+	mockService.On("DeleteOrdersByFilter", mock.Anything, models.DeleteOrdersFilter{}).Return(int64(0), domain.ErrEmptyFilter)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/orders", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestOrderHandler_DeleteOrders_ParsesFilter(t *testing.T) {
+	// Arrange
+	useBulkDelete(t, true)
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Delete("/orders", handler.DeleteOrders)
+
+	createdBefore := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	expected := models.DeleteOrdersFilter{Status: models.StatusCancelled, CreatedBefore: &createdBefore}
+	mockService.On("DeleteOrdersByFilter", mock.Anything, expected).Return(int64(42), nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/orders?status=cancelled&created_before=2025-01-01", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, float64(42), body["deleted"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_DeleteOrders_InvalidFilter(t *testing.T) {
+	useBulkDelete(t, true)
+
+	for _, query := range []string{"status=gone", "created_before=yesterday"} {
+		t.Run(query, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Delete("/orders", handler.DeleteOrders)
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodDelete, "/orders?"+query, nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+			mockService.AssertNotCalled(t, "DeleteOrdersByFilter", mock.Anything, mock.Anything)
+		})
+	}
+}
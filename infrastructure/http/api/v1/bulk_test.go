@@ -0,0 +1,108 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const mixedBulkBody = `[
+	{"customer_name":"Alice","items":[{"product_name":"Widget","quantity":1,"price":10}]},
+	{"customer_name":"","items":[{"product_name":"Widget","quantity":1,"price":10}]}
+]`
+
+func bulkRequest(query, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/orders/bulk"+query, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func newBulkApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders/bulk", handler.BulkCreateOrders)
+	return app
+}
+
+type bulkResponse struct {
+	Message string                   `json:"message"`
+	Data    []models.BulkOrderResult `json:"data"`
+}
+
+func TestOrderHandler_BulkCreateOrders_MixedBatch(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBulkApp(mockService)
+
+	results := []models.BulkOrderResult{
+		{Index: 0, Order: &models.OrderWithItems{Order: models.Order{ID: 1, CustomerName: "Alice"}}},
+		{Index: 1, Error: "customer name is required"},
+	}
+	mockService.On("BulkCreateOrders", mock.Anything, mock.MatchedBy(func(orders []models.CreateOrderInput) bool {
+		return len(orders) == 2 && orders[0].CustomerName == "Alice"
+	}), false).Return(results, nil)
+
+	// Act
+	resp, err := app.Test(bulkRequest("", mixedBulkBody))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	var body bulkResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "1 of 2 orders created", body.Message)
+	if assert.Len(t, body.Data, 2) {
+		assert.Equal(t, 1, body.Data[0].Order.ID)
+		assert.Equal(t, 1, body.Data[1].Index)
+		assert.Nil(t, body.Data[1].Order)
+		assert.Equal(t, "customer name is required", body.Data[1].Error)
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_BulkCreateOrders_Atomic(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBulkApp(mockService)
+
+	results := []models.BulkOrderResult{{Index: 0}, {Index: 1, Error: "customer name is required"}}
+	mockService.On("BulkCreateOrders", mock.Anything, mock.Anything, true).
+		Return(results, fmt.Errorf("%w: 1 of 2 orders failed validation", domain.ErrBatchInvalid))
+
+	// Act
+	resp, err := app.Test(bulkRequest("?atomic=true", mixedBulkBody))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var body bulkResponse
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, results, body.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_BulkCreateOrders_InvalidBody(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newBulkApp(mockService)
+	tooMany := `[` + strings.TrimSuffix(strings.Repeat(`{"customer_name":"A"},`, maxBulkOrders+1), ",") + `]`
+
+	for _, body := range []string{`[]`, `{"orders":[]}`, tooMany} {
+		// Act
+		resp, err := app.Test(bulkRequest("", body))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+	mockService.AssertNotCalled(t, "BulkCreateOrders", mock.Anything, mock.Anything, mock.Anything)
+}
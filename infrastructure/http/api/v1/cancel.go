@@ -0,0 +1,51 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// CancelOrder cancels a pending or processing order. Repeating the call on a cancelled order
+// returns 200 with the order, so clients can retry safely.
+func (h *OrderHandler) CancelOrder(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	orderID, err := h.orderIDParam(c)
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+
+	order, err := h.service.CancelOrder(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderNotCancellable) {
+			requestLogger.WithError(err).Warn("Order not cancellable", "order_id", orderID)
+			return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order not found", "order_id", orderID)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not cancelled", "order_id", orderID)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to cancel order", "order_id", orderID)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"message": "Order cancelled successfully",
+		"data":    order,
+	})
+}
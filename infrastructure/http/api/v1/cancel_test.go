@@ -0,0 +1,75 @@
+package v1
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newCancelApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders/:id/cancel", handler.CancelOrder)
+	return app
+}
+
+func TestOrderHandler_CancelOrder_Success(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newCancelApp(mockService)
+	cancelled := models.OrderWithItems{Order: models.Order{ID: 4, Status: models.StatusCancelled}}
+	mockService.On("CancelOrder", mock.Anything, 4).Return(cancelled, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/orders/4/cancel", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Data models.OrderWithItems `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, models.StatusCancelled, body.Data.Status)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CancelOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "invalid id", id: "abc", expectedStatus: http.StatusBadRequest},
+		{name: "completed order", id: "4", serviceErr: fmt.Errorf("%w: order is completed", domain.ErrOrderNotCancellable), expectedStatus: http.StatusConflict},
+		{name: "unknown order", id: "4", serviceErr: pgx.ErrNoRows, expectedStatus: http.StatusNotFound},
+		{name: "repository failure", id: "4", serviceErr: errors.New("boom"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newCancelApp(mockService)
+			mockService.On("CancelOrder", mock.Anything, mock.Anything).Return(models.OrderWithItems{}, tt.serviceErr).Maybe()
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/orders/"+tt.id+"/cancel", nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
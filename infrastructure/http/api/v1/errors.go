@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"sync/atomic"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+const internalErrorMessage = "Internal server error"
+
+// exposeErrorDetails controls whether 500 responses include the underlying error message
+var exposeErrorDetails atomic.Bool
+
+// SetExposeErrorDetails sets whether 500 responses return err.Error() to the client.
+// When disabled a generic message and the request ID are returned instead.
+func SetExposeErrorDetails(expose bool) {
+	exposeErrorDetails.Store(expose)
+}
+
+// internalErrorBody builds the response body for a 500. The full error is expected
+// to be logged by the caller, so the terse body only carries the request ID for correlation.
+// Database errors also carry a stable code, such as DB_TIMEOUT, naming their class.
+func internalErrorBody(c *fiber.Ctx, err error) fiber.Map {
+	var body fiber.Map
+	if exposeErrorDetails.Load() {
+		body = fiber.Map{
+			"message": err.Error(),
+		}
+	} else {
+		requestID, _ := c.Locals("request_id").(string)
+		body = fiber.Map{
+			"message":    internalErrorMessage,
+			"request_id": requestID,
+		}
+	}
+
+	if code := database.ErrorCode(err); code != "" {
+		body["code"] = code
+	}
+	return body
+}
@@ -0,0 +1,24 @@
+package v1
+
+import (
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// orderModifiedError writes the response for domain.ErrOrderModified: 412 when If-Match names a
+// version of the order that is not current, and 409 when a request without If-Match lost a race
+// with another write that changed the order's status first
+func orderModifiedError(c *fiber.Ctx, id int) error {
+	requestLogger := logger.LoggerWithRequestIDFromContext(c.UserContext())
+	ifMatch := c.Get(fiber.HeaderIfMatch)
+	if ifMatch == "" {
+		requestLogger.Warn("Order changed by a concurrent request", "order_id", id)
+		return c.Status(fiber.StatusConflict).JSON(fiber.Map{
+			"message": "Order was changed by another request, fetch it again and retry",
+		})
+	}
+	requestLogger.Warn("If-Match does not match the current order", "order_id", id, "if_match", ifMatch)
+	return c.Status(fiber.StatusPreconditionFailed).JSON(fiber.Map{
+		"message": "Order has been modified, fetch it again and retry with its current ETag",
+	})
+}
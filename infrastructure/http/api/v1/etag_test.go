@@ -0,0 +1,133 @@
+package v1
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newIfMatchApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+	app.Put("/orders/:id/status", handler.UpdateOrder)
+	app.Patch("/orders/:id", handler.PatchOrder)
+	return app
+}
+
+func etagTestOrder(updatedAt time.Time) models.OrderWithItems {
+	return models.OrderWithItems{
+		Order: models.Order{ID: 42, Status: models.StatusPending, UpdatedAt: updatedAt},
+		Items: []models.OrderItem{{ID: 7, OrderID: 42, ProductName: "Product 1", Quantity: 1, Price: 5, UpdatedAt: updatedAt}},
+	}
+}
+
+func statusRequest(ifMatch string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/orders/42/status", bytes.NewReader([]byte(`{"status":"processing"}`)))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	if ifMatch != "" {
+		req.Header.Set(fiber.HeaderIfMatch, ifMatch)
+	}
+	return req
+}
+
+func TestOrderHandler_GetOrder_SetsETag(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newIfMatchApp(mockService)
+	order := etagTestOrder(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	mockService.On("GetOrderById", mock.Anything, 42).Return(order, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, order.ETag(), resp.Header.Get(fiber.HeaderETag))
+}
+
+func TestOrderHandler_UpdateOrder_IfMatch(t *testing.T) {
+	current := etagTestOrder(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+
+	cases := []struct {
+		name       string
+		ifMatch    string
+		serviceErr error
+		wantStatus int
+	}{
+		{name: "matching", ifMatch: current.ETag(), wantStatus: http.StatusOK},
+		{name: "missing", ifMatch: "", wantStatus: http.StatusOK},
+		{name: "modified", ifMatch: current.ETag(), serviceErr: domain.ErrOrderModified, wantStatus: http.StatusPreconditionFailed},
+		{name: "status changed concurrently", ifMatch: "", serviceErr: domain.ErrOrderModified, wantStatus: http.StatusConflict},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newIfMatchApp(mockService)
+			expected := models.UpdateOrderInput{ID: 42, Status: models.StatusProcessing, IfMatch: tc.ifMatch}
+			mockService.On("UpdateOrder", mock.Anything, expected).Return(tc.serviceErr)
+
+			// Act
+			resp, err := app.Test(statusRequest(tc.ifMatch))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+			mockService.AssertNotCalled(t, "GetOrderById", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_PatchOrder_IfMatch(t *testing.T) {
+	current := etagTestOrder(time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC))
+	patched := etagTestOrder(time.Date(2025, 6, 1, 12, 5, 0, 0, time.UTC))
+	name := "Jane"
+	expected := models.PatchOrderInput{CustomerName: &name, IfMatch: current.ETag()}
+	patchRequest := func(ifMatch string) *http.Request {
+		req := httptest.NewRequest(http.MethodPatch, "/orders/42", bytes.NewReader([]byte(`{"customer_name":"Jane"}`)))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		req.Header.Set(fiber.HeaderIfMatch, ifMatch)
+		return req
+	}
+
+	t.Run("matching", func(t *testing.T) {
+		// Arrange
+		mockService := &MockOrderService{}
+		app := newIfMatchApp(mockService)
+		mockService.On("PatchOrder", mock.Anything, 42, expected).Return(patched, nil)
+
+		// Act
+		resp, err := app.Test(patchRequest(current.ETag()))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, patched.ETag(), resp.Header.Get(fiber.HeaderETag))
+	})
+
+	t.Run("modified", func(t *testing.T) {
+		// Arrange
+		mockService := &MockOrderService{}
+		app := newIfMatchApp(mockService)
+		mockService.On("PatchOrder", mock.Anything, 42, expected).Return(models.OrderWithItems{}, domain.ErrOrderModified)
+
+		// Act
+		resp, err := app.Test(patchRequest(current.ETag()))
+
+		// Assert
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusPreconditionFailed, resp.StatusCode)
+	})
+}
@@ -0,0 +1,103 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/Testzyler/order-management-go/application/models"
+)
+
+// selectableOrderFields is the whitelist of top-level order fields accepted by ?fields=
+var selectableOrderFields = map[string]struct{}{
+	"id":            {},
+	"order_number":  {},
+	"customer_name": {},
+	"total_amount":  {},
+	"status":        {},
+	"created_at":    {},
+	"updated_at":    {},
+	"user_id":       {},
+	"items":         {},
+}
+
+// parseFieldsParam parses a comma separated ?fields= value and validates every name.
+// An empty value returns nil, meaning the full representation should be returned.
+func parseFieldsParam(raw string) ([]string, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var fields []string
+	seen := make(map[string]struct{})
+	for _, field := range strings.Split(raw, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+		if _, ok := selectableOrderFields[field]; !ok {
+			return nil, fmt.Errorf("unknown field: %s", field)
+		}
+		if _, ok := seen[field]; ok {
+			continue
+		}
+		seen[field] = struct{}{}
+		fields = append(fields, field)
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("fields parameter must name at least one field")
+	}
+	return fields, nil
+}
+
+// selectOrderFields serializes the order and keeps only the requested fields.
+// Items are dropped unless explicitly requested; warnings are always kept.
+func selectOrderFields(order models.OrderWithItems, fields []string) (map[string]any, error) {
+	raw, err := json.Marshal(order)
+	if err != nil {
+		return nil, err
+	}
+
+	// UseNumber keeps the 2-decimal money formatting intact
+	var full map[string]any
+	decoder := json.NewDecoder(bytes.NewReader(raw))
+	decoder.UseNumber()
+	if err := decoder.Decode(&full); err != nil {
+		return nil, err
+	}
+
+	selected := make(map[string]any, len(fields))
+	for _, field := range fields {
+		if value, ok := full[field]; ok {
+			selected[field] = value
+		}
+	}
+	// A truncated item list keeps its markers so clients know to fetch the full order
+	if _, ok := selected["items"]; ok {
+		for _, marker := range []string{"more_items", "item_count"} {
+			if value, ok := full[marker]; ok {
+				selected[marker] = value
+			}
+		}
+	}
+	// Warnings mark a partial result and are kept whatever fields were asked for
+	if warnings, ok := full["warnings"]; ok {
+		selected["warnings"] = warnings
+	}
+	return selected, nil
+}
+
+// selectOrdersFields applies selectOrderFields to every order in a list
+func selectOrdersFields(orders []models.OrderWithItems, fields []string) ([]map[string]any, error) {
+	selected := make([]map[string]any, 0, len(orders))
+	for _, order := range orders {
+		filtered, err := selectOrderFields(order, fields)
+		if err != nil {
+			return nil, err
+		}
+		selected = append(selected, filtered)
+	}
+	return selected, nil
+}
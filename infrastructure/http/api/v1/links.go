@@ -0,0 +1,90 @@
+package v1
+
+import (
+	"net/url"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+)
+
+// firstForwardedValue returns the first entry of a possibly comma-joined forwarded header,
+// which is the value set by the proxy closest to the client
+func firstForwardedValue(c *fiber.Ctx, header string) string {
+	value, _, _ := strings.Cut(c.Get(header), ",")
+	return strings.TrimSpace(value)
+}
+
+// requestBaseURL returns the scheme and host the client used to reach the API. Behind a trusted
+// proxy X-Forwarded-Proto and X-Forwarded-Host take precedence over the connection's own scheme
+// and Host header; values that are not a plain scheme or host are ignored. From any other peer
+// the headers are ignored, so clients cannot point the returned links at another host.
+func requestBaseURL(c *fiber.Ctx) string {
+	scheme := "http"
+	if c.Context().IsTLS() {
+		scheme = "https"
+	}
+	host := string(c.Context().Host())
+	if !middleware.FromTrustedProxy(c) {
+		return scheme + "://" + host
+	}
+
+	if proto := strings.ToLower(firstForwardedValue(c, fiber.HeaderXForwardedProto)); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	if forwarded := firstForwardedValue(c, fiber.HeaderXForwardedHost); forwarded != "" && !strings.ContainsAny(forwarded, "/\\@ ") {
+		host = forwarded
+	}
+	return scheme + "://" + host
+}
+
+// absoluteURL turns an absolute path into a URL using the request's scheme and host
+func absoluteURL(c *fiber.Ctx, urlPath string) string {
+	return requestBaseURL(c) + urlPath
+}
+
+// orderURL returns the absolute URL of order inside the given orders collection path, using the
+// ID exposed by the configured ID strategy
+func orderURL(c *fiber.Ctx, collectionPath string, order models.Order) string {
+	id := strconv.Itoa(order.ID)
+	if models.CurrentIDStrategy() == models.IDStrategyUUID {
+		id = order.PublicID
+	}
+	return absoluteURL(c, strings.TrimSuffix(collectionPath, "/")+"/"+id)
+}
+
+// ordersCollectionPath returns the collection path of an order resource path such as /api/v1/orders/7
+func ordersCollectionPath(resourcePath string) string {
+	return path.Dir(strings.TrimSuffix(resourcePath, "/"))
+}
+
+// paginationLinkHeader builds an RFC 5988 Link header for a page of a listing. The links keep the
+// request's path and query, replacing only the page parameter; prev and next are left out at the
+// first and last page.
+func paginationLinkHeader(c *fiber.Ctx, page, totalPages int) string {
+	query, err := url.ParseQuery(string(c.Request().URI().QueryString()))
+	if err != nil {
+		query = url.Values{}
+	}
+	base := absoluteURL(c, c.Path())
+	pageURL := func(p int) string {
+		query.Set("page", strconv.Itoa(p))
+		return base + "?" + query.Encode()
+	}
+
+	lastPage := max(totalPages, 1)
+	links := make([]string, 0, 4)
+	links = append(links, `<`+pageURL(1)+`>; rel="first"`)
+	if page > 1 {
+		links = append(links, `<`+pageURL(min(page-1, lastPage))+`>; rel="prev"`)
+	}
+	if page < lastPage {
+		links = append(links, `<`+pageURL(page+1)+`>; rel="next"`)
+	}
+	links = append(links, `<`+pageURL(lastPage)+`>; rel="last"`)
+	return strings.Join(links, ", ")
+}
@@ -0,0 +1,132 @@
+package v1
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// useTrustedTestPeer trusts the forwarded headers of requests sent with app.Test for the duration of the test
+func useTrustedTestPeer(t *testing.T) {
+	assert.NoError(t, middleware.SetTrustedProxies([]string{"0.0.0.0"}))
+	t.Cleanup(func() { _ = middleware.SetTrustedProxies(nil) })
+}
+
+func TestRequestBaseURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		untrusted bool
+		headers   map[string]string
+		expected  string
+	}{
+		{name: "direct request", expected: "http://api.internal:8080"},
+		{name: "forwarded proto and host", headers: map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "shop.example.com"}, expected: "https://shop.example.com"},
+		{name: "proxy chain uses the first value", headers: map[string]string{"X-Forwarded-Proto": "https, http", "X-Forwarded-Host": "shop.example.com, lb.internal"}, expected: "https://shop.example.com"},
+		{name: "forwarded proto only", headers: map[string]string{"X-Forwarded-Proto": "HTTPS"}, expected: "https://api.internal:8080"},
+		{name: "unknown proto ignored", headers: map[string]string{"X-Forwarded-Proto": "javascript"}, expected: "http://api.internal:8080"},
+		{name: "host with path ignored", headers: map[string]string{"X-Forwarded-Host": "evil.example.com/phish"}, expected: "http://api.internal:8080"},
+		{name: "untrusted peer ignored", untrusted: true, headers: map[string]string{"X-Forwarded-Proto": "https", "X-Forwarded-Host": "evil.example.com"}, expected: "http://api.internal:8080"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			if !tt.untrusted {
+				useTrustedTestPeer(t)
+			}
+			app := fiber.New()
+			var baseURL string
+			app.Get("/", func(c *fiber.Ctx) error {
+				baseURL = requestBaseURL(c)
+				return nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "http://api.internal:8080/", nil)
+			for key, value := range tt.headers {
+				req.Header.Set(key, value)
+			}
+
+			// Act
+			_, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, baseURL)
+		})
+	}
+}
+
+func TestOrderHandler_CreateOrder_LocationBehindProxy(t *testing.T) {
+	// Arrange
+	useTrustedTestPeer(t)
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+	app := fiber.New()
+	app.Post("/api/v1/orders", handler.CreateOrder)
+	mockService.On("CreateOrder", mock.Anything, mock.Anything).Return(models.OrderWithItems{Order: models.Order{ID: 42}}, nil)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders/", strings.NewReader(`{"customer_name":"Jane","items":[{"product_name":"Widget","quantity":1,"price":1}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Forwarded-Proto", "https")
+	req.Header.Set("X-Forwarded-Host", "shop.example.com")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "https://shop.example.com/api/v1/orders/42", resp.Header.Get("Location"))
+	var body struct {
+		Links map[string]string `json:"links"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "https://shop.example.com/api/v1/orders/42", body.Links["self"])
+}
+
+func TestOrderHandler_GetOrder_SelfLink(t *testing.T) {
+	tests := []struct {
+		name string
+		ref  string
+	}{
+		{name: "by id", ref: "7"},
+		{name: "by order number", ref: "ORD-20250601-K7QX2M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			useTrustedTestPeer(t)
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+			app := fiber.New()
+			app.Get("/api/v1/orders/:id", handler.GetOrder)
+			order := models.OrderWithItems{Order: models.Order{ID: 7, OrderNumber: "ORD-20250601-K7QX2M"}}
+			mockService.On("GetOrderById", mock.Anything, 7).Return(order, nil).Maybe()
+			mockService.On("GetOrderByNumber", mock.Anything, "ORD-20250601-K7QX2M").Return(order, nil).Maybe()
+
+			req := httptest.NewRequest(http.MethodGet, "/api/v1/orders/"+tt.ref, nil)
+			req.Header.Set("X-Forwarded-Proto", "https")
+			req.Header.Set("X-Forwarded-Host", "shop.example.com")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			var body struct {
+				Links map[string]string `json:"links"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, "https://shop.example.com/api/v1/orders/7", body.Links["self"])
+		})
+	}
+}
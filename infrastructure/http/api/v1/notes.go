@@ -0,0 +1,111 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// AddOrderNote attaches a free-text note to an order
+func (h *OrderHandler) AddOrderNote(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	orderID, err := h.orderIDParam(c)
+	if err == nil && orderID <= 0 {
+		err = errInvalidOrderID
+	}
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+
+	var input models.CreateNoteInput
+	if err := c.BodyParser(&input); err != nil {
+		requestLogger.WithError(err).Error("Failed to parse note request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	input.OrderID = orderID
+	// An authenticated caller is always the author, so notes cannot be written in someone else's name
+	if userID, ok := middleware.UserID(c); ok {
+		if input.Author != "" && input.Author != userID {
+			requestLogger.Warn("Note author differs from the authenticated user", "order_id", orderID, "author", input.Author)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": "author must be the authenticated user",
+			})
+		}
+		input.Author = userID
+	}
+
+	note, err := h.service.AddOrderNote(ctx, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidNote) {
+			requestLogger.WithError(err).Warn("Invalid order note", "order_id", orderID)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order not found for note", "order_id", orderID)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, note not added", "order_id", orderID)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to add order note", "order_id", orderID)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	requestLogger.Info("Order note added", "order_id", orderID, "note_id", note.ID)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Note added successfully",
+		"data":    note,
+	})
+}
+
+// ListOrderNotes returns the notes of an order, oldest first
+func (h *OrderHandler) ListOrderNotes(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	orderID, err := h.orderIDParam(c)
+	if err == nil && orderID <= 0 {
+		err = errInvalidOrderID
+	}
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+
+	notes, err := h.service.ListOrderNotes(ctx, orderID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order not found for notes", "order_id", orderID)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, notes not fetched", "order_id", orderID)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to list order notes", "order_id", orderID)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"data": notes,
+	})
+}
@@ -0,0 +1,167 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newNotesApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders/:id/notes", handler.AddOrderNote)
+	app.Get("/orders/:id/notes", handler.ListOrderNotes)
+	return app
+}
+
+func noteRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/orders/"+id+"/notes", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestOrderHandler_AddOrderNote_Success(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newNotesApp(mockService)
+
+	input := models.CreateNoteInput{OrderID: 4, Author: "support", Text: "Customer called about delivery"}
+	created := models.OrderNote{ID: 11, OrderID: 4, Author: "support", Text: input.Text, CreatedAt: time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)}
+	mockService.On("AddOrderNote", mock.Anything, input).Return(created, nil)
+
+	// Act
+	resp, err := app.Test(noteRequest("4", `{"author":"support","text":"Customer called about delivery"}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body struct {
+		Data models.OrderNote `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, created, body.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_AddOrderNote_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		id             string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "invalid id", id: "abc", body: `{"text":"hi"}`, expectedStatus: http.StatusBadRequest},
+		{name: "malformed body", id: "4", body: `{"text":`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid note", id: "4", body: `{"text":" "}`, serviceErr: fmt.Errorf("%w: text is required", domain.ErrInvalidNote), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "unknown order", id: "4", body: `{"text":"hi"}`, serviceErr: pgx.ErrNoRows, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newNotesApp(mockService)
+			mockService.On("AddOrderNote", mock.Anything, mock.Anything).Return(models.OrderNote{}, tt.serviceErr).Maybe()
+
+			// Act
+			resp, err := app.Test(noteRequest(tt.id, tt.body))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestOrderHandler_AddOrderNote_AuthenticatedAuthor(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		expectedStatus int
+	}{
+		{name: "author omitted", body: `{"text":"hi"}`, expectedStatus: http.StatusCreated},
+		{name: "same author", body: `{"author":"user-42","text":"hi"}`, expectedStatus: http.StatusCreated},
+		{name: "other author", body: `{"author":"support","text":"hi"}`, expectedStatus: http.StatusUnprocessableEntity},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+			app := fiber.New()
+			app.Post("/orders/:id/notes", middleware.AuthMiddleware(middleware.AuthConfig{Enabled: true, Secret: testAuthSecret}), handler.AddOrderNote)
+			input := models.CreateNoteInput{OrderID: 4, Author: "user-42", Text: "hi"}
+			mockService.On("AddOrderNote", mock.Anything, input).Return(models.OrderNote{ID: 1, OrderID: 4, Author: "user-42", Text: "hi"}, nil).Maybe()
+
+			req := ownerRequest(t, "/orders/4/notes", "user-42", "")
+			req.Method = http.MethodPost
+			req.Body = io.NopCloser(strings.NewReader(tt.body))
+			req.ContentLength = int64(len(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrderNotes_Success(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newNotesApp(mockService)
+
+	notes := []models.OrderNote{
+		{ID: 1, OrderID: 4, Author: "support", Text: "First"},
+		{ID: 2, OrderID: 4, Author: "anonymous", Text: "Second"},
+	}
+	mockService.On("ListOrderNotes", mock.Anything, 4).Return(notes, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/4/notes", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []models.OrderNote `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, notes, body.Data)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrderNotes_UnknownOrder(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newNotesApp(mockService)
+	mockService.On("ListOrderNotes", mock.Anything, 99).Return(nil, pgx.ErrNoRows)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/99/notes", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
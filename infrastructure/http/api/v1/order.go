@@ -2,7 +2,10 @@ package v1
 
 import (
 	"errors"
+	"fmt"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Testzyler/order-management-go/application/constants"
@@ -10,7 +13,9 @@ import (
 	"github.com/Testzyler/order-management-go/application/models"
 	"github.com/Testzyler/order-management-go/application/repositories"
 	"github.com/Testzyler/order-management-go/application/services"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	"github.com/gofiber/fiber/v2"
 	"github.com/jackc/pgx/v5"
@@ -41,24 +46,96 @@ func (h *OrderHandler) GetRouteDefinition() route.RouteDefinition {
 				Method:      constants.METHOD_POST,
 				HandlerFunc: h.CreateOrder,
 			},
+			route.Route{
+				Name:        "CreateOrders",
+				Path:        "/batch",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.CreateOrders,
+			},
+			route.Route{
+				Name:        "BulkCreateOrders",
+				Path:        "/bulk",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.BulkCreateOrders,
+			},
+			route.Route{
+				Name:        "DailyTotals",
+				Path:        "/daily",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.DailyTotals,
+			},
+			route.Route{
+				Name:        "RecentOrders",
+				Path:        "/recent",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.RecentOrders,
+			},
 			route.Route{
 				Name:        "GetOrder",
 				Path:        "/:id",
 				Method:      constants.METHOD_GET,
 				HandlerFunc: h.GetOrder,
 			},
+			route.Route{
+				Name:        "UpsertOrderByNumber",
+				Path:        "/by-number/:ref",
+				Method:      constants.METHOD_PUT,
+				HandlerFunc: h.UpsertOrderByNumber,
+			},
 			route.Route{
 				Name:        "UpdateOrder",
 				Path:        "/:id/status",
 				Method:      constants.METHOD_PUT,
 				HandlerFunc: h.UpdateOrder,
 			},
+			route.Route{
+				Name:        "UpdateItemStatus",
+				Path:        "/:id/items/:itemId/status",
+				Method:      constants.METHOD_PUT,
+				HandlerFunc: h.UpdateItemStatus,
+			},
+			route.Route{
+				Name:        "ShipOrder",
+				Path:        "/:id/ship",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.ShipOrder,
+			},
+			route.Route{
+				Name:        "CancelOrder",
+				Path:        "/:id/cancel",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.CancelOrder,
+			},
+			route.Route{
+				Name:        "AddOrderNote",
+				Path:        "/:id/notes",
+				Method:      constants.METHOD_POST,
+				HandlerFunc: h.AddOrderNote,
+			},
+			route.Route{
+				Name:        "ListOrderNotes",
+				Path:        "/:id/notes",
+				Method:      constants.METHOD_GET,
+				HandlerFunc: h.ListOrderNotes,
+			},
+			route.Route{
+				Name:        "PatchOrder",
+				Path:        "/:id",
+				Method:      constants.METHOD_PATCH,
+				HandlerFunc: h.PatchOrder,
+			},
 			route.Route{
 				Name:        "DeleteOrder",
 				Path:        "/:id",
 				Method:      constants.METHOD_DELETE,
 				HandlerFunc: h.DeleteOrder,
 			},
+			route.Route{
+				Name:        "DeleteOrders",
+				Path:        "/",
+				Method:      constants.METHOD_DELETE,
+				HandlerFunc: h.DeleteOrders,
+			},
 			route.Route{
 				Name:        "ListOrders",
 				Path:        "/",
@@ -75,14 +152,44 @@ func init() {
 	route.RegisterHandler(NewOrderHandler())
 }
 
+// serviceUnavailableMessage reports whether err is a transient condition that should
+// be returned as 503 and the message to show the client
+func serviceUnavailableMessage(err error) (string, bool) {
+	switch {
+	case errors.Is(err, domain.ErrReadOnly):
+		return domain.ErrReadOnly.Error(), true
+	case errors.Is(err, database.ErrPoolClosed):
+		return "server shutting down", true
+	default:
+		return "", false
+	}
+}
+
+// duplicateItemsResponse builds the 422 body listing the repeated product names of a rejected order
+func duplicateItemsResponse(err error) (fiber.Map, bool) {
+	var duplicateErr *domain.DuplicateItemsError
+	if !errors.As(err, &duplicateErr) {
+		return nil, false
+	}
+	return fiber.Map{
+		"message":    err.Error(),
+		"duplicates": duplicateErr.ProductNames,
+	}, true
+}
+
 func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 
 	// Get logger with request ID from context
 	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
-	var input models.CreateOrderInput
-
-	if err := c.BodyParser(&input); err != nil {
+	input, err := parseCreateOrderBody(c)
+	if errors.Is(err, errUnsupportedBody) {
+		requestLogger.Warn("Unsupported order body content type", "content_type", c.Get(fiber.HeaderContentType))
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"message": "Content-Type must be " + supportedCreateContentTypes(),
+		})
+	}
+	if err != nil {
 		requestLogger.WithError(err).Error("Failed to parse request body")
 		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
 			"message": err.Error(),
@@ -90,60 +197,139 @@ func (h *OrderHandler) CreateOrder(c *fiber.Ctx) error {
 	}
 
 	start := time.Now()
-	err := h.service.CreateOrder(ctx, input)
+	created, err := h.service.CreateOrder(ctx, input)
 	duration := time.Since(start)
 
 	if err != nil {
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not created")
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		var fieldErr *domain.FieldError
+		if errors.As(err, &fieldErr) && errors.Is(err, domain.ErrTotalOutOfRange) {
+			requestLogger.WithError(err).Warn("Item subtotal out of range", "field", fieldErr.Field)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+				"field":   fieldErr.Field,
+			})
+		}
+		if errors.Is(err, domain.ErrTotalOutOfRange) {
+			requestLogger.WithError(err).Warn("Order total out of range")
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrTotalMismatch) {
+			requestLogger.WithError(err).Warn("Order total does not match items")
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if response, ok := duplicateItemsResponse(err); ok {
+			requestLogger.WithError(err).Warn("Order rejected for duplicate items")
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response)
+		}
+		if errors.Is(err, domain.ErrQuotaExceeded) {
+			requestLogger.WithError(err).Warn("Order rejected by customer quota")
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
 		requestLogger.WithError(err).Error("Failed to create order", "duration_ms", duration.Milliseconds())
-		return c.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"message": err.Error(),
-		})
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
 	}
 
-	requestLogger.Info("Order created successfully", "duration_ms", duration.Milliseconds())
+	requestLogger.Info("Order created successfully", "order_id", created.ID, "duration_ms", duration.Milliseconds())
+	self := orderURL(c, c.Path(), created.Order)
+	c.Location(self)
 	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
 		"message": "Order created successfully",
+		"data":    created,
+		"links":   fiber.Map{"self": self},
 	})
 }
 
+// GetOrder looks an order up by its ID, numeric or UUID depending on the ID strategy, or by order number.
+// Unknown IDs, unknown order numbers and malformed references all return 404.
 func (h *OrderHandler) GetOrder(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
-	id := c.Params("id")
+	ref := c.Params("id")
 
-	if id == "" {
+	if ref == "" {
 		requestLogger.Error("Order ID is required")
 		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
 			"message": "Order ID is required",
 		})
 	}
 
-	idInt, err := strconv.Atoi(id)
+	fields, err := parseFieldsParam(c.Query("fields"))
 	if err != nil {
-		requestLogger.WithError(err).Error("Invalid Order ID format", "id", id)
+		requestLogger.WithError(err).Error("Invalid fields parameter", "fields", c.Query("fields"))
 		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
-			"message": "Invalid Order ID format",
+			"message": err.Error(),
 		})
 	}
 
 	start := time.Now()
-	order, err := h.service.GetOrderById(ctx, idInt)
+	var order models.OrderWithItems
+	publicID, isPublicID := models.ParseOrderPublicID(ref)
+	idInt, convErr := strconv.Atoi(ref)
+	if models.CurrentIDStrategy() == models.IDStrategyUUID && isPublicID {
+		order, err = h.service.GetOrderByPublicID(ctx, publicID)
+	} else if models.CurrentIDStrategy() == models.IDStrategySerial && convErr == nil && idInt > 0 {
+		order, err = h.service.GetOrderById(ctx, idInt)
+	} else if models.IsOrderNumber(ref) {
+		order, err = h.service.GetOrderByNumber(ctx, ref)
+	} else {
+		requestLogger.Warn("Order reference is neither an ID nor an order number", "order_ref", ref)
+		return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"message": "Order not found",
+		})
+	}
 	duration := time.Since(start)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			requestLogger.Warn("Order not found", "order_id", idInt)
+			requestLogger.Warn("Order not found", "order_ref", ref)
 			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
 				"message": "Order not found",
 			})
 		}
-		requestLogger.WithError(err).Error("Failed to get order", "order_id", idInt, "duration_ms", duration.Milliseconds())
-		return c.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"message": err.Error(),
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not fetched", "order_ref", ref)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to get order", "order_ref", ref, "duration_ms", duration.Milliseconds())
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	if !canAccessOrder(c, order.Order) {
+		return forbiddenOrder(c, order.Order)
+	}
+
+	// The self link always uses the order's ID, also when the order was fetched by order number
+	links := fiber.Map{"self": orderURL(c, ordersCollectionPath(c.Path()), order.Order)}
+	c.Set(fiber.HeaderETag, order.ETag())
+
+	if fields != nil {
+		selected, err := selectOrderFields(order, fields)
+		if err != nil {
+			requestLogger.WithError(err).Error("Failed to select order fields", "order_ref", ref)
+			return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+		}
+		return c.JSON(fiber.Map{
+			"data":  selected,
+			"links": links,
 		})
 	}
 
 	return c.JSON(fiber.Map{
-		"data": order,
+		"data":  order,
+		"links": links,
 	})
 }
 
@@ -166,21 +352,38 @@ func (h *OrderHandler) UpdateOrder(c *fiber.Ctx) error {
 		})
 	}
 
-	idInt, err := strconv.Atoi(id)
+	idInt, err := h.orderIDParam(c)
 	if err != nil {
-		requestLogger.WithError(err).Error("Invalid Order ID format", "id", id)
-		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
-			"message": "Invalid Order ID",
-		})
+		return orderIDParamError(c, err)
 	}
 
 	input.ID = idInt
+	input.IfMatch = c.Get(fiber.HeaderIfMatch)
 	err = h.service.UpdateOrder(ctx, input)
 	if err != nil {
+		if errors.Is(err, domain.ErrOrderModified) {
+			return orderModifiedError(c, idInt)
+		}
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			requestLogger.WithError(err).Warn("Invalid status transition", "order_id", idInt, "status", input.Status)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order not found", "order_id", idInt)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not updated", "order_id", idInt)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
 		requestLogger.WithError(err).Error("Failed to update order", "order_id", idInt)
-		return c.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"message": err.Error(),
-		})
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
 	}
 
 	requestLogger.Info("Order updated successfully", "order_id", idInt, "status", input.Status)
@@ -189,6 +392,131 @@ func (h *OrderHandler) UpdateOrder(c *fiber.Ctx) error {
 	})
 }
 
+// PatchOrder applies a JSON Merge Patch (RFC 7386) sent as application/merge-patch+json, or a
+// partial update of customer_name and/or status sent as application/json
+func (h *OrderHandler) PatchOrder(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	idInt, err := h.orderIDParam(c)
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+	ifMatch := c.Get(fiber.HeaderIfMatch)
+
+	contentType := strings.TrimSpace(strings.Split(c.Get(fiber.HeaderContentType), ";")[0])
+	var order models.OrderWithItems
+	switch {
+	case strings.EqualFold(contentType, models.MergePatchContentType):
+		order, err = h.service.MergePatchOrder(ctx, idInt, c.Body(), ifMatch)
+	case strings.EqualFold(contentType, fiber.MIMEApplicationJSON):
+		var input models.PatchOrderInput
+		if err := c.BodyParser(&input); err != nil {
+			requestLogger.WithError(err).Warn("Failed to parse patch body", "order_id", idInt)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid JSON body",
+			})
+		}
+		input.IfMatch = ifMatch
+		order, err = h.service.PatchOrder(ctx, idInt, input)
+	default:
+		requestLogger.Error("Unsupported patch content type", "content_type", contentType)
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"message": "Content-Type must be " + models.MergePatchContentType + " or " + fiber.MIMEApplicationJSON,
+		})
+	}
+	if err != nil {
+		if errors.Is(err, domain.ErrOrderModified) {
+			return orderModifiedError(c, idInt)
+		}
+		if errors.Is(err, domain.ErrInvalidPatch) || errors.Is(err, domain.ErrInvalidStatusTransition) {
+			requestLogger.WithError(err).Warn("Invalid order patch", "order_id", idInt)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order not found", "order_id", idInt)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not patched", "order_id", idInt)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to patch order", "order_id", idInt)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	requestLogger.Info("Order patched successfully", "order_id", idInt, "status", order.Status)
+	c.Set(fiber.HeaderETag, order.ETag())
+	return c.JSON(fiber.Map{
+		"message": "Order updated successfully",
+		"data":    order,
+	})
+}
+
+// UpdateItemStatus sets one item's fulfillment status and returns the order with its derived status
+func (h *OrderHandler) UpdateItemStatus(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	orderID, err := h.orderIDParam(c)
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+	itemID, err := strconv.Atoi(c.Params("itemId"))
+	if err != nil {
+		requestLogger.WithError(err).Error("Invalid Item ID format", "item_id", c.Params("itemId"))
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": "Invalid Item ID",
+		})
+	}
+
+	var input models.UpdateItemStatusInput
+	if err := c.BodyParser(&input); err != nil {
+		requestLogger.WithError(err).Error("Failed to parse item status request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	input.OrderID = orderID
+	input.ItemID = itemID
+
+	order, err := h.service.UpdateItemStatus(ctx, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidItemStatus) {
+			requestLogger.WithError(err).Warn("Invalid item status", "order_id", orderID, "item_id", itemID)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order item not found", "order_id", orderID, "item_id", itemID)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order item not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, item not updated", "order_id", orderID, "item_id", itemID)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to update item status", "order_id", orderID, "item_id", itemID)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	requestLogger.Info("Item status updated successfully", "order_id", orderID, "item_id", itemID, "status", input.Status, "order_status", order.Status)
+	return c.JSON(fiber.Map{
+		"message": "Item status updated successfully",
+		"data":    order,
+	})
+}
+
 func (h *OrderHandler) DeleteOrder(c *fiber.Ctx) error {
 	ctx := c.UserContext()
 	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
@@ -201,20 +529,21 @@ func (h *OrderHandler) DeleteOrder(c *fiber.Ctx) error {
 		})
 	}
 
-	idInt, err := strconv.Atoi(id)
+	idInt, err := h.orderIDParam(c)
 	if err != nil {
-		requestLogger.WithError(err).Error("Invalid Order ID format", "id", id)
-		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
-			"message": "Invalid Order ID",
-		})
+		return orderIDParamError(c, err)
 	}
 
 	err = h.service.DeleteOrder(ctx, idInt)
 	if err != nil {
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not deleted", "order_id", idInt)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
 		requestLogger.WithError(err).Error("Failed to delete order", "order_id", idInt)
-		return c.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"message": err.Error(),
-		})
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
 	}
 
 	requestLogger.Info("Order deleted successfully", "order_id", idInt)
@@ -244,10 +573,85 @@ func (h *OrderHandler) ListOrders(c *fiber.Ctx) error {
 		})
 	}
 
-	orders, err := h.service.ListOrders(ctx, models.ListInput{
+	fields, err := parseFieldsParam(c.Query("fields"))
+	if err != nil {
+		requestLogger.WithError(err).Error("Invalid fields parameter", "fields", c.Query("fields"))
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	listInput := models.ListInput{
 		Page: pageInt,
 		Size: sizeInt,
-	})
+	}
+	if updatedSince := c.Query("updated_since"); updatedSince != "" {
+		since, err := time.Parse(time.RFC3339, updatedSince)
+		if err != nil {
+			requestLogger.WithError(err).Error("Invalid updated_since parameter", "updated_since", updatedSince)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid updated_since, expected RFC3339 timestamp",
+			})
+		}
+		listInput.UpdatedSince = &since
+	}
+	statuses, err := parseStatusFilter(c)
+	if err != nil {
+		requestLogger.WithError(err).Warn("Invalid status parameter")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	listInput.Statuses = statuses
+	if c.QueryBool("mine") {
+		userID, ok := middleware.UserID(c)
+		if !ok {
+			requestLogger.Warn("mine=true sent without an authenticated user")
+			return c.Status(fiber.ErrUnauthorized.Code).JSON(fiber.Map{
+				"message": "mine=true requires an authenticated user",
+			})
+		}
+		listInput.UserID = userID
+	}
+	if token := c.Query("cursor"); token != "" {
+		cursor, err := models.DecodeCursor(token)
+		if err != nil {
+			requestLogger.WithError(err).Warn("Invalid cursor parameter")
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid cursor",
+			})
+		}
+		// Filters sent with a cursor must be the ones it was issued for; omitted filters are restored from it
+		if (c.Query("updated_since") != "" || listInput.UserID != "" || len(listInput.Statuses) > 0) && !cursor.Matches(listInput) {
+			requestLogger.Warn("Cursor used with different filters", "cursor_sort", cursor.Sort, "sort", listInput.SortOrder())
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "cursor/filter mismatch",
+			})
+		}
+		// A cursor scoped to a user is only honored for that user, so a leaked token does not list
+		// someone else's orders
+		if cursor.UserID != "" {
+			userID, ok := middleware.UserID(c)
+			if !ok {
+				requestLogger.Warn("User cursor sent without an authenticated user")
+				return c.Status(fiber.ErrUnauthorized.Code).JSON(fiber.Map{
+					"message": "cursor requires an authenticated user",
+				})
+			}
+			if userID != cursor.UserID {
+				requestLogger.Warn("Cursor issued to another user", "user_id", userID)
+				return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+					"message": "cursor was issued to another user",
+				})
+			}
+		}
+		listInput.UpdatedSince = cursor.UpdatedSince
+		listInput.UserID = cursor.UserID
+		listInput.Statuses = cursor.Statuses
+		listInput.After = &cursor
+	}
+
+	orders, err := h.service.ListOrders(ctx, listInput)
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			requestLogger.Warn("No orders found", "page", pageInt, "size", sizeInt)
@@ -256,11 +660,149 @@ func (h *OrderHandler) ListOrders(c *fiber.Ctx) error {
 			})
 		}
 
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, orders not listed", "page", pageInt, "size", sizeInt)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+
 		requestLogger.WithError(err).Error("Failed to list orders", "page", pageInt, "size", sizeInt)
-		return c.Status(fiber.ErrInternalServerError.Code).JSON(fiber.Map{
-			"message": err.Error(),
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	// Page links do not apply when continuing from a cursor
+	if listInput.After == nil {
+		c.Set(fiber.HeaderLink, paginationLinkHeader(c, orders.Page, orders.TotalPages))
+	}
+
+	if fields != nil {
+		selected, err := selectOrdersFields(orders.Data, fields)
+		if err != nil {
+			requestLogger.WithError(err).Error("Failed to select order fields", "page", pageInt, "size", sizeInt)
+			return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+		}
+		return c.JSON(models.ListPaginated[map[string]any]{
+			Data:       selected,
+			Total:      orders.Total,
+			Page:       orders.Page,
+			Size:       orders.Size,
+			TotalPages: orders.TotalPages,
+			NextCursor: orders.NextCursor,
 		})
 	}
 
 	return c.JSON(orders)
 }
+
+// parseStatusFilter reads the status query parameter, which may be repeated or comma-separated as in
+// ?status=pending&status=processing or ?status=pending,processing. Repeated statuses are kept once
+// and the result is sorted, so the same filter in any order continues the same cursor.
+func parseStatusFilter(c *fiber.Ctx) ([]models.Status, error) {
+	var statuses []models.Status
+	for _, value := range c.Request().URI().QueryArgs().PeekMulti("status") {
+		for _, name := range strings.Split(string(value), ",") {
+			status := models.Status(strings.TrimSpace(name))
+			if status == "" {
+				continue
+			}
+			if !status.IsValid() {
+				return nil, fmt.Errorf("invalid status %q", status)
+			}
+			if !slices.Contains(statuses, status) {
+				statuses = append(statuses, status)
+			}
+		}
+	}
+	slices.Sort(statuses)
+	return statuses, nil
+}
+
+// RecentOrders returns the newest orders without items or pagination metadata
+func (h *OrderHandler) RecentOrders(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	limit := c.Query("limit", strconv.Itoa(models.DefaultRecentOrdersLimit))
+
+	limitInt, err := strconv.Atoi(limit)
+	if err != nil || limitInt < 1 {
+		requestLogger.Error("Invalid limit parameter", "limit", limit)
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": "Invalid limit number",
+		})
+	}
+
+	orders, err := h.service.RecentOrders(ctx, limitInt)
+	if err != nil {
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, recent orders not fetched")
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to get recent orders", "limit", limitInt)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"data": orders,
+	})
+}
+
+func (h *OrderHandler) DailyTotals(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	// Default to the last 30 days including today
+	to := time.Now().UTC()
+	if toParam := c.Query("to"); toParam != "" {
+		parsed, err := time.Parse(models.DailyTotalsDateLayout, toParam)
+		if err != nil {
+			requestLogger.WithError(err).Error("Invalid to parameter", "to", toParam)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid to date, expected YYYY-MM-DD",
+			})
+		}
+		to = parsed
+	}
+	from := to.AddDate(0, 0, -29)
+	if fromParam := c.Query("from"); fromParam != "" {
+		parsed, err := time.Parse(models.DailyTotalsDateLayout, fromParam)
+		if err != nil {
+			requestLogger.WithError(err).Error("Invalid from parameter", "from", fromParam)
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": "Invalid from date, expected YYYY-MM-DD",
+			})
+		}
+		from = parsed
+	}
+
+	if to.Before(from) {
+		requestLogger.Error("Invalid daily totals range", "from", from, "to", to)
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": "from must not be after to",
+		})
+	}
+	if to.Sub(from) >= time.Duration(models.MaxDailyTotalsDays)*24*time.Hour {
+		requestLogger.Error("Daily totals range too large", "from", from, "to", to)
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": fmt.Sprintf("date range must not exceed %d days", models.MaxDailyTotalsDays),
+		})
+	}
+
+	totals, err := h.service.GetDailyTotals(ctx, models.DailyTotalsInput{From: from, To: to})
+	if err != nil {
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, daily totals not fetched")
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to get daily totals", "from", from, "to", to)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	return c.JSON(fiber.Map{
+		"data": totals,
+	})
+}
@@ -0,0 +1,79 @@
+package v1
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// errInvalidOrderID is returned for an :id path parameter that is not an ID under the ID strategy
+var errInvalidOrderID = errors.New("invalid order ID")
+
+// orderIDParam returns the serial ID of the order named by the :id path parameter. With the uuid
+// ID strategy the parameter must be a public ID, which is resolved to the serial ID; integers
+// are rejected so orders cannot be enumerated. Under order ownership an order of another user
+// returns errOrderForbidden.
+func (h *OrderHandler) orderIDParam(c *fiber.Ctx) (int, error) {
+	id, err := h.resolveOrderIDParam(c)
+	if err != nil {
+		return 0, err
+	}
+	if err := h.checkOrderAccess(c, id); err != nil {
+		return 0, err
+	}
+	return id, nil
+}
+
+// resolveOrderIDParam turns the :id path parameter into a serial ID under the ID strategy
+func (h *OrderHandler) resolveOrderIDParam(c *fiber.Ctx) (int, error) {
+	ref := c.Params("id")
+	if models.CurrentIDStrategy() != models.IDStrategyUUID {
+		id, err := strconv.Atoi(ref)
+		if err != nil {
+			return 0, errInvalidOrderID
+		}
+		return id, nil
+	}
+
+	publicID, ok := models.ParseOrderPublicID(ref)
+	if !ok {
+		return 0, errInvalidOrderID
+	}
+	return h.service.GetOrderIDByPublicID(c.UserContext(), publicID)
+}
+
+// orderIDParamError writes the response for an error returned by orderIDParam
+func orderIDParamError(c *fiber.Ctx, err error) error {
+	requestLogger := logger.LoggerWithRequestIDFromContext(c.UserContext())
+	switch {
+	case errors.Is(err, errInvalidOrderID):
+		requestLogger.Error("Invalid Order ID format", "id", c.Params("id"))
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": "Invalid Order ID",
+		})
+	case errors.Is(err, errOrderForbidden):
+		userID, _ := middleware.UserID(c)
+		requestLogger.Warn("Order belongs to another user", "id", c.Params("id"), "user_id", userID)
+		return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+			"message": "Order belongs to another user",
+		})
+	case errors.Is(err, pgx.ErrNoRows):
+		requestLogger.Warn("Order not found", "id", c.Params("id"))
+		return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+			"message": "Order not found",
+		})
+	}
+	if message, ok := serviceUnavailableMessage(err); ok {
+		requestLogger.WithError(err).Warn("Service unavailable, order ID not resolved", "id", c.Params("id"))
+		return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+			"message": message,
+		})
+	}
+	requestLogger.WithError(err).Error("Failed to resolve order ID", "id", c.Params("id"))
+	return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+}
@@ -0,0 +1,166 @@
+package v1
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testPublicID = "3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b"
+
+// useIDStrategy sets the order ID strategy for the duration of the test
+func useIDStrategy(t *testing.T, strategy models.IDStrategy) {
+	models.SetIDStrategy(strategy)
+	t.Cleanup(func() { models.SetIDStrategy(models.IDStrategySerial) })
+}
+
+func newOrderRefApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+	app.Get("/orders/:id", handler.GetOrder)
+	app.Post("/orders/:id/cancel", handler.CancelOrder)
+	return app
+}
+
+func TestOrderHandler_CreateOrder_UUIDStrategy(t *testing.T) {
+	// Arrange
+	useIDStrategy(t, models.IDStrategyUUID)
+	mockService := &MockOrderService{}
+	app := newOrderRefApp(mockService)
+	created := models.OrderWithItems{
+		Order: models.Order{ID: 42, PublicID: testPublicID, Status: models.StatusPending},
+		Items: []models.OrderItem{{ID: 7, OrderID: 42, ProductName: "Product 1", Quantity: 1, Price: 5}},
+	}
+	mockService.On("CreateOrder", mock.Anything, mock.Anything).Return(created, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(`{"customer_name":"Jane","items":[{"product_name":"Product 1","quantity":1,"price":5}]}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "http://example.com/orders/"+testPublicID, resp.Header.Get("Location"))
+
+	var body struct {
+		Data  map[string]any    `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, testPublicID, body.Data["id"])
+	assert.Equal(t, "http://example.com/orders/"+testPublicID, body.Links["self"])
+	items := body.Data["items"].([]any)
+	assert.NotContains(t, items[0], "order_id")
+}
+
+func TestOrderHandler_GetOrder_UUIDStrategy(t *testing.T) {
+	// Arrange
+	useIDStrategy(t, models.IDStrategyUUID)
+	mockService := &MockOrderService{}
+	app := newOrderRefApp(mockService)
+	order := models.OrderWithItems{Order: models.Order{ID: 42, PublicID: testPublicID, Status: models.StatusPending}}
+	mockService.On("GetOrderByPublicID", mock.Anything, testPublicID).Return(order, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/"+testPublicID, nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	var body struct {
+		Data  map[string]any    `json:"data"`
+		Links map[string]string `json:"links"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, testPublicID, body.Data["id"])
+	assert.Equal(t, "http://example.com/orders/"+testPublicID, body.Links["self"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_UUIDStrategyRejectsSerialID(t *testing.T) {
+	// Arrange
+	useIDStrategy(t, models.IDStrategyUUID)
+	mockService := &MockOrderService{}
+	app := newOrderRefApp(mockService)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetOrderById", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_GetOrder_SerialStrategyRejectsUUID(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newOrderRefApp(mockService)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/"+testPublicID, nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetOrderByPublicID", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_CancelOrder_UUIDStrategyResolvesPublicID(t *testing.T) {
+	// Arrange
+	useIDStrategy(t, models.IDStrategyUUID)
+	mockService := &MockOrderService{}
+	app := newOrderRefApp(mockService)
+	cancelled := models.OrderWithItems{Order: models.Order{ID: 42, PublicID: testPublicID, Status: models.StatusCancelled}}
+	mockService.On("GetOrderIDByPublicID", mock.Anything, testPublicID).Return(42, nil)
+	mockService.On("CancelOrder", mock.Anything, 42).Return(cancelled, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/orders/"+testPublicID+"/cancel", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CancelOrder_UUIDStrategyErrors(t *testing.T) {
+	useIDStrategy(t, models.IDStrategyUUID)
+	unknownID := "00000000-0000-4000-8000-000000000000"
+
+	cases := []struct {
+		name       string
+		ref        string
+		wantStatus int
+	}{
+		{name: "serial ID", ref: "42", wantStatus: http.StatusBadRequest},
+		{name: "unknown public ID", ref: unknownID, wantStatus: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newOrderRefApp(mockService)
+			mockService.On("GetOrderIDByPublicID", mock.Anything, unknownID).Return(0, pgx.ErrNoRows)
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/orders/"+tc.ref+"/cancel", nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			mockService.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -5,12 +5,20 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
+	"io"
+	"math"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
+	"github.com/Testzyler/order-management-go/application/domain"
 	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/database"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 )
@@ -20,9 +28,9 @@ type MockOrderService struct {
 	mock.Mock
 }
 
-func (m *MockOrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) error {
+func (m *MockOrderService) CreateOrder(ctx context.Context, input models.CreateOrderInput) (models.OrderWithItems, error) {
 	args := m.Called(ctx, input)
-	return args.Error(0)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
 }
 
 func (m *MockOrderService) GetOrderById(ctx context.Context, id int) (models.OrderWithItems, error) {
@@ -30,6 +38,21 @@ func (m *MockOrderService) GetOrderById(ctx context.Context, id int) (models.Ord
 	return args.Get(0).(models.OrderWithItems), args.Error(1)
 }
 
+func (m *MockOrderService) GetOrderByNumber(ctx context.Context, orderNumber string) (models.OrderWithItems, error) {
+	args := m.Called(ctx, orderNumber)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrderByPublicID(ctx context.Context, publicID string) (models.OrderWithItems, error) {
+	args := m.Called(ctx, publicID)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) GetOrderIDByPublicID(ctx context.Context, publicID string) (int, error) {
+	args := m.Called(ctx, publicID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockOrderService) UpdateOrder(ctx context.Context, input models.UpdateOrderInput) error {
 	args := m.Called(ctx, input)
 	return args.Error(0)
@@ -45,6 +68,85 @@ func (m *MockOrderService) ListOrders(ctx context.Context, input models.ListInpu
 	return args.Get(0).(models.ListPaginatedOrders), args.Error(1)
 }
 
+func (m *MockOrderService) ExpirePendingOrders(ctx context.Context, maxAge time.Duration) (int64, error) {
+	args := m.Called(ctx, maxAge)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderService) RecentOrders(ctx context.Context, limit int) ([]models.Order, error) {
+	args := m.Called(ctx, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.Order), args.Error(1)
+}
+
+func (m *MockOrderService) AddOrderNote(ctx context.Context, input models.CreateNoteInput) (models.OrderNote, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(models.OrderNote), args.Error(1)
+}
+
+func (m *MockOrderService) ListOrderNotes(ctx context.Context, orderID int) ([]models.OrderNote, error) {
+	args := m.Called(ctx, orderID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.OrderNote), args.Error(1)
+}
+
+func (m *MockOrderService) CancelOrder(ctx context.Context, id int) (models.OrderWithItems, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) PatchOrder(ctx context.Context, id int, input models.PatchOrderInput) (models.OrderWithItems, error) {
+	args := m.Called(ctx, id, input)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) UpsertOrderByNumber(ctx context.Context, orderNumber string, input models.CreateOrderInput) (models.OrderWithItems, bool, error) {
+	args := m.Called(ctx, orderNumber, input)
+	return args.Get(0).(models.OrderWithItems), args.Bool(1), args.Error(2)
+}
+
+func (m *MockOrderService) GetDailyTotals(ctx context.Context, input models.DailyTotalsInput) ([]models.DailyOrderTotal, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).([]models.DailyOrderTotal), args.Error(1)
+}
+
+func (m *MockOrderService) MergePatchOrder(ctx context.Context, id int, patch []byte, ifMatch string) (models.OrderWithItems, error) {
+	args := m.Called(ctx, id, patch, ifMatch)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) DeleteOrdersByFilter(ctx context.Context, filter models.DeleteOrdersFilter) (int64, error) {
+	args := m.Called(ctx, filter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MockOrderService) UpdateItemStatus(ctx context.Context, input models.UpdateItemStatusInput) (models.OrderWithItems, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(models.OrderWithItems), args.Error(1)
+}
+
+func (m *MockOrderService) CreateOrders(ctx context.Context, orders []models.CreateOrderInput, atomic bool) (models.BatchCreateResult, error) {
+	args := m.Called(ctx, orders, atomic)
+	return args.Get(0).(models.BatchCreateResult), args.Error(1)
+}
+
+func (m *MockOrderService) BulkCreateOrders(ctx context.Context, orders []models.CreateOrderInput, atomic bool) ([]models.BulkOrderResult, error) {
+	args := m.Called(ctx, orders, atomic)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]models.BulkOrderResult), args.Error(1)
+}
+
+func (m *MockOrderService) ShipOrder(ctx context.Context, input models.ShipOrderInput) (models.ShipOrderResult, error) {
+	args := m.Called(ctx, input)
+	return args.Get(0).(models.ShipOrderResult), args.Error(1)
+}
+
 func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 	// Arrange
 	mockService := &MockOrderService{}
@@ -65,8 +167,26 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 		},
 	}
 
+	createdOrder := models.OrderWithItems{
+		Order: models.Order{
+			ID:           42,
+			CustomerName: "John Doe",
+			TotalAmount:  100.50,
+			Status:       models.StatusPending,
+		},
+		Items: []models.OrderItem{
+			{
+				ID:          7,
+				OrderID:     42,
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
 	requestBody, _ := json.Marshal(orderInput)
-	mockService.On("CreateOrder", mock.Anything, orderInput).Return(nil)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(createdOrder, nil)
 
 	// Act
 	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
@@ -76,6 +196,14 @@ func TestOrderHandler_CreateOrder_Success(t *testing.T) {
 	// Assert
 	assert.NoError(t, err)
 	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "http://example.com/orders/42", resp.Header.Get("Location"))
+
+	var body struct {
+		Data models.OrderWithItems `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, 42, body.Data.ID)
+	assert.Len(t, body.Data.Items, 1)
 	mockService.AssertExpectations(t)
 }
 
@@ -101,6 +229,31 @@ func TestOrderHandler_CreateOrder_BadRequest(t *testing.T) {
 	mockService.AssertNotCalled(t, "CreateOrder")
 }
 
+func TestOrderHandler_CreateOrder_ItemsObject(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	body := `{"customer_name": "John Doe", "items": {"product_name": "Product 1", "quantity": 2, "price": 50.25}}`
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader([]byte(body)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+
+	var response map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&response))
+	assert.Equal(t, models.ErrItemsNotArray.Error(), response["message"])
+	mockService.AssertNotCalled(t, "CreateOrder")
+}
+
 func TestOrderHandler_CreateOrder_ServiceError(t *testing.T) {
 	// Arrange
 	mockService := &MockOrderService{}
@@ -122,7 +275,7 @@ func TestOrderHandler_CreateOrder_ServiceError(t *testing.T) {
 	}
 
 	requestBody, _ := json.Marshal(orderInput)
-	mockService.On("CreateOrder", mock.Anything, orderInput).Return(errors.New("service error"))
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, errors.New("service error"))
 
 	// Act
 	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
@@ -135,6 +288,202 @@ func TestOrderHandler_CreateOrder_ServiceError(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
+func TestOrderHandler_CreateOrder_ReadOnlyDatabase(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items: []models.OrderItem{
+			{
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, fmt.Errorf("%w: read-only transaction", domain.ErrReadOnly))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_TotalMismatch(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		TotalAmount:  99.99,
+		Items: []models.OrderItem{
+			{
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, fmt.Errorf("%w: provided 99.99, computed 100.50", domain.ErrTotalMismatch))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_QuotaExceeded(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items:        []models.OrderItem{{ProductName: "Product 1", Quantity: 1, Price: 10}},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, fmt.Errorf("%w: at most 100 orders per 1h0m0s", domain.ErrQuotaExceeded))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_DuplicateItems(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items: []models.OrderItem{
+			{ProductName: "Widget", Quantity: 1, Price: 10},
+			{ProductName: "Widget", Quantity: 2, Price: 10},
+		},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, &domain.DuplicateItemsError{ProductNames: []string{"Widget"}})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	var body struct {
+		Message    string   `json:"message"`
+		Duplicates []string `json:"duplicates"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "duplicate items: Widget", body.Message)
+	assert.Equal(t, []string{"Widget"}, body.Duplicates)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_TotalOutOfRange(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items:        []models.OrderItem{{ProductName: "Product 1", Quantity: 1000000, Price: 100}},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, fmt.Errorf("%w: total 1e+08 exceeds 99999999.99", domain.ErrTotalOutOfRange))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_CreateOrder_ItemSubtotalOutOfRange(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
+		CustomerName: "John Doe",
+		Items: []models.OrderItem{
+			{ProductName: "Product 1", Quantity: 1, Price: 10},
+			{ProductName: "Product 2", Quantity: math.MaxInt64, Price: 100},
+		},
+	}
+
+	requestBody, _ := json.Marshal(orderInput)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, &domain.FieldError{
+		Field: "items[1]",
+		Err:   fmt.Errorf("%w: subtotal 9.2e+20 exceeds 99999999.99", domain.ErrTotalOutOfRange),
+	})
+
+	// Act
+	req := httptest.NewRequest(http.MethodPost, "/orders", bytes.NewReader(requestBody))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "items[1]", body["field"])
+	assert.Contains(t, body["message"], "subtotal")
+	mockService.AssertExpectations(t)
+}
+
 func TestOrderHandler_GetOrder_Success(t *testing.T) {
 	// Arrange
 	mockService := &MockOrderService{}
@@ -173,7 +522,7 @@ func TestOrderHandler_GetOrder_Success(t *testing.T) {
 	mockService.AssertExpectations(t)
 }
 
-func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
+func TestOrderHandler_GetOrder_ShuttingDown(t *testing.T) {
 	// Arrange
 	mockService := &MockOrderService{}
 	handler := &OrderHandler{service: mockService}
@@ -181,25 +530,729 @@ func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
 	app := fiber.New()
 	app.Get("/orders/:id", handler.GetOrder)
 
+	mockService.On("GetOrderById", mock.Anything, 1).Return(models.OrderWithItems{}, fmt.Errorf("failed to query order: %w", database.ErrPoolClosed))
+
 	// Act
-	req := httptest.NewRequest(http.MethodGet, "/orders/invalid", nil)
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
 	resp, err := app.Test(req)
 
 	// Assert
 	assert.NoError(t, err)
-	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
-	mockService.AssertNotCalled(t, "GetOrderById")
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	mockService.AssertExpectations(t)
 }
 
-// Benchmark tests for HTTP handlers
-func BenchmarkOrderHandler_CreateOrder(b *testing.B) {
+func TestOrderHandler_GetOrder_InternalErrorTerse(t *testing.T) {
+	// Arrange
+	SetExposeErrorDetails(false)
+
 	mockService := &MockOrderService{}
 	handler := &OrderHandler{service: mockService}
 
 	app := fiber.New()
-	app.Post("/orders", handler.CreateOrder)
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("request_id", "req-123")
+		return c.Next()
+	})
+	app.Get("/orders/:id", handler.GetOrder)
 
-	orderInput := models.CreateOrderInput{
+	mockService.On("GetOrderById", mock.Anything, 1).Return(models.OrderWithItems{}, errors.New("failed to query order: dial tcp 10.0.0.5:5432"))
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, internalErrorMessage, body["message"])
+	assert.Equal(t, "req-123", body["request_id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_InternalErrorCode(t *testing.T) {
+	// Arrange
+	SetExposeErrorDetails(false)
+
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("request_id", "req-123")
+		return c.Next()
+	})
+	app.Get("/orders/:id", handler.GetOrder)
+
+	pgErr := &pgconn.PgError{Code: "57014", Message: "canceling statement due to statement timeout"}
+	mockService.On("GetOrderById", mock.Anything, 1).Return(models.OrderWithItems{}, fmt.Errorf("failed to query order: %w", pgErr))
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]string{
+		"message":    internalErrorMessage,
+		"request_id": "req-123",
+		"code":       database.ErrorCodeTimeout,
+	}, body)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_InternalErrorVerbose(t *testing.T) {
+	// Arrange
+	SetExposeErrorDetails(true)
+	defer SetExposeErrorDetails(false)
+
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+
+	mockService.On("GetOrderById", mock.Anything, 1).Return(models.OrderWithItems{}, errors.New("failed to query order: dial tcp 10.0.0.5:5432"))
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/1", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusInternalServerError, resp.StatusCode)
+
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "failed to query order: dial tcp 10.0.0.5:5432", body["message"])
+	_, hasRequestID := body["request_id"]
+	assert.False(t, hasRequestID)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_PatchOrder_MergePatch(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Patch("/orders/:id", handler.PatchOrder)
+
+	patch := []byte(`{"status":"processing"}`)
+	patched := models.OrderWithItems{Order: models.Order{ID: 1, CustomerName: "John Doe", Status: models.StatusProcessing}}
+	mockService.On("MergePatchOrder", mock.Anything, 1, patch, "").Return(patched, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader(patch))
+	req.Header.Set("Content-Type", "application/merge-patch+json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_PatchOrder_UnsupportedContentType(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Patch("/orders/:id", handler.PatchOrder)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader([]byte(`{"status":"processing"}`)))
+	req.Header.Set("Content-Type", "text/plain")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnsupportedMediaType, resp.StatusCode)
+	mockService.AssertNotCalled(t, "MergePatchOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_PatchOrder_InvalidPatch(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Patch("/orders/:id", handler.PatchOrder)
+
+	patch := []byte(`{"customer_name":null}`)
+	mockService.On("MergePatchOrder", mock.Anything, 1, patch, "").Return(models.OrderWithItems{}, fmt.Errorf("%w: customer name is required", domain.ErrInvalidPatch))
+
+	// Act
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader(patch))
+	req.Header.Set("Content-Type", "application/merge-patch+json; charset=utf-8")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnprocessableEntity, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_PatchOrder_JSON(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Patch("/orders/:id", handler.PatchOrder)
+
+	name := "Jane Roe"
+	patched := models.OrderWithItems{Order: models.Order{ID: 1, CustomerName: name, Status: models.StatusPending}}
+	mockService.On("PatchOrder", mock.Anything, 1, models.PatchOrderInput{CustomerName: &name}).Return(patched, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader([]byte(`{"customer_name":"Jane Roe"}`)))
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+	mockService.AssertNotCalled(t, "MergePatchOrder", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_PatchOrder_JSONErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "malformed body", body: `{"status":`, expectedStatus: http.StatusBadRequest},
+		{name: "invalid transition", body: `{"status":"completed"}`, serviceErr: fmt.Errorf("%w: pending to completed", domain.ErrInvalidStatusTransition), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "no fields", body: `{}`, serviceErr: fmt.Errorf("%w: customer_name or status is required", domain.ErrInvalidPatch), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "unknown order", body: `{"status":"processing"}`, serviceErr: pgx.ErrNoRows, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Patch("/orders/:id", handler.PatchOrder)
+			mockService.On("PatchOrder", mock.Anything, 1, mock.Anything).Return(models.OrderWithItems{}, tt.serviceErr).Maybe()
+
+			// Act
+			req := httptest.NewRequest(http.MethodPatch, "/orders/1", bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_InvalidID(t *testing.T) {
+	for _, ref := range []string{"invalid", "0", "-3", "ORD-2025-XYZ"} {
+		t.Run(ref, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Get("/orders/:id", handler.GetOrder)
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+ref, nil)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			mockService.AssertNotCalled(t, "GetOrderById", mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "GetOrderByNumber", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_ByOrderNumber(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+
+	expectedOrder := models.OrderWithItems{
+		Order: models.Order{ID: 7, OrderNumber: "ORD-20250601-K7QX2M", CustomerName: "John Doe", Status: models.StatusPending},
+	}
+	mockService.On("GetOrderByNumber", mock.Anything, "ORD-20250601-K7QX2M").Return(expectedOrder, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/ORD-20250601-K7QX2M", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "ORD-20250601-K7QX2M", body.Data["order_number"])
+	mockService.AssertNotCalled(t, "GetOrderById", mock.Anything, mock.Anything)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_NotFound(t *testing.T) {
+	tests := []struct {
+		ref    string
+		method string
+		arg    any
+	}{
+		{ref: "42", method: "GetOrderById", arg: 42},
+		{ref: "ORD-20250601-K7QX2M", method: "GetOrderByNumber", arg: "ORD-20250601-K7QX2M"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Get("/orders/:id", handler.GetOrder)
+
+			mockService.On(tt.method, mock.Anything, tt.arg).Return(models.OrderWithItems{}, pgx.ErrNoRows)
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/orders/"+tt.ref, nil)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_FieldSelection(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+
+	expectedOrder := models.OrderWithItems{
+		Order: models.Order{
+			ID:           1,
+			CustomerName: "John Doe",
+			TotalAmount:  100.50,
+			Status:       models.StatusPending,
+		},
+		Items: []models.OrderItem{
+			{
+				ID:          1,
+				OrderID:     1,
+				ProductName: "Product 1",
+				Quantity:    2,
+				Price:       50.25,
+			},
+		},
+	}
+
+	mockService.On("GetOrderById", mock.Anything, 1).Return(expectedOrder, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/1?fields=id,status", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, float64(1), body.Data["id"])
+	assert.Equal(t, "pending", body.Data["status"])
+	assert.NotContains(t, body.Data, "items")
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_PartialResultKeepsWarnings(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+
+	partial := models.OrderWithItems{
+		Order:    models.Order{ID: 1, CustomerName: "John Doe", Status: models.StatusPending},
+		Items:    []models.OrderItem{},
+		Warnings: []string{"items could not be loaded"},
+	}
+	mockService.On("GetOrderById", mock.Anything, 1).Return(partial, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/1?fields=id", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, []any{"items could not be loaded"}, body.Data["warnings"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_FieldsKeepTruncatedItemMarkers(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders", handler.ListOrders)
+
+	truncated := models.OrderWithItems{
+		Order:     models.Order{ID: 1, Status: models.StatusPending},
+		Items:     []models.OrderItem{{ID: 1, OrderID: 1, ProductName: "Product 1", Quantity: 1, Price: 1}},
+		MoreItems: true,
+		ItemCount: 8,
+	}
+	mockService.On("ListOrders", mock.Anything, mock.Anything).Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{truncated}, Page: 1, Size: 10}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders?fields=id,items", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	if assert.Len(t, body.Data, 1) {
+		assert.Equal(t, true, body.Data[0]["more_items"])
+		assert.Equal(t, float64(8), body.Data[0]["item_count"])
+	}
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_GetOrder_InvalidFields(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/:id", handler.GetOrder)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/1?fields=id,password", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetOrderById")
+}
+
+func TestOrderHandler_ListOrders_UpdatedSince(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders", handler.ListOrders)
+
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	mockService.On("ListOrders", mock.Anything, mock.MatchedBy(func(input models.ListInput) bool {
+		return input.UpdatedSince != nil && input.UpdatedSince.Equal(since)
+	})).Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 1, Size: 10}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders?updated_since=2025-01-02T03:04:05Z", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_LinkHeader(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/api/v1/orders", handler.ListOrders)
+
+	mockService.On("ListOrders", mock.Anything, mock.Anything).
+		Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Total: 50, Page: 3, Size: 10, TotalPages: 5}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "http://shop.example.com/api/v1/orders?size=10&page=3&fields=id", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, `<http://shop.example.com/api/v1/orders?fields=id&page=1&size=10>; rel="first", `+
+		`<http://shop.example.com/api/v1/orders?fields=id&page=2&size=10>; rel="prev", `+
+		`<http://shop.example.com/api/v1/orders?fields=id&page=4&size=10>; rel="next", `+
+		`<http://shop.example.com/api/v1/orders?fields=id&page=5&size=10>; rel="last"`, resp.Header.Get("Link"))
+}
+
+func TestOrderHandler_ListOrders_Statuses(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expected       []models.Status
+	}{
+		{name: "repeated", query: "status=pending&status=processing", expectedStatus: http.StatusOK, expected: []models.Status{models.StatusPending, models.StatusProcessing}},
+		{name: "comma separated", query: "status=pending,processing,pending", expectedStatus: http.StatusOK, expected: []models.Status{models.StatusPending, models.StatusProcessing}},
+		{name: "sorted", query: "status=processing,cancelled&status=pending", expectedStatus: http.StatusOK, expected: []models.Status{models.StatusCancelled, models.StatusPending, models.StatusProcessing}},
+		{name: "one invalid", query: "status=pending&status=processing,shipped", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Get("/orders", handler.ListOrders)
+
+			mockService.On("ListOrders", mock.Anything, mock.MatchedBy(func(input models.ListInput) bool {
+				return assert.ObjectsAreEqual(tt.expected, input.Statuses)
+			})).Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 1, Size: 10}, nil)
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/orders?"+tt.query, nil)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.AssertExpectations(t)
+				return
+			}
+			var body map[string]string
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Equal(t, `invalid status "shipped"`, body["message"])
+			mockService.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrders_InvalidUpdatedSince(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders", handler.ListOrders)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders?updated_since=yesterday", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "ListOrders")
+}
+
+func TestOrderHandler_ListOrders_Cursor(t *testing.T) {
+	since := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	withSince := models.NewListCursor(models.ListInput{UpdatedSince: &since}, models.Order{ID: 7, UpdatedAt: since.Add(time.Hour)}).Encode()
+	withoutFilters := models.NewListCursor(models.ListInput{}, models.Order{ID: 7, CreatedAt: since}).Encode()
+	withStatuses := models.NewListCursor(models.ListInput{Statuses: []models.Status{models.StatusPending, models.StatusProcessing}}, models.Order{ID: 7, CreatedAt: since}).Encode()
+
+	tests := []struct {
+		name           string
+		query          string
+		expectedStatus int
+		expectedSince  *time.Time
+		expectedBody   string
+	}{
+		{name: "filters restored from cursor", query: "?cursor=" + withSince, expectedStatus: http.StatusOK, expectedSince: &since},
+		{name: "matching filters", query: "?updated_since=2025-01-02T03:04:05Z&cursor=" + withSince, expectedStatus: http.StatusOK, expectedSince: &since},
+		{name: "no filters", query: "?cursor=" + withoutFilters, expectedStatus: http.StatusOK},
+		{name: "statuses in another order", query: "?status=processing&status=pending&cursor=" + withStatuses, expectedStatus: http.StatusOK},
+		{name: "different updated_since", query: "?updated_since=2025-02-01T00:00:00Z&cursor=" + withSince, expectedStatus: http.StatusBadRequest, expectedBody: "cursor/filter mismatch"},
+		{name: "filter added to unfiltered cursor", query: "?updated_since=2025-01-02T03:04:05Z&cursor=" + withoutFilters, expectedStatus: http.StatusBadRequest, expectedBody: "cursor/filter mismatch"},
+		{name: "malformed cursor", query: "?cursor=not-a-cursor", expectedStatus: http.StatusBadRequest, expectedBody: "Invalid cursor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Get("/orders", handler.ListOrders)
+
+			mockService.On("ListOrders", mock.Anything, mock.MatchedBy(func(input models.ListInput) bool {
+				if input.After == nil || input.After.LastID != 7 {
+					return false
+				}
+				if tt.expectedSince == nil {
+					return input.UpdatedSince == nil
+				}
+				return input.UpdatedSince != nil && input.UpdatedSince.Equal(*tt.expectedSince)
+			})).Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 1, Size: 10}, nil).Maybe()
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/orders"+tt.query, nil)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus == http.StatusOK {
+				mockService.AssertNumberOfCalls(t, "ListOrders", 1)
+			} else {
+				body, _ := io.ReadAll(resp.Body)
+				assert.Contains(t, string(body), tt.expectedBody)
+				mockService.AssertNotCalled(t, "ListOrders")
+			}
+		})
+	}
+}
+
+func TestOrderHandler_RecentOrders(t *testing.T) {
+	tests := []struct {
+		name           string
+		query          string
+		expectedLimit  int
+		expectedStatus int
+	}{
+		{name: "default limit", query: "", expectedLimit: models.DefaultRecentOrdersLimit, expectedStatus: http.StatusOK},
+		{name: "explicit limit", query: "?limit=5", expectedLimit: 5, expectedStatus: http.StatusOK},
+		{name: "not a number", query: "?limit=ten", expectedStatus: http.StatusBadRequest},
+		{name: "zero", query: "?limit=0", expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Get("/orders/recent", handler.RecentOrders)
+
+			mockService.On("RecentOrders", mock.Anything, tt.expectedLimit).Return([]models.Order{{ID: 2}, {ID: 1}}, nil).Maybe()
+
+			// Act
+			req := httptest.NewRequest(http.MethodGet, "/orders/recent"+tt.query, nil)
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			if tt.expectedStatus != http.StatusOK {
+				mockService.AssertNotCalled(t, "RecentOrders")
+				return
+			}
+
+			var body struct {
+				Data []models.Order `json:"data"`
+			}
+			assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+			assert.Len(t, body.Data, 2)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_DailyTotals_Success(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/daily", handler.DailyTotals)
+
+	input := models.DailyTotalsInput{
+		From: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		To:   time.Date(2025, 3, 2, 0, 0, 0, 0, time.UTC),
+	}
+	mockService.On("GetDailyTotals", mock.Anything, input).Return([]models.DailyOrderTotal{
+		{Date: input.From, Count: 2, Total: 10.1},
+		{Date: input.To},
+	}, nil)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/daily?from=2025-03-01&to=2025-03-02", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+
+	var body struct {
+		Data []map[string]any `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Len(t, body.Data, 2)
+	assert.Equal(t, "2025-03-01", body.Data[0]["date"])
+	assert.Equal(t, float64(0), body.Data[1]["count"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_DailyTotals_RangeTooLarge(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Get("/orders/daily", handler.DailyTotals)
+
+	// Act
+	req := httptest.NewRequest(http.MethodGet, "/orders/daily?from=2020-01-01&to=2025-01-01", nil)
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	mockService.AssertNotCalled(t, "GetDailyTotals")
+}
+
+// Benchmark tests for HTTP handlers
+func BenchmarkOrderHandler_CreateOrder(b *testing.B) {
+	mockService := &MockOrderService{}
+	handler := &OrderHandler{service: mockService}
+
+	app := fiber.New()
+	app.Post("/orders", handler.CreateOrder)
+
+	orderInput := models.CreateOrderInput{
 		CustomerName: "John Doe",
 		Status:       models.StatusPending,
 		Items: []models.OrderItem{
@@ -212,7 +1265,7 @@ func BenchmarkOrderHandler_CreateOrder(b *testing.B) {
 	}
 
 	requestBody, _ := json.Marshal(orderInput)
-	mockService.On("CreateOrder", mock.Anything, orderInput).Return(nil)
+	mockService.On("CreateOrder", mock.Anything, orderInput).Return(models.OrderWithItems{}, nil)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
@@ -221,3 +1274,44 @@ func BenchmarkOrderHandler_CreateOrder(b *testing.B) {
 		_, _ = app.Test(req)
 	}
 }
+
+func TestOrderHandler_UpdateItemStatus(t *testing.T) {
+	tests := []struct {
+		name           string
+		path           string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "success", path: "/orders/1/items/2/status", body: `{"status":"shipped"}`, expectedStatus: http.StatusOK},
+		{name: "unknown status", path: "/orders/1/items/2/status", body: `{"status":"lost"}`, serviceErr: domain.ErrInvalidItemStatus, expectedStatus: http.StatusBadRequest},
+		{name: "item not found", path: "/orders/1/items/2/status", body: `{"status":"shipped"}`, serviceErr: pgx.ErrNoRows, expectedStatus: http.StatusNotFound},
+		{name: "invalid item id", path: "/orders/1/items/abc/status", body: `{"status":"shipped"}`, expectedStatus: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			handler := &OrderHandler{service: mockService}
+
+			app := fiber.New()
+			app.Put("/orders/:id/items/:itemId/status", handler.UpdateItemStatus)
+
+			var input models.UpdateItemStatusInput
+			assert.NoError(t, json.Unmarshal([]byte(tt.body), &input))
+			input.OrderID, input.ItemID = 1, 2
+			order := models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusCompleted}}
+			mockService.On("UpdateItemStatus", mock.Anything, input).Return(order, tt.serviceErr)
+
+			// Act
+			req := httptest.NewRequest(http.MethodPut, tt.path, bytes.NewReader([]byte(tt.body)))
+			req.Header.Set("Content-Type", "application/json")
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
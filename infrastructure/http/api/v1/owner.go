@@ -0,0 +1,60 @@
+package v1
+
+import (
+	"errors"
+	"sync/atomic"
+
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// enforceOrderOwnership makes every /orders/:id route refuse other users' orders with 403
+var enforceOrderOwnership atomic.Bool
+
+// errOrderForbidden is returned by orderIDParam for an order the request's user may not access
+var errOrderForbidden = errors.New("order belongs to another user")
+
+// SetEnforceOrderOwnership sets whether users may only read and change the orders they created.
+// Admins are never restricted; requests without an authenticated user are refused.
+func SetEnforceOrderOwnership(enforce bool) {
+	enforceOrderOwnership.Store(enforce)
+}
+
+// canAccessOrder reports whether the request's user may access the order under the ownership setting
+func canAccessOrder(c *fiber.Ctx, order models.Order) bool {
+	if !enforceOrderOwnership.Load() || middleware.IsAdmin(c) {
+		return true
+	}
+	userID, ok := middleware.UserID(c)
+	return ok && order.UserID == userID
+}
+
+// checkOrderAccess loads the order when ownership is enforced and returns errOrderForbidden if
+// the request's user may not access it
+func (h *OrderHandler) checkOrderAccess(c *fiber.Ctx, id int) error {
+	if !enforceOrderOwnership.Load() || middleware.IsAdmin(c) {
+		return nil
+	}
+	if _, ok := middleware.UserID(c); !ok {
+		return errOrderForbidden
+	}
+	order, err := h.service.GetOrderById(c.UserContext(), id)
+	if err != nil {
+		return err
+	}
+	if !canAccessOrder(c, order.Order) {
+		return errOrderForbidden
+	}
+	return nil
+}
+
+// forbiddenOrder writes the 403 returned for an order owned by another user
+func forbiddenOrder(c *fiber.Ctx, order models.Order) error {
+	userID, _ := middleware.UserID(c)
+	logger.LoggerWithRequestIDFromContext(c.UserContext()).Warn("Order belongs to another user", "order_id", order.ID, "user_id", userID)
+	return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+		"message": "Order belongs to another user",
+	})
+}
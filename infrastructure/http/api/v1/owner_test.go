@@ -0,0 +1,241 @@
+package v1
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+const testAuthSecret = "test-secret-with-enough-entropy-123"
+
+// useEnforceOrderOwnership sets the ownership enforcement for the duration of the test
+func useEnforceOrderOwnership(t *testing.T, enforce bool) {
+	previous := enforceOrderOwnership.Load()
+	SetEnforceOrderOwnership(enforce)
+	t.Cleanup(func() { SetEnforceOrderOwnership(previous) })
+}
+
+// newOwnerApp serves the order routes behind AuthMiddleware, or without authentication when withAuth is false
+func newOwnerApp(service *MockOrderService, withAuth bool) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	var handlers []fiber.Handler
+	if withAuth {
+		handlers = append(handlers, middleware.AuthMiddleware(middleware.AuthConfig{Enabled: true, Secret: testAuthSecret}))
+	}
+	orders := app.Group("/orders", handlers...)
+	orders.Get("/", handler.ListOrders)
+	orders.Get("/:id", handler.GetOrder)
+	orders.Post("/:id/cancel", handler.CancelOrder)
+	orders.Delete("/:id", handler.DeleteOrder)
+	orders.Put("/by-number/:ref", handler.UpsertOrderByNumber)
+	return app
+}
+
+func ownerRequest(t *testing.T, path, userID, role string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if userID != "" {
+		token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+			"sub":  userID,
+			"role": role,
+			"exp":  time.Now().Add(time.Hour).Unix(),
+		}).SignedString([]byte(testAuthSecret))
+		assert.NoError(t, err)
+		req.Header.Set(fiber.HeaderAuthorization, "Bearer "+token)
+	}
+	return req
+}
+
+func TestOrderHandler_GetOrder_Ownership(t *testing.T) {
+	order := models.OrderWithItems{Order: models.Order{ID: 42, Status: models.StatusPending, UserID: "user-42"}}
+
+	cases := []struct {
+		name       string
+		enforce    bool
+		userID     string
+		role       string
+		wantStatus int
+	}{
+		{name: "owner", enforce: true, userID: "user-42", wantStatus: http.StatusOK},
+		{name: "other user", enforce: true, userID: "user-7", wantStatus: http.StatusForbidden},
+		{name: "admin bypass", enforce: true, userID: "user-7", role: middleware.AdminRole, wantStatus: http.StatusOK},
+		{name: "not enforced", enforce: false, userID: "user-7", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			useEnforceOrderOwnership(t, tc.enforce)
+			mockService := &MockOrderService{}
+			app := newOwnerApp(mockService, true)
+			mockService.On("GetOrderById", mock.Anything, 42).Return(order, nil)
+
+			// Act
+			resp, err := app.Test(ownerRequest(t, "/orders/42", tc.userID, tc.role))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+		})
+	}
+}
+
+func TestOrderHandler_GetOrder_OwnershipWithoutAuth(t *testing.T) {
+	// Arrange: with authentication off there is no user who could own the order
+	useEnforceOrderOwnership(t, true)
+	mockService := &MockOrderService{}
+	app := newOwnerApp(mockService, false)
+	order := models.OrderWithItems{Order: models.Order{ID: 42, Status: models.StatusPending, UserID: "user-42"}}
+	mockService.On("GetOrderById", mock.Anything, 42).Return(order, nil)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/42", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+}
+
+func TestOrderHandler_MutatingRoutes_Ownership(t *testing.T) {
+	order := models.OrderWithItems{Order: models.Order{ID: 42, Status: models.StatusPending, UserID: "user-42"}}
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+	}{
+		{name: "cancel", method: http.MethodPost, path: "/orders/42/cancel"},
+		{name: "delete", method: http.MethodDelete, path: "/orders/42"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			useEnforceOrderOwnership(t, true)
+			mockService := &MockOrderService{}
+			app := newOwnerApp(mockService, true)
+			mockService.On("GetOrderById", mock.Anything, 42).Return(order, nil)
+			req := ownerRequest(t, tc.path, "user-7", "")
+			req.Method = tc.method
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+			mockService.AssertNotCalled(t, "CancelOrder", mock.Anything, mock.Anything)
+			mockService.AssertNotCalled(t, "DeleteOrder", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOrderHandler_ListOrders_Mine(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newOwnerApp(mockService, true)
+	mockService.On("ListOrders", mock.Anything, models.ListInput{Page: 1, Size: 10, UserID: "user-42"}).
+		Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 1, Size: 10}, nil)
+
+	// Act
+	resp, err := app.Test(ownerRequest(t, "/orders?mine=true", "user-42", ""))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ListOrders_MineRequiresUser(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newOwnerApp(mockService, false)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders?mine=true", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	mockService.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything)
+}
+
+func TestOrderHandler_ListOrders_UserCursorOwnership(t *testing.T) {
+	token := models.ListCursor{Sort: models.SortCreatedDesc, UserID: "user-42", LastTime: time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC), LastID: 8}.Encode()
+
+	cases := []struct {
+		name       string
+		withAuth   bool
+		userID     string
+		wantStatus int
+	}{
+		{name: "issued user", withAuth: true, userID: "user-42", wantStatus: http.StatusOK},
+		{name: "other user", withAuth: true, userID: "user-7", wantStatus: http.StatusForbidden},
+		{name: "no user", withAuth: false, wantStatus: http.StatusUnauthorized},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newOwnerApp(mockService, tc.withAuth)
+			mockService.On("ListOrders", mock.Anything, mock.MatchedBy(func(input models.ListInput) bool { return input.UserID == "user-42" })).
+				Return(models.ListPaginatedOrders{Data: []models.OrderWithItems{}, Page: 1, Size: 10}, nil)
+
+			// Act
+			resp, err := app.Test(ownerRequest(t, "/orders?cursor="+token, tc.userID, ""))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			if tc.wantStatus != http.StatusOK {
+				mockService.AssertNotCalled(t, "ListOrders", mock.Anything, mock.Anything)
+			}
+		})
+	}
+}
+
+func TestOrderHandler_UpsertOrderByNumber_Ownership(t *testing.T) {
+	cases := []struct {
+		name          string
+		role          string
+		serviceErr    error
+		wantOwnerOnly bool
+		wantStatus    int
+	}{
+		{name: "owner", wantOwnerOnly: true, wantStatus: http.StatusOK},
+		{name: "other user", serviceErr: domain.ErrNotOrderOwner, wantOwnerOnly: true, wantStatus: http.StatusForbidden},
+		{name: "admin", role: middleware.AdminRole, wantOwnerOnly: false, wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			useEnforceOrderOwnership(t, true)
+			mockService := &MockOrderService{}
+			app := newOwnerApp(mockService, true)
+			mockService.On("UpsertOrderByNumber", mock.Anything, "ORD-20250601-K7QX2M", mock.MatchedBy(func(input models.CreateOrderInput) bool {
+				return input.OwnerOnly == tc.wantOwnerOnly
+			})).Return(models.OrderWithItems{}, false, tc.serviceErr)
+			req := upsertRequest("ORD-20250601-K7QX2M", `{"customer_name":"Jane","items":[{"product_name":"Widget","quantity":1,"price":1}]}`)
+			req.Header.Set(fiber.HeaderAuthorization, ownerRequest(t, "/", "user-42", tc.role).Header.Get(fiber.HeaderAuthorization))
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
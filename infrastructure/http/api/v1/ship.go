@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+)
+
+// ShipOrder records a shipment of item quantities and returns the fulfillment with the updated order
+func (h *OrderHandler) ShipOrder(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+
+	orderID, err := h.orderIDParam(c)
+	if err != nil {
+		return orderIDParamError(c, err)
+	}
+
+	var input models.ShipOrderInput
+	if err := c.BodyParser(&input); err != nil {
+		requestLogger.WithError(err).Error("Failed to parse shipment request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+	input.OrderID = orderID
+
+	result, err := h.service.ShipOrder(ctx, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrInvalidShipment) {
+			requestLogger.WithError(err).Warn("Invalid shipment", "order_id", orderID)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, pgx.ErrNoRows) {
+			requestLogger.Warn("Order or item not found for shipment", "order_id", orderID)
+			return c.Status(fiber.ErrNotFound.Code).JSON(fiber.Map{
+				"message": "Order or order item not found",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not shipped", "order_id", orderID)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to ship order", "order_id", orderID)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	requestLogger.Info("Order shipped successfully", "order_id", orderID, "fulfillment_id", result.Fulfillment.ID, "status", result.Order.Status)
+	return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+		"message": "Shipment recorded successfully",
+		"data":    result,
+	})
+}
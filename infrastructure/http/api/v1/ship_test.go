@@ -0,0 +1,117 @@
+package v1
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newShipApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Post("/orders/:id/ship", handler.ShipOrder)
+	return app
+}
+
+func shipRequest(id, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, "/orders/"+id+"/ship", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestOrderHandler_ShipOrder_Partial(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newShipApp(mockService)
+
+	input := models.ShipOrderInput{OrderID: 1, Items: []models.ShipItem{{ItemID: 3, Quantity: 2}}}
+	result := models.ShipOrderResult{
+		Order: models.OrderWithItems{
+			Order: models.Order{ID: 1, Status: models.StatusPartiallyShipped},
+			Items: []models.OrderItem{{ID: 3, OrderID: 1, Quantity: 5, ShippedQuantity: 2}},
+		},
+		Fulfillment: models.Fulfillment{ID: 8, OrderID: 1, Items: input.Items},
+	}
+	mockService.On("ShipOrder", mock.Anything, input).Return(result, nil)
+
+	// Act
+	resp, err := app.Test(shipRequest("1", `{"items":[{"item_id":3,"quantity":2}]}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body struct {
+		Data struct {
+			Order       map[string]any `json:"order"`
+			Fulfillment map[string]any `json:"fulfillment"`
+		} `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "partially_shipped", body.Data.Order["status"])
+	assert.Equal(t, float64(2), body.Data.Order["items"].([]any)[0].(map[string]any)["shipped_quantity"])
+	assert.Equal(t, float64(8), body.Data.Fulfillment["id"])
+	mockService.AssertExpectations(t)
+}
+
+func TestOrderHandler_ShipOrder_Full(t *testing.T) {
+	// Arrange
+	mockService := &MockOrderService{}
+	app := newShipApp(mockService)
+
+	result := models.ShipOrderResult{Order: models.OrderWithItems{Order: models.Order{ID: 1, Status: models.StatusCompleted}}}
+	mockService.On("ShipOrder", mock.Anything, mock.Anything).Return(result, nil)
+
+	// Act
+	resp, err := app.Test(shipRequest("1", `{"items":[{"item_id":3,"quantity":5}]}`))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var body struct {
+		Data models.ShipOrderResult `json:"data"`
+	}
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, models.StatusCompleted, body.Data.Order.Status)
+}
+
+func TestOrderHandler_ShipOrder_Errors(t *testing.T) {
+	tests := []struct {
+		name     string
+		id       string
+		err      error
+		expected int
+	}{
+		{name: "invalid id", id: "abc", expected: http.StatusBadRequest},
+		{name: "invalid shipment", id: "1", err: fmt.Errorf("%w: item 3 would ship 6 of 5", domain.ErrInvalidShipment), expected: http.StatusUnprocessableEntity},
+		{name: "not found", id: "1", err: pgx.ErrNoRows, expected: http.StatusNotFound},
+		{name: "read only", id: "1", err: domain.ErrReadOnly, expected: http.StatusServiceUnavailable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newShipApp(mockService)
+			mockService.On("ShipOrder", mock.Anything, mock.Anything).Return(models.ShipOrderResult{}, tt.err)
+
+			// Act
+			resp, err := app.Test(shipRequest(tt.id, `{"items":[{"item_id":3,"quantity":6}]}`))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, resp.StatusCode)
+		})
+	}
+}
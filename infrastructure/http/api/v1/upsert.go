@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"errors"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpsertOrderByNumber creates or replaces the order with the given order number, so external
+// systems can sync the same order repeatedly. It returns 201 when the order was created and 200
+// when an existing one was updated. With ownership enforced, replacing another user's order
+// returns 403.
+func (h *OrderHandler) UpsertOrderByNumber(c *fiber.Ctx) error {
+	ctx := c.UserContext()
+	requestLogger := logger.LoggerWithRequestIDFromContext(ctx)
+	ref := c.Params("ref")
+
+	if !models.IsOrderNumber(ref) {
+		requestLogger.Warn("Invalid order number", "order_number", ref)
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": "Invalid order number, expected ORD-YYYYMMDD-XXXXXX",
+		})
+	}
+
+	input, err := parseCreateOrderBody(c)
+	if errors.Is(err, errUnsupportedBody) {
+		requestLogger.Warn("Unsupported order body content type", "content_type", c.Get(fiber.HeaderContentType))
+		return c.Status(fiber.StatusUnsupportedMediaType).JSON(fiber.Map{
+			"message": "Content-Type must be " + supportedCreateContentTypes(),
+		})
+	}
+	if err != nil {
+		requestLogger.WithError(err).Error("Failed to parse request body")
+		return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+			"message": err.Error(),
+		})
+	}
+
+	// An existing order is only replaced by its owner while ownership is enforced
+	if enforceOrderOwnership.Load() && !middleware.IsAdmin(c) {
+		if _, ok := middleware.UserID(c); !ok {
+			requestLogger.Warn("Upsert without an authenticated user refused", "order_number", ref)
+			return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+				"message": "Order belongs to another user",
+			})
+		}
+		input.OwnerOnly = true
+	}
+
+	order, created, err := h.service.UpsertOrderByNumber(ctx, ref, input)
+	if err != nil {
+		if errors.Is(err, domain.ErrNotOrderOwner) {
+			requestLogger.Warn("Order belongs to another user", "order_number", ref)
+			return c.Status(fiber.ErrForbidden.Code).JSON(fiber.Map{
+				"message": "Order belongs to another user",
+			})
+		}
+		if message, ok := serviceUnavailableMessage(err); ok {
+			requestLogger.WithError(err).Warn("Service unavailable, order not upserted", "order_number", ref)
+			return c.Status(fiber.ErrServiceUnavailable.Code).JSON(fiber.Map{
+				"message": message,
+			})
+		}
+		if errors.Is(err, domain.ErrInvalidOrderNumber) {
+			return c.Status(fiber.ErrBadRequest.Code).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		var fieldErr *domain.FieldError
+		if errors.As(err, &fieldErr) {
+			requestLogger.WithError(err).Warn("Invalid order field", "order_number", ref, "field", fieldErr.Field)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+				"field":   fieldErr.Field,
+			})
+		}
+		if response, ok := duplicateItemsResponse(err); ok {
+			requestLogger.WithError(err).Warn("Order rejected for duplicate items", "order_number", ref)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(response)
+		}
+		if errors.Is(err, domain.ErrInvalidStatusTransition) {
+			requestLogger.WithError(err).Warn("Invalid status transition", "order_number", ref, "status", input.Status)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		if errors.Is(err, domain.ErrTotalOutOfRange) || errors.Is(err, domain.ErrTotalMismatch) {
+			requestLogger.WithError(err).Warn("Order total rejected", "order_number", ref)
+			return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{
+				"message": err.Error(),
+			})
+		}
+		requestLogger.WithError(err).Error("Failed to upsert order", "order_number", ref)
+		return c.Status(fiber.ErrInternalServerError.Code).JSON(internalErrorBody(c, err))
+	}
+
+	if created {
+		requestLogger.Info("Order created by number", "order_id", order.ID, "order_number", ref)
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"message": "Order created successfully",
+			"data":    order,
+		})
+	}
+	requestLogger.Info("Order updated by number", "order_id", order.ID, "order_number", ref)
+	return c.JSON(fiber.Map{
+		"message": "Order updated successfully",
+		"data":    order,
+	})
+}
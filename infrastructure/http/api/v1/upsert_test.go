@@ -0,0 +1,91 @@
+package v1
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func newUpsertApp(service *MockOrderService) *fiber.App {
+	handler := &OrderHandler{service: service}
+	app := fiber.New()
+	app.Put("/orders/by-number/:ref", handler.UpsertOrderByNumber)
+	return app
+}
+
+func upsertRequest(ref, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPut, "/orders/by-number/"+ref, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	return req
+}
+
+func TestOrderHandler_UpsertOrderByNumber_Status(t *testing.T) {
+	tests := []struct {
+		name           string
+		created        bool
+		expectedStatus int
+	}{
+		{name: "created", created: true, expectedStatus: http.StatusCreated},
+		{name: "updated", created: false, expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newUpsertApp(mockService)
+			order := models.OrderWithItems{Order: models.Order{ID: 5, OrderNumber: "ORD-20250601-K7QX2M"}}
+			mockService.On("UpsertOrderByNumber", mock.Anything, "ORD-20250601-K7QX2M", mock.AnythingOfType("models.CreateOrderInput")).Return(order, tt.created, nil)
+
+			// Act
+			resp, err := app.Test(upsertRequest("ORD-20250601-K7QX2M", `{"customer_name":"Jane","items":[{"product_name":"Widget","quantity":1,"price":1}]}`))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+			mockService.AssertExpectations(t)
+		})
+	}
+}
+
+func TestOrderHandler_UpsertOrderByNumber_Errors(t *testing.T) {
+	tests := []struct {
+		name           string
+		ref            string
+		body           string
+		serviceErr     error
+		expectedStatus int
+	}{
+		{name: "invalid order number", ref: "ORD-1", body: `{}`, expectedStatus: http.StatusBadRequest},
+		{name: "malformed body", ref: "ORD-20250601-K7QX2M", body: `{"customer_name":`, expectedStatus: http.StatusBadRequest},
+		{name: "item subtotal out of range", ref: "ORD-20250601-K7QX2M", body: `{}`, serviceErr: &domain.FieldError{Field: "items[0]", Err: domain.ErrTotalOutOfRange}, expectedStatus: http.StatusUnprocessableEntity},
+		{name: "total mismatch", ref: "ORD-20250601-K7QX2M", body: `{}`, serviceErr: fmt.Errorf("%w: expected 2", domain.ErrTotalMismatch), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "invalid status transition", ref: "ORD-20250601-K7QX2M", body: `{}`, serviceErr: fmt.Errorf("%w: order ORD-20250601-K7QX2M cannot move to pending", domain.ErrInvalidStatusTransition), expectedStatus: http.StatusUnprocessableEntity},
+		{name: "repository failure", ref: "ORD-20250601-K7QX2M", body: `{}`, serviceErr: errors.New("boom"), expectedStatus: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			mockService := &MockOrderService{}
+			app := newUpsertApp(mockService)
+			mockService.On("UpsertOrderByNumber", mock.Anything, mock.Anything, mock.Anything).Return(models.OrderWithItems{}, false, tt.serviceErr).Maybe()
+
+			// Act
+			resp, err := app.Test(upsertRequest(tt.ref, tt.body))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, resp.StatusCode)
+		})
+	}
+}
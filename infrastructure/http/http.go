@@ -2,10 +2,15 @@ package http
 
 import (
 	"context"
+	"math"
 	"time"
 
+	"github.com/Testzyler/order-management-go/application/models"
+	"github.com/Testzyler/order-management-go/application/repositories"
+	"github.com/Testzyler/order-management-go/application/services"
 	"github.com/Testzyler/order-management-go/infrastructure/http/api"
 	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	v1 "github.com/Testzyler/order-management-go/infrastructure/http/api/v1"
 	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	"github.com/gofiber/fiber/v2"
@@ -19,49 +24,109 @@ func InitHttpServer(ctx context.Context) {
 	httpLogger.Info("Initializing HTTP server")
 
 	// Initialize all handlers first (after database is ready)
-	route.InitializeAllHandlers()
+	route.SetEnabledHandlers(viper.GetStringSlice("HttpServer.EnabledHandlers"))
+	if err := route.InitializeAllHandlers(); err != nil {
+		logger.Fatal("Failed to initialize HTTP handlers", "error", err)
+	}
 
 	// Config Port and Address
 	httpPort := viper.GetString("HttpServer.Port")
-	readTimeout := viper.GetDuration("HttpServer.ServerTimeout")
-	writeTimeout := viper.GetDuration("HttpServer.ServerTimeout")
-	idleTimeout := viper.GetDuration("HttpServer.IdleTimeout")
 	requestTimeout := viper.GetDuration("HttpServer.RequestTimeout")
-
-	// Set defaults if not configured
-	if readTimeout == 0 {
-		readTimeout = 30 * time.Second
+	models.SetMoneyFormat(models.MoneyFormat(viper.GetString("HttpServer.MoneyFormat")))
+	models.SetAcceptSingleItemObject(viper.GetBool("HttpServer.AcceptSingleItemObject"))
+	models.SetIDStrategy(models.IDStrategy(viper.GetString("Order.IDStrategy")))
+	if err := models.SetCursorKey(viper.GetString("Security.CursorKey")); err != nil {
+		logger.Fatal("Invalid cursor key config", "error", err)
 	}
-	if writeTimeout == 0 {
-		writeTimeout = 30 * time.Second
+	v1.SetExposeErrorDetails(viper.GetBool("App.ExposeErrorDetails"))
+	v1.SetAcceptFormBody(viper.GetBool("HttpServer.AcceptFormBody"))
+	services.SetValidateClientTotal(viper.GetBool("App.ValidateClientTotal"))
+	// Strict unless explicitly disabled, a missing key must not silently allow partial results
+	services.SetStrictItemLoad(!viper.IsSet("Order.StrictItemLoad") || viper.GetBool("Order.StrictItemLoad"))
+	services.SetCollapseNameWhitespace(viper.GetBool("App.CollapseNameWhitespace"))
+	services.SetMaxOrderTotal(viper.GetFloat64("App.MaxOrderTotal"))
+	services.SetDeepPageOffset(viper.GetInt("App.DeepPageOffset"))
+	services.SetDuplicateItemStrategy(models.DuplicateItemStrategy(viper.GetString("Order.DuplicateItems")))
+	if viper.GetBool("Order.CustomerQuota.Enabled") {
+		services.SetCustomerQuota(viper.GetInt("Order.CustomerQuota.MaxOrders"), viper.GetDuration("Order.CustomerQuota.Window"))
 	}
-	if idleTimeout == 0 {
-		idleTimeout = 60 * time.Second
+	v1.SetAllowBulkDelete(viper.GetBool("App.AllowBulkDelete"))
+	v1.SetEnforceOrderOwnership(viper.GetBool("Order.EnforceOwnership"))
+	api.SetAdminToken(viper.GetString("Admin.Token"))
+	api.SetWriteStaleWindow(viper.GetDuration("Health.WriteStaleWindow"))
+	api.SetSaturationGrace(viper.GetDuration("Readiness.SaturationGrace"))
+	repositories.SetSlowTransactionThreshold(viper.GetDuration("Database.SlowTransactionThreshold"))
+	repositories.SetItemsQueryChunkSize(viper.GetInt("Database.ItemsQueryChunkSize"))
+	repositories.SetListItemLimit(viper.GetInt("Order.ListItemLimit"))
+	repositories.SetAnalyzeAfterBulk(viper.GetBool("Database.AnalyzeAfterBulk"), viper.GetInt64("Database.AnalyzeMinRows"), viper.GetDuration("Database.AnalyzeInterval"))
+	piiCipher, err := PIICipherFromViper(viper.GetViper())
+	if err != nil {
+		logger.Fatal("Invalid PII encryption config", "error", err)
 	}
+	repositories.SetPIICipher(piiCipher)
+
 	if requestTimeout == 0 {
 		requestTimeout = 30 * time.Second
 	}
 
-	AppServer = fiber.New(fiber.Config{
-		DisableStartupMessage: true,
-		ReadTimeout:           readTimeout,
-		WriteTimeout:          writeTimeout,
-		IdleTimeout:           idleTimeout,
-	})
+	if err := middleware.SetTrustedProxies(viper.GetStringSlice("HttpServer.TrustedProxies")); err != nil {
+		logger.Fatal("Invalid trusted proxies config", "error", err)
+	}
+
+	AppServer = fiber.New(NewServerConfig())
 
 	AppServer.Use(middleware.ContextMiddleware(ctx))
 	AppServer.Use(middleware.CancellationMiddleware())
+	if viper.GetBool("HttpServer.ServerTiming") {
+		AppServer.Use(middleware.ServerTimingMiddleware())
+	}
 	AppServer.Use(middleware.TimeoutMiddleware(requestTimeout))
 	AppServer.Use(middleware.RequestIDMiddleware())
+
+	var pathNormalizationConfig middleware.PathNormalizationConfig
+	if err := viper.UnmarshalKey("HttpServer.PathNormalization", &pathNormalizationConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal path normalization config", "error", err)
+	}
+	AppServer.Use(middleware.PathNormalizationMiddleware(pathNormalizationConfig))
+	AppServer.Use(middleware.ResponseVersionMiddleware(viper.GetInt("HttpServer.DefaultResponseVersion")))
 	AppServer.Use(middleware.RecoveryMiddleware())
 
-	// Add root level routes (like /healthz) directly to AppServer
-	baseRouter := AppServer.Group("")
-	api.AddRootRoutes(&baseRouter)
+	var uriLengthConfig middleware.URILengthConfig
+	if err := viper.UnmarshalKey("HttpServer", &uriLengthConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal URI length config", "error", err)
+	}
+	AppServer.Use(middleware.URILengthMiddleware(uriLengthConfig))
+	AppServer.Use(middleware.BodyLimitMiddleware(maxBodyBytes()))
 
-	// Add API routes under /api prefix
-	apiGroup := AppServer.Group("/api")
-	api.AddRoute(&apiGroup)
+	var loggingConfig middleware.LoggingConfig
+	if err := viper.UnmarshalKey("HttpServer.Logging", &loggingConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal request logging config", "error", err)
+	}
+	AppServer.Use(middleware.LoggingMiddleware(loggingConfig))
+
+	var rateLimitConfig middleware.RateLimitConfig
+	if err := viper.UnmarshalKey("RateLimit", &rateLimitConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal rate limit config", "error", err)
+	} else {
+		if rateLimitConfig.MaxConcurrentPerIP > 0 {
+			AppServer.Use(middleware.ConcurrencyLimitMiddleware(rateLimitConfig.MaxConcurrentPerIP))
+		}
+		if rateLimitConfig.Enabled {
+			AppServer.Use(middleware.RateLimitMiddleware(rateLimitConfig))
+		}
+	}
+
+	var idempotencyConfig middleware.IdempotencyConfig
+	if err := viper.UnmarshalKey("Idempotency", &idempotencyConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal idempotency config", "error", err)
+		idempotencyConfig.Enabled = false
+	}
+
+	var authConfig middleware.AuthConfig
+	if err := viper.UnmarshalKey("Auth", &authConfig); err != nil {
+		httpLogger.Error("Failed to unmarshal auth config", "error", err)
+	}
+	mountRoutes(AppServer, authConfig, idempotencyConfig)
 
 	// Start Server in goroutine
 	go func() {
@@ -78,6 +143,86 @@ func InitHttpServer(ctx context.Context) {
 	httpLogger.Info("Context cancelled, shutting down HTTP server")
 }
 
+// mountRoutes adds the API routes under /api and the root level routes (like /healthz). With
+// authentication enabled every route requires a token except the public health, readiness,
+// metrics and admin routes at the root, including order routes reached without the /api/v1 prefix.
+// Idempotent replays run after authentication, so a stored response is only returned to a caller
+// that is allowed to make the request, and keys are scoped to that caller.
+func mountRoutes(app *fiber.App, authConfig middleware.AuthConfig, idempotencyConfig middleware.IdempotencyConfig) {
+	var protect []fiber.Handler
+	if authConfig.Enabled {
+		protect = append(protect, middleware.AuthMiddleware(authConfig))
+	}
+	if idempotencyConfig.Enabled {
+		protect = append(protect, middleware.IdempotencyMiddleware(idempotencyConfig))
+	}
+
+	baseRouter := app.Group("")
+	api.AddRootRoutes(&baseRouter, protect...)
+
+	apiGroup := app.Group("/api", protect...)
+	api.AddRoute(&apiGroup)
+}
+
+// PIICipherFromViper builds the customer name cipher from the Security settings. It returns nil
+// when encryption is off and no keys are configured, so names are stored and read as plaintext.
+// Keys stay usable for reads while EncryptPII is off so previously encrypted rows remain readable.
+func PIICipherFromViper(v *viper.Viper) (*repositories.PIICipher, error) {
+	encrypt := v.GetBool("Security.EncryptPII")
+	keys := v.GetStringMapString("Security.PIIKeys")
+	if !encrypt && len(keys) == 0 {
+		return nil, nil
+	}
+	return repositories.NewPIICipher(keys, v.GetInt("Security.PIIKeyVersion"), encrypt)
+}
+
+// maxBodyBytes returns HttpServer.MaxBodyBytes, defaulting to 1MB when unset; 0 disables the limit
+func maxBodyBytes() int {
+	if !viper.IsSet("HttpServer.MaxBodyBytes") {
+		return middleware.DefaultMaxBodyBytes
+	}
+	return max(viper.GetInt("HttpServer.MaxBodyBytes"), 0)
+}
+
+// NewServerConfig builds the Fiber configuration from the HttpServer settings
+func NewServerConfig() fiber.Config {
+	readTimeout := viper.GetDuration("HttpServer.ServerTimeout")
+	writeTimeout := viper.GetDuration("HttpServer.ServerTimeout")
+	idleTimeout := viper.GetDuration("HttpServer.IdleTimeout")
+	// Requests whose request line and headers exceed this size are rejected with 431
+	maxHeaderBytes := viper.GetInt("HttpServer.MaxHeaderBytes")
+
+	// Set defaults if not configured
+	if readTimeout == 0 {
+		readTimeout = 30 * time.Second
+	}
+	if writeTimeout == 0 {
+		writeTimeout = 30 * time.Second
+	}
+	if idleTimeout == 0 {
+		idleTimeout = 60 * time.Second
+	}
+	if maxHeaderBytes <= 0 {
+		maxHeaderBytes = 4096
+	}
+	// The server stops reading bodies over the configured limit before any middleware runs and
+	// ErrorHandler renders its 413. Fiber has no unlimited setting, so 0 uses the largest limit.
+	bodyLimit := maxBodyBytes()
+	if bodyLimit == 0 {
+		bodyLimit = math.MaxInt32
+	}
+
+	return fiber.Config{
+		DisableStartupMessage: true,
+		ReadTimeout:           readTimeout,
+		WriteTimeout:          writeTimeout,
+		IdleTimeout:           idleTimeout,
+		ReadBufferSize:        maxHeaderBytes,
+		BodyLimit:             bodyLimit,
+		ErrorHandler:          middleware.ErrorHandler,
+	}
+}
+
 func ShutdownHttpServer() {
 	logger := logger.GetDefault()
 	logger.Info("HTTP server is shutting down")
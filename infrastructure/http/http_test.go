@@ -0,0 +1,206 @@
+package http
+
+import (
+	"encoding/json"
+	"math"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/Testzyler/order-management-go/infrastructure/http/api/route"
+	"github.com/Testzyler/order-management-go/infrastructure/http/middleware"
+	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewServerConfig_RejectsOversizedHeaders(t *testing.T) {
+	// Arrange
+	viper.Set("HttpServer.MaxHeaderBytes", 1024)
+	defer viper.Set("HttpServer.MaxHeaderBytes", nil)
+
+	app := fiber.New(NewServerConfig())
+	app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// app.Test bypasses the server error handler, so serve on a real listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer func() { _ = app.Shutdown() }()
+
+	url := "http://" + listener.Addr().String() + "/healthz"
+	small, _ := http.NewRequest(http.MethodGet, url, nil)
+	large, _ := http.NewRequest(http.MethodGet, url, nil)
+	large.Header.Set("X-Large", strings.Repeat("a", 2048))
+
+	// Act
+	smallResp, err := http.DefaultClient.Do(small)
+	assert.NoError(t, err)
+	defer smallResp.Body.Close()
+	largeResp, err := http.DefaultClient.Do(large)
+	assert.NoError(t, err)
+	defer largeResp.Body.Close()
+
+	// Assert
+	assert.Equal(t, http.StatusOK, smallResp.StatusCode)
+	assert.Equal(t, http.StatusRequestHeaderFieldsTooLarge, largeResp.StatusCode)
+}
+
+func TestNewServerConfig_Defaults(t *testing.T) {
+	config := NewServerConfig()
+
+	assert.Equal(t, 4096, config.ReadBufferSize)
+	assert.Equal(t, middleware.DefaultMaxBodyBytes, config.BodyLimit)
+	assert.True(t, config.DisableStartupMessage)
+}
+
+func TestNewServerConfig_BodyLimitCoversMaxBodyBytes(t *testing.T) {
+	// Arrange
+	viper.Set("HttpServer.MaxBodyBytes", 8<<20)
+	defer viper.Set("HttpServer.MaxBodyBytes", nil)
+
+	// Act
+	config := NewServerConfig()
+
+	// Assert
+	assert.Equal(t, 8<<20, config.BodyLimit)
+}
+
+func TestNewServerConfig_BodyLimitMatchesMaxBodyBytes(t *testing.T) {
+	cases := []struct {
+		name         string
+		maxBodyBytes int
+		want         int
+	}{
+		{name: "below the fiber default", maxBodyBytes: 64 << 10, want: 64 << 10},
+		{name: "disabled", maxBodyBytes: 0, want: math.MaxInt32},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			viper.Set("HttpServer.MaxBodyBytes", tc.maxBodyBytes)
+			defer viper.Set("HttpServer.MaxBodyBytes", nil)
+
+			// Act
+			config := NewServerConfig()
+
+			// Assert
+			assert.Equal(t, tc.want, config.BodyLimit)
+		})
+	}
+}
+
+func TestNewServerConfig_BodyOverServerLimit(t *testing.T) {
+	// Arrange
+	viper.Set("HttpServer.MaxBodyBytes", 64)
+	defer viper.Set("HttpServer.MaxBodyBytes", nil)
+	app := fiber.New(NewServerConfig())
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+
+	// app.Test bypasses the server error handler, so serve on a real listener
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	go func() { _ = app.Listener(listener) }()
+	defer func() { _ = app.Shutdown() }()
+
+	req, _ := http.NewRequest(http.MethodPost, "http://"+listener.Addr().String()+"/api/v1/orders", strings.NewReader(strings.Repeat("a", 65)))
+	req.Header.Set(middleware.RequestIDHeader, "req-413")
+
+	// Act
+	resp, err := http.DefaultClient.Do(req)
+
+	// Assert
+	if !assert.NoError(t, err) {
+		return
+	}
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Equal(t, "req-413", resp.Header.Get(middleware.RequestIDHeader))
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, map[string]any{"message": "Request body too large", "request_id": "req-413"}, body)
+}
+
+func TestNewServerConfig_MethodNotAllowed(t *testing.T) {
+	// Arrange
+	app := fiber.New(NewServerConfig())
+	app.Use(func(c *fiber.Ctx) error { return c.Next() })
+	app.Get("/api/v1/orders/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Patch("/api/v1/orders/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/orders/7", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, resp.StatusCode)
+	assert.ElementsMatch(t, []string{"GET", "HEAD", "PATCH"}, strings.Split(strings.ReplaceAll(resp.Header.Get("Allow"), " ", ""), ","))
+	assert.Contains(t, resp.Header.Get("Content-Type"), "application/json")
+
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Method POST not allowed", body["message"])
+}
+
+func TestNewServerConfig_NotFoundIsJSON(t *testing.T) {
+	// Arrange
+	app := fiber.New(NewServerConfig())
+	app.Get("/api/v1/orders/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/customers", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+	var body map[string]string
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	assert.Equal(t, "Cannot GET /api/v1/customers", body["message"])
+}
+
+func TestMountRoutes_AuthCoversRootOrderRoutes(t *testing.T) {
+	// Arrange
+	assert.NoError(t, route.InitializeAllHandlers())
+	app := fiber.New()
+	mountRoutes(app, middleware.AuthConfig{Enabled: true, Secret: "test-secret"}, middleware.IdempotencyConfig{})
+
+	// Act
+	rootOrder, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders/1", nil))
+	assert.NoError(t, err)
+	apiOrder, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders/1", nil))
+	assert.NoError(t, err)
+	health, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, rootOrder.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, apiOrder.StatusCode)
+	assert.Equal(t, http.StatusOK, health.StatusCode)
+}
+
+func TestMountRoutes_AuthRunsBeforeIdempotency(t *testing.T) {
+	// Arrange
+	assert.NoError(t, route.InitializeAllHandlers())
+	app := fiber.New()
+	mountRoutes(app, middleware.AuthConfig{Enabled: true, Secret: "test-secret"}, middleware.IdempotencyConfig{Enabled: true})
+	newRequest := func(path string) *http.Request {
+		req := httptest.NewRequest(http.MethodDelete, path, nil)
+		// Too short a key is rejected by the idempotency middleware, if it runs first
+		req.Header.Set(middleware.IdempotencyKeyHeader, "1")
+		return req
+	}
+
+	// Act
+	apiOrder, err := app.Test(newRequest("/api/v1/orders/1"))
+	assert.NoError(t, err)
+	rootOrder, err := app.Test(newRequest("/orders/1"))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusUnauthorized, apiOrder.StatusCode)
+	assert.Equal(t, http.StatusUnauthorized, rootOrder.StatusCode)
+	assert.Empty(t, apiOrder.Header.Get(middleware.IdempotencyReplayedHeader))
+}
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// AuthConfig holds the JWT bearer authentication settings
+type AuthConfig struct {
+	Enabled bool `mapstructure:"Enabled"`
+	// Secret is the HMAC key tokens are signed with (HS256, HS384 or HS512)
+	Secret string `mapstructure:"Secret"`
+}
+
+// AdminRole is the "role" claim of users that may access every order
+const AdminRole = "admin"
+
+// c.Locals keys holding the authenticated user
+const (
+	userIDLocal   = "user_id"
+	userRoleLocal = "user_role"
+)
+
+// authClaims are the token claims read by AuthMiddleware: the subject is the user ID
+type authClaims struct {
+	jwt.RegisteredClaims
+	Role string `json:"role,omitempty"`
+}
+
+// UserID returns the ID of the user authenticated by AuthMiddleware, ok is false for requests
+// that did not pass through it
+func UserID(c *fiber.Ctx) (userID string, ok bool) {
+	userID, ok = c.Locals(userIDLocal).(string)
+	return userID, ok && userID != ""
+}
+
+// IsAdmin reports whether the authenticated user's token carries the admin role
+func IsAdmin(c *fiber.Ctx) bool {
+	role, _ := c.Locals(userRoleLocal).(string)
+	return role == AdminRole
+}
+
+// AuthMiddleware requires an "Authorization: Bearer <token>" header carrying a JWT signed with
+// the configured secret. The token's subject is the user ID, stored in c.Locals("user_id") and
+// in the request context so every request log line carries it. Its optional "role" claim is read
+// by IsAdmin. Missing, expired or otherwise invalid tokens are rejected with 401.
+func AuthMiddleware(config AuthConfig) fiber.Handler {
+	secret := []byte(config.Secret)
+	parser := jwt.NewParser(jwt.WithValidMethods([]string{
+		jwt.SigningMethodHS256.Alg(),
+		jwt.SigningMethodHS384.Alg(),
+		jwt.SigningMethodHS512.Alg(),
+	}))
+	keyFunc := func(*jwt.Token) (any, error) { return secret, nil }
+
+	return func(c *fiber.Ctx) error {
+		requestLogger := logger.LoggerWithRequestIDFromContext(c.UserContext())
+
+		raw, ok := strings.CutPrefix(c.Get(fiber.HeaderAuthorization), "Bearer ")
+		if !ok || strings.TrimSpace(raw) == "" {
+			return unauthorized(c, "Missing bearer token")
+		}
+
+		var claims authClaims
+		if _, err := parser.ParseWithClaims(strings.TrimSpace(raw), &claims, keyFunc); err != nil {
+			if errors.Is(err, jwt.ErrTokenExpired) {
+				requestLogger.Debug("Rejected expired bearer token")
+				return unauthorized(c, "Token expired")
+			}
+			requestLogger.WithError(err).Debug("Rejected invalid bearer token")
+			return unauthorized(c, "Invalid token")
+		}
+		if claims.Subject == "" {
+			requestLogger.Debug("Rejected bearer token without subject")
+			return unauthorized(c, "Invalid token")
+		}
+
+		c.Locals(userIDLocal, claims.Subject)
+		c.Locals(userRoleLocal, claims.Role)
+		c.SetUserContext(logger.WithUserIDToContext(c.UserContext(), claims.Subject))
+		requestLogger.WithUserID(claims.Subject).Debug("Request authenticated")
+
+		return c.Next()
+	}
+}
+
+func unauthorized(c *fiber.Ctx, message string) error {
+	c.Set(fiber.HeaderWWWAuthenticate, `Bearer realm="orders"`)
+	return c.Status(fiber.StatusUnauthorized).JSON(fiber.Map{
+		"message": message,
+	})
+}
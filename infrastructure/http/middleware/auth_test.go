@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+)
+
+const testAuthSecret = "test-secret-with-enough-entropy-123"
+
+func signTestToken(t *testing.T, method jwt.SigningMethod, secret string, claims jwt.RegisteredClaims) string {
+	token, err := jwt.NewWithClaims(method, claims).SignedString([]byte(secret))
+	assert.NoError(t, err)
+	return token
+}
+
+// newAuthApp serves /api/v1/orders behind AuthMiddleware, echoing the user ID seen by the handler
+func newAuthApp() *fiber.App {
+	app := fiber.New()
+	app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	api := app.Group("/api", AuthMiddleware(AuthConfig{Enabled: true, Secret: testAuthSecret}))
+	api.Get("/v1/orders", func(c *fiber.Ctx) error {
+		userID, _ := UserID(c)
+		return c.JSON(fiber.Map{
+			"user_id":         userID,
+			"context_user_id": logger.UserIDFromContext(c.UserContext()),
+		})
+	})
+	return app
+}
+
+func bearerRequest(path, authorization string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	if authorization != "" {
+		req.Header.Set(fiber.HeaderAuthorization, authorization)
+	}
+	return req
+}
+
+func TestAuthMiddleware_ValidToken(t *testing.T) {
+	// Arrange
+	app := newAuthApp()
+	token := signTestToken(t, jwt.SigningMethodHS256, testAuthSecret, jwt.RegisteredClaims{
+		Subject:   "user-42",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	})
+
+	// Act
+	resp, err := app.Test(bearerRequest("/api/v1/orders", "Bearer "+token))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, map[string]any{"user_id": "user-42", "context_user_id": "user-42"}, decodeBody(t, resp))
+}
+
+func TestAuthMiddleware_RejectsTokens(t *testing.T) {
+	expired := signTestToken(t, jwt.SigningMethodHS256, testAuthSecret, jwt.RegisteredClaims{
+		Subject:   "user-42",
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(-time.Minute)),
+	})
+	wrongSecret := signTestToken(t, jwt.SigningMethodHS256, "another-secret", jwt.RegisteredClaims{Subject: "user-42"})
+	noSubject := signTestToken(t, jwt.SigningMethodHS256, testAuthSecret, jwt.RegisteredClaims{})
+	unsigned, err := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{Subject: "user-42"}).
+		SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name          string
+		authorization string
+		wantMessage   string
+	}{
+		{name: "missing header", authorization: "", wantMessage: "Missing bearer token"},
+		{name: "other scheme", authorization: "Basic dXNlcjpwYXNz", wantMessage: "Missing bearer token"},
+		{name: "expired", authorization: "Bearer " + expired, wantMessage: "Token expired"},
+		{name: "malformed", authorization: "Bearer not.a.jwt", wantMessage: "Invalid token"},
+		{name: "wrong secret", authorization: "Bearer " + wrongSecret, wantMessage: "Invalid token"},
+		{name: "no subject", authorization: "Bearer " + noSubject, wantMessage: "Invalid token"},
+		{name: "alg none", authorization: "Bearer " + unsigned, wantMessage: "Invalid token"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			app := newAuthApp()
+
+			// Act
+			resp, err := app.Test(bearerRequest("/api/v1/orders", tc.authorization))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+			assert.Equal(t, `Bearer realm="orders"`, resp.Header.Get(fiber.HeaderWWWAuthenticate))
+			assert.Equal(t, map[string]any{"message": tc.wantMessage}, decodeBody(t, resp))
+		})
+	}
+}
+
+func TestAuthMiddleware_RootRoutesStayPublic(t *testing.T) {
+	// Arrange
+	app := newAuthApp()
+
+	// Act
+	resp, err := app.Test(bearerRequest("/healthz", ""))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
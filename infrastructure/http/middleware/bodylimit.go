@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DefaultMaxBodyBytes is the request body limit used when HttpServer.MaxBodyBytes is not set
+const DefaultMaxBodyBytes = 1 << 20
+
+// BodyLimitMiddleware rejects requests whose body is larger than maxBytes with 413 before any
+// handler parses it. The declared Content-Length is checked first; chunked bodies have none and
+// are checked by their received size. A limit of 0 disables it.
+func BodyLimitMiddleware(maxBytes int) fiber.Handler {
+	if maxBytes <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+
+	return func(c *fiber.Ctx) error {
+		// fasthttp reports -1 for chunked and -2 for identity bodies without a Content-Length
+		size := c.Request().Header.ContentLength()
+		if size < 0 {
+			size = len(c.Request().Body())
+		}
+
+		if size > maxBytes {
+			requestID, _ := c.Locals("request_id").(string)
+			logger.LoggerWithRequestIDFromContext(c.UserContext()).Warn("Request body too large",
+				"path", c.Path(),
+				"body_bytes", size,
+				"max_body_bytes", maxBytes,
+			)
+			return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+				"message":    "Request body too large",
+				"request_id": requestID,
+			})
+		}
+
+		return c.Next()
+	}
+}
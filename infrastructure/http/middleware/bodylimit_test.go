@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newBodyLimitApp(maxBytes int) *fiber.App {
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(BodyLimitMiddleware(maxBytes))
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+	return app
+}
+
+func TestBodyLimitMiddleware_JustUnderLimit(t *testing.T) {
+	// Arrange
+	app := newBodyLimitApp(64)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(strings.Repeat("a", 64)))
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
+
+func TestBodyLimitMiddleware_JustOverLimit(t *testing.T) {
+	// Arrange
+	app := newBodyLimitApp(64)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(strings.Repeat("a", 65)))
+	req.Header.Set(RequestIDHeader, "req-413")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+	assert.Equal(t, map[string]any{"message": "Request body too large", "request_id": "req-413"}, decodeBody(t, resp))
+}
+
+func TestBodyLimitMiddleware_ChunkedBodyOverLimit(t *testing.T) {
+	// Arrange
+	app := newBodyLimitApp(64)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(strings.Repeat("a", 128)))
+	req.ContentLength = -1
+	req.TransferEncoding = []string{"chunked"}
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestEntityTooLarge, resp.StatusCode)
+}
+
+func TestBodyLimitMiddleware_ZeroDisables(t *testing.T) {
+	// Arrange
+	app := newBodyLimitApp(0)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", strings.NewReader(strings.Repeat("a", 4096)))
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+}
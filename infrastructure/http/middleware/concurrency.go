@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// concurrencyLimiter counts the in-flight requests of each client IP
+type concurrencyLimiter struct {
+	mu     sync.Mutex
+	active map[string]int
+	max    int
+}
+
+func newConcurrencyLimiter(max int) *concurrencyLimiter {
+	return &concurrencyLimiter{
+		active: make(map[string]int),
+		max:    max,
+	}
+}
+
+// acquire takes a slot for the IP and reports false when all of its slots are in use
+func (l *concurrencyLimiter) acquire(ip string) bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[ip] >= l.max {
+		return false
+	}
+	l.active[ip]++
+	return true
+}
+
+// release frees a slot, dropping the IP once it has no requests left so the map does not grow
+func (l *concurrencyLimiter) release(ip string) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.active[ip] <= 1 {
+		delete(l.active, ip)
+		return
+	}
+	l.active[ip]--
+}
+
+// ConcurrencyLimitMiddleware rejects requests with 429 while the client IP already has maxPerIP
+// requests in flight, so slow clients cannot hold every server slot. A limit of 0 disables it.
+func ConcurrencyLimitMiddleware(maxPerIP int) fiber.Handler {
+	if maxPerIP <= 0 {
+		return func(c *fiber.Ctx) error { return c.Next() }
+	}
+	limiter := newConcurrencyLimiter(maxPerIP)
+
+	return func(c *fiber.Ctx) error {
+		ip := ClientIP(c)
+		if !limiter.acquire(ip) {
+			c.Set(RetryAfterHeader, "1")
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"message": "Too many concurrent requests",
+			})
+		}
+		defer limiter.release(ip)
+
+		return c.Next()
+	}
+}
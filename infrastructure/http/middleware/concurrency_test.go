@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConcurrencyLimitMiddleware_RejectsOverLimit(t *testing.T) {
+	// Arrange
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	app := fiber.New()
+	app.Use(ConcurrencyLimitMiddleware(2))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		entered <- struct{}{}
+		<-release
+		return c.SendStatus(fiber.StatusOK)
+	})
+	app.Get("/fast", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	var wg sync.WaitGroup
+	statuses := make(chan int, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil), -1)
+			if assert.NoError(t, err) {
+				statuses <- resp.StatusCode
+			}
+		}()
+	}
+	<-entered
+	<-entered
+
+	// Act: a third request from the same IP while both slots are held
+	rejected, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil))
+	close(release)
+	wg.Wait()
+	close(statuses)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rejected.StatusCode)
+	assert.Equal(t, "1", rejected.Header.Get(RetryAfterHeader))
+	for status := range statuses {
+		assert.Equal(t, http.StatusOK, status)
+	}
+
+	// Slots are returned once the slow requests finish
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/fast", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestConcurrencyLimiter_ReleaseDropsIdleIPs(t *testing.T) {
+	// Arrange
+	limiter := newConcurrencyLimiter(1)
+
+	// Act & Assert
+	assert.True(t, limiter.acquire("10.0.0.1"))
+	assert.False(t, limiter.acquire("10.0.0.1"))
+	assert.True(t, limiter.acquire("10.0.0.2"))
+
+	limiter.release("10.0.0.1")
+	limiter.release("10.0.0.2")
+	assert.Empty(t, limiter.active)
+}
+
+func TestConcurrencyLimitMiddleware_Disabled(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(ConcurrencyLimitMiddleware(0))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
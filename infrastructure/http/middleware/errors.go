@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"errors"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ErrorHandler renders errors that reach Fiber, such as unmatched routes, disallowed methods and
+// recovered panics, in the same {"message": ...} body the handlers use. For 405 Fiber has already
+// set the Allow header to the methods registered for the path.
+func ErrorHandler(c *fiber.Ctx, err error) error {
+	var fiberErr *fiber.Error
+	if !errors.As(err, &fiberErr) {
+		logger.LoggerWithRequestIDFromContext(c.UserContext()).WithError(err).Error("Unhandled request error")
+		fiberErr = fiber.ErrInternalServerError
+	}
+
+	if fiberErr.Code == fiber.StatusRequestEntityTooLarge {
+		// The server rejects bodies over its limit before RequestIDMiddleware runs
+		return c.Status(fiber.StatusRequestEntityTooLarge).JSON(fiber.Map{
+			"message":    "Request body too large",
+			"request_id": ensureRequestID(c),
+		})
+	}
+
+	message := fiberErr.Message
+	if fiberErr.Code == fiber.StatusMethodNotAllowed {
+		message = "Method " + c.Method() + " not allowed"
+	}
+	return c.Status(fiberErr.Code).JSON(fiber.Map{
+		"message": message,
+	})
+}
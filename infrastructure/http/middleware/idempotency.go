@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofiber/fiber/v2/middleware/idempotency"
+)
+
+const (
+	IdempotencyKeyHeader      = "X-Idempotency-Key"
+	IdempotencyReplayedHeader = "X-Idempotency-Replayed"
+
+	// minIdempotencyKeyLength rejects keys too short to be unique, such as counters or "1"
+	minIdempotencyKeyLength = 16
+	// maxIdempotencyKeyLength bounds the keys kept in the store
+	maxIdempotencyKeyLength = 255
+
+	// idempotencyStorageKeyHeader carries the key responses are stored under. It is set from the
+	// client's key on every request and never read from the client.
+	idempotencyStorageKeyHeader = "X-Idempotency-Storage-Key"
+)
+
+// IdempotencyConfig configures replaying of unsafe requests sent with the same X-Idempotency-Key.
+// Responses are kept in memory, so replays are only detected by the instance that served the original.
+type IdempotencyConfig struct {
+	Enabled  bool          `mapstructure:"Enabled"`
+	Lifetime time.Duration `mapstructure:"Lifetime"`
+}
+
+// IdempotencyMiddleware replays the stored response for POST, PUT, PATCH and DELETE requests
+// that repeat an X-Idempotency-Key. Responses are stored per method, path and authenticated
+// user, so a key reused on another route or by another user is processed as a new request.
+// Responses to keyed requests carry X-Idempotency-Replayed set to "true" when served from the
+// store and "false" when freshly processed. Keys must be 16 to 255 characters long.
+func IdempotencyMiddleware(config IdempotencyConfig) fiber.Handler {
+	lifetime := config.Lifetime
+	if lifetime <= 0 {
+		lifetime = 30 * time.Minute
+	}
+
+	handler := idempotency.New(idempotency.Config{
+		Lifetime:  lifetime,
+		KeyHeader: idempotencyStorageKeyHeader,
+		// The storage key is a hash built by idempotencyStorageKey; the client's key is checked below
+		KeyHeaderValidate: func(string) error { return nil },
+		// Per-request headers such as X-Request-ID are set again by earlier middleware on replay
+		KeepResponseHeaders: []string{fiber.HeaderContentType, fiber.HeaderLocation},
+	})
+
+	return func(c *fiber.Ctx) error {
+		c.Request().Header.Del(idempotencyStorageKeyHeader)
+		if key := c.Get(IdempotencyKeyHeader); key != "" && !fiber.IsMethodSafe(c.Method()) {
+			if len(key) < minIdempotencyKeyLength || len(key) > maxIdempotencyKeyLength {
+				return fiber.NewError(fiber.StatusBadRequest, "X-Idempotency-Key must be between 16 and 255 characters")
+			}
+			c.Request().Header.Set(idempotencyStorageKeyHeader, idempotencyStorageKey(c, key))
+		}
+
+		err := handler(c)
+
+		// The stored response was captured before this header is set, so it is never replayed itself
+		if replayed := idempotency.IsFromCache(c); replayed || idempotency.WasPutToCache(c) {
+			c.Set(IdempotencyReplayedHeader, strconv.FormatBool(replayed))
+		}
+		return err
+	}
+}
+
+// idempotencyStorageKey scopes the client's key to the request's method, path and authenticated user
+func idempotencyStorageKey(c *fiber.Ctx, key string) string {
+	userID, _ := UserID(c)
+	hash := sha256.New()
+	for _, part := range []string{c.Method(), c.Path(), userID, key} {
+		hash.Write([]byte(part))
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
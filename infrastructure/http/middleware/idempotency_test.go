@@ -0,0 +1,154 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+const testIdempotencyKey = "6f1c2a7e-order-key-0001"
+
+func newIdempotentApp(calls *int) *fiber.App {
+	app := fiber.New()
+	app.Use(IdempotencyMiddleware(IdempotencyConfig{Enabled: true}))
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error {
+		*calls++
+		c.Location("/api/v1/orders/1")
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"calls": *calls})
+	})
+	app.Delete("/api/v1/orders/:id", func(c *fiber.Ctx) error {
+		*calls++
+		return c.Status(fiber.StatusAccepted).JSON(fiber.Map{"calls": *calls})
+	})
+	return app
+}
+
+func TestIdempotencyMiddleware_ReplayedHeader(t *testing.T) {
+	// Arrange
+	calls := 0
+	app := newIdempotentApp(&calls)
+	newRequest := func() *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+		req.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+		return req
+	}
+
+	// Act
+	first, err := app.Test(newRequest())
+	assert.NoError(t, err)
+	second, err := app.Test(newRequest())
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 1, calls)
+	assert.Equal(t, http.StatusCreated, first.StatusCode)
+	assert.Equal(t, "false", first.Header.Get(IdempotencyReplayedHeader))
+	assert.Equal(t, http.StatusCreated, second.StatusCode)
+	assert.Equal(t, "true", second.Header.Get(IdempotencyReplayedHeader))
+	assert.Equal(t, []string{"true"}, second.Header.Values(IdempotencyReplayedHeader))
+	assert.Equal(t, "/api/v1/orders/1", second.Header.Get(fiber.HeaderLocation))
+}
+
+func TestIdempotencyMiddleware_NoKey(t *testing.T) {
+	// Arrange
+	calls := 0
+	app := newIdempotentApp(&calls)
+
+	// Act
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil))
+		assert.NoError(t, err)
+		assert.Empty(t, resp.Header.Get(IdempotencyReplayedHeader))
+	}
+
+	// Assert
+	assert.Equal(t, 2, calls)
+}
+
+func TestIdempotencyMiddleware_KeyScopedToMethodAndPath(t *testing.T) {
+	// Arrange
+	calls := 0
+	app := newIdempotentApp(&calls)
+	create := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+	create.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+	remove := httptest.NewRequest(http.MethodDelete, "/api/v1/orders/5", nil)
+	remove.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+
+	// Act
+	_, err := app.Test(create)
+	assert.NoError(t, err)
+	resp, err := app.Test(remove)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, http.StatusAccepted, resp.StatusCode)
+	assert.Equal(t, "false", resp.Header.Get(IdempotencyReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_KeyScopedToUser(t *testing.T) {
+	// Arrange
+	calls := 0
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals(userIDLocal, c.Get("X-Test-User"))
+		return c.Next()
+	})
+	app.Use(IdempotencyMiddleware(IdempotencyConfig{Enabled: true}))
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error {
+		calls++
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{"calls": calls})
+	})
+	newRequest := func(userID string) *http.Request {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+		req.Header.Set(IdempotencyKeyHeader, testIdempotencyKey)
+		req.Header.Set("X-Test-User", userID)
+		return req
+	}
+
+	// Act
+	_, err := app.Test(newRequest("user-42"))
+	assert.NoError(t, err)
+	other, err := app.Test(newRequest("user-7"))
+	assert.NoError(t, err)
+	same, err := app.Test(newRequest("user-42"))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, 2, calls)
+	assert.Equal(t, "false", other.Header.Get(IdempotencyReplayedHeader))
+	assert.Equal(t, "true", same.Header.Get(IdempotencyReplayedHeader))
+}
+
+func TestIdempotencyMiddleware_KeyLength(t *testing.T) {
+	tests := []struct {
+		name       string
+		key        string
+		wantStatus int
+	}{
+		{name: "too short", key: "1", wantStatus: http.StatusBadRequest},
+		{name: "too long", key: strings.Repeat("k", 256), wantStatus: http.StatusBadRequest},
+		{name: "uuid", key: "3f2b8c1e-9a4d-4e5f-8b6a-1c2d3e4f5a6b", wantStatus: http.StatusCreated},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			calls := 0
+			app := newIdempotentApp(&calls)
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil)
+			req.Header.Set(IdempotencyKeyHeader, tt.key)
+
+			// Act
+			resp, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+		})
+	}
+}
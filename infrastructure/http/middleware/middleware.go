@@ -2,8 +2,12 @@ package middleware
 
 import (
 	"context"
+	"errors"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/Testzyler/order-management-go/infrastructure/metrics"
 	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/recover"
@@ -12,6 +16,13 @@ import (
 
 const RequestIDHeader = "X-Request-ID"
 
+const (
+	// RequestDeadlineHeader carries the request's effective deadline (RFC 3339, UTC)
+	RequestDeadlineHeader = "X-Request-Deadline"
+	// RequestElapsedHeader is set on requests that ran past their deadline, in milliseconds
+	RequestElapsedHeader = "X-Request-Elapsed-Ms"
+)
+
 // ContextMiddleware adds context with timeout and cancellation support to each request
 func ContextMiddleware(parentCtx context.Context) fiber.Handler {
 	return func(c *fiber.Ctx) error {
@@ -34,15 +45,32 @@ func ContextMiddleware(parentCtx context.Context) fiber.Handler {
 	}
 }
 
-// TimeoutMiddleware creates a middleware that enforces request timeout
+// TimeoutMiddleware creates a middleware that enforces request timeout. The effective deadline,
+// the earlier of this timeout and any deadline already on the context, is returned in
+// X-Request-Deadline; requests that exceed it also report how long they ran.
 func TimeoutMiddleware(timeout time.Duration) fiber.Handler {
 	return func(c *fiber.Ctx) error {
+		start := time.Now()
 		ctx, cancel := context.WithTimeout(c.UserContext(), timeout)
 		defer cancel()
 
 		c.SetUserContext(ctx)
+		if deadline, ok := ctx.Deadline(); ok {
+			c.Set(RequestDeadlineHeader, deadline.UTC().Format(time.RFC3339Nano))
+		}
 
-		return c.Next()
+		err := c.Next()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			elapsed := time.Since(start)
+			c.Set(RequestElapsedHeader, strconv.FormatInt(elapsed.Milliseconds(), 10))
+			logger.LoggerWithRequestIDFromContext(ctx).Warn("Request exceeded its deadline",
+				"path", c.Path(),
+				"timeout_ms", timeout.Milliseconds(),
+				"elapsed_ms", elapsed.Milliseconds(),
+			)
+		}
+		return err
 	}
 }
 
@@ -68,14 +96,39 @@ func RecoveryMiddleware() fiber.Handler {
 	})
 }
 
+// requestIDFromHeader returns the first non-empty X-Request-ID value and how many values were sent.
+// The lookup is case-insensitive, and repeated headers as well as comma-joined values
+// (as some proxies merge them) are all counted.
+func requestIDFromHeader(c *fiber.Ctx) (string, int) {
+	var values []string
+	for _, header := range c.Request().Header.PeekAll(RequestIDHeader) {
+		for _, value := range strings.Split(string(header), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	if len(values) == 0 {
+		return "", 0
+	}
+	return values[0], len(values)
+}
+
 // RequestIDMiddleware adds a unique request ID to each request for Fiber
 func RequestIDMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		requestID := c.Get(RequestIDHeader)
+		requestID, count := requestIDFromHeader(c)
+		if count > 1 {
+			logger.LoggerWithRequestIDFromContext(c.UserContext()).Warn("Multiple X-Request-ID values received, using the first",
+				"request_id", requestID,
+				"count", count,
+			)
+		}
 		if requestID == "" {
 			requestID = uuid.New().String()
 		}
 
+		// fasthttp normalizes header names, so the echoed header is always sent as X-Request-Id
 		c.Set(RequestIDHeader, requestID)
 
 		c.Locals("request_id", requestID)
@@ -87,8 +140,78 @@ func RequestIDMiddleware() fiber.Handler {
 	}
 }
 
-// LoggingMiddleware logs HTTP requests with structured logging for Fiber
-func LoggingMiddleware() fiber.Handler {
+// ensureRequestID returns the request ID set by RequestIDMiddleware. For requests rejected before
+// it ran, it assigns one the same way and echoes it in the response header.
+func ensureRequestID(c *fiber.Ctx) string {
+	if requestID, ok := c.Locals("request_id").(string); ok && requestID != "" {
+		return requestID
+	}
+	requestID, _ := requestIDFromHeader(c)
+	if requestID == "" {
+		requestID = uuid.New().String()
+	}
+	c.Set(RequestIDHeader, requestID)
+	c.Locals("request_id", requestID)
+	return requestID
+}
+
+// LoggingConfig configures the request logging middleware
+type LoggingConfig struct {
+	// ExcludePaths are logged at Debug only. Entries ending with "*" match by prefix,
+	// all others must match the path exactly.
+	ExcludePaths []string `mapstructure:"ExcludePaths"`
+	// StartedLog is the level of the "Request started" entry logged before the handler runs:
+	// "off" (the default) only logs completion, "debug" or "info" log both
+	StartedLog string `mapstructure:"StartedLog"`
+}
+
+// Request started log levels
+const (
+	StartedLogOff   = "off"
+	StartedLogDebug = "debug"
+	StartedLogInfo  = "info"
+)
+
+// isExcludedPath reports whether path matches one of the exclusion entries
+func isExcludedPath(path string, excludePaths []string) bool {
+	for _, excluded := range excludePaths {
+		if prefix, ok := strings.CutSuffix(excluded, "*"); ok {
+			if strings.HasPrefix(path, prefix) {
+				return true
+			}
+		} else if path == excluded {
+			return true
+		}
+	}
+	return false
+}
+
+// responseStatus returns the status the client receives. A returned error has not been rendered
+// by ErrorHandler yet, so its status is derived from the error the same way.
+func responseStatus(c *fiber.Ctx, err error) int {
+	if err == nil {
+		return c.Response().StatusCode()
+	}
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) {
+		return fiberErr.Code
+	}
+	return fiber.StatusInternalServerError
+}
+
+// metricsPath returns the matched route pattern used as the path label. Requests no route
+// matched are grouped under "unmatched" so arbitrary paths cannot create new series.
+func metricsPath(c *fiber.Ctx, err error) string {
+	var fiberErr *fiber.Error
+	if errors.As(err, &fiberErr) && (fiberErr.Code == fiber.StatusNotFound || fiberErr.Code == fiber.StatusMethodNotAllowed) {
+		return "unmatched"
+	}
+	return c.Route().Path
+}
+
+// LoggingMiddleware logs HTTP requests with structured logging for Fiber and records them in the
+// request metrics, including requests whose handler returned an error
+func LoggingMiddleware(config LoggingConfig) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		start := time.Now()
 
@@ -102,21 +225,46 @@ func LoggingMiddleware() fiber.Handler {
 			"method":     c.Method(),
 			"path":       c.Path(),
 			"user_agent": c.Get("User-Agent"),
-			"remote_ip":  c.IP(),
+			"remote_ip":  ClientIP(c),
 			"referer":    c.Get("Referer"),
 		})
 
+		excluded := isExcludedPath(c.Path(), config.ExcludePaths)
+		switch strings.ToLower(config.StartedLog) {
+		case StartedLogInfo:
+			if excluded {
+				requestLogger.Debug("Request started")
+			} else {
+				requestLogger.Info("Request started")
+			}
+		case StartedLogDebug:
+			requestLogger.Debug("Request started")
+		}
+
 		err := c.Next()
 
 		duration := time.Since(start)
 
-		status := c.Response().StatusCode()
+		status := responseStatus(c, err)
+		metrics.ObserveRequest(c.Method(), metricsPath(c, err), status, duration)
 
 		logFields := map[string]interface{}{
 			"status":      status,
 			"duration_ms": duration.Milliseconds(),
 			"size":        len(c.Response().Body()),
 		}
+		// The matched route is only known after routing; its name groups logs by endpoint
+		if routeName := c.Route().Name; routeName != "" {
+			logFields["route_name"] = routeName
+		}
+
+		if excluded {
+			if err != nil {
+				logFields["error"] = err.Error()
+			}
+			requestLogger.WithFields(logFields).Debug("Request completed")
+			return err
+		}
 
 		if err != nil {
 			logFields["error"] = err.Error()
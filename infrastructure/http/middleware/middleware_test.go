@@ -0,0 +1,304 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/metrics"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// observeLogs installs a default logger that records entries at debug level and above
+func observeLogs(t *testing.T) *observer.ObservedLogs {
+	core, logs := observer.New(zapcore.DebugLevel)
+	previous := logger.GetDefault()
+	logger.SetDefault(logger.New(zap.New(core)))
+	t.Cleanup(func() { logger.SetDefault(previous) })
+	return logs
+}
+
+func TestLoggingMiddleware_ExcludedPaths(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+
+	app := fiber.New()
+	app.Use(LoggingMiddleware(LoggingConfig{ExcludePaths: []string{"/healthz", "/internal/*"}}))
+	handler := func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }
+	app.Get("/healthz", handler)
+	app.Get("/internal/stats", handler)
+	app.Get("/api/v1/orders", handler)
+
+	// Act
+	for _, path := range []string{"/healthz", "/internal/stats", "/api/v1/orders"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Assert
+	infoLogs := logs.FilterLevelExact(zapcore.InfoLevel).All()
+	assert.Len(t, infoLogs, 1)
+	assert.Equal(t, "/api/v1/orders", infoLogs[0].ContextMap()["path"])
+
+	debugLogs := logs.FilterLevelExact(zapcore.DebugLevel).All()
+	assert.Len(t, debugLogs, 2)
+}
+
+func TestLoggingMiddleware_StartedLog(t *testing.T) {
+	tests := []struct {
+		name       string
+		startedLog string
+		wantLevel  zapcore.Level
+		wantCount  int
+	}{
+		{name: "disabled by default", startedLog: "", wantCount: 0},
+		{name: "off", startedLog: StartedLogOff, wantCount: 0},
+		{name: "debug", startedLog: StartedLogDebug, wantLevel: zapcore.DebugLevel, wantCount: 1},
+		{name: "info", startedLog: StartedLogInfo, wantLevel: zapcore.InfoLevel, wantCount: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			logs := observeLogs(t)
+
+			app := fiber.New()
+			app.Use(LoggingMiddleware(LoggingConfig{StartedLog: tt.startedLog}))
+			app.Get("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders", nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			started := logs.FilterMessage("Request started").All()
+			if assert.Len(t, started, tt.wantCount) && tt.wantCount > 0 {
+				assert.Equal(t, tt.wantLevel, started[0].Level)
+				assert.Equal(t, "/api/v1/orders", started[0].ContextMap()["path"])
+			}
+			assert.Len(t, logs.FilterMessage("Request completed successfully").All(), 1)
+		})
+	}
+}
+
+func TestIsExcludedPath(t *testing.T) {
+	excluded := []string{"/healthz", "/metrics*"}
+
+	assert.True(t, isExcludedPath("/healthz", excluded))
+	assert.False(t, isExcludedPath("/healthz/deep", excluded))
+	assert.True(t, isExcludedPath("/metrics/go", excluded))
+	assert.False(t, isExcludedPath("/api/v1/orders", excluded))
+}
+
+func newRequestIDApp() *fiber.App {
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Get("/", func(c *fiber.Ctx) error {
+		return c.SendString(c.Locals("request_id").(string))
+	})
+	return app
+}
+
+func TestRequestIDMiddleware_DuplicateHeaders(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+	app := newRequestIDApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Add("X-Request-ID", "first-id")
+	req.Header.Add("x-request-id", "second-id")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "first-id", string(body))
+	assert.Equal(t, []string{"first-id"}, resp.Header.Values("X-Request-Id"))
+
+	warnings := logs.FilterMessage("Multiple X-Request-ID values received, using the first").All()
+	if assert.Len(t, warnings, 1) {
+		assert.EqualValues(t, 2, warnings[0].ContextMap()["count"])
+	}
+}
+
+func TestRequestIDMiddleware_CommaJoinedValues(t *testing.T) {
+	// Arrange
+	app := newRequestIDApp()
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("x-REQUEST-id", " proxy-id , client-id")
+
+	// Act
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	body, _ := io.ReadAll(resp.Body)
+	assert.Equal(t, "proxy-id", string(body))
+}
+
+func TestRequestIDMiddleware_GeneratesWhenMissing(t *testing.T) {
+	// Arrange
+	app := newRequestIDApp()
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.NotEmpty(t, resp.Header.Get(RequestIDHeader))
+}
+
+func TestLoggingMiddleware_RouteName(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+
+	app := fiber.New()
+	app.Use(LoggingMiddleware(LoggingConfig{}))
+	app.Get("/orders/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) }).Name("GetOrder")
+	app.Get("/unnamed", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	for _, path := range []string{"/orders/123", "/unnamed"} {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+	}
+
+	// Assert
+	completed := logs.FilterMessage("Request completed successfully").All()
+	if assert.Len(t, completed, 2) {
+		assert.Equal(t, "GetOrder", completed[0].ContextMap()["route_name"])
+		assert.NotContains(t, completed[1].ContextMap(), "route_name")
+	}
+}
+
+// requestCount returns the recorded request count for the label values, 0 when no series exists
+func requestCount(t *testing.T, method, path string, status int) float64 {
+	families, err := metrics.Registry.Gather()
+	assert.NoError(t, err)
+	want := map[string]string{"method": method, "path": path, "status": strconv.Itoa(status)}
+	for _, family := range families {
+		if family.GetName() != "order_management_http_requests_total" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			matched := 0
+			for _, label := range metric.GetLabel() {
+				if want[label.GetName()] == label.GetValue() {
+					matched++
+				}
+			}
+			if matched == len(want) {
+				return metric.GetCounter().GetValue()
+			}
+		}
+	}
+	return 0
+}
+
+func TestLoggingMiddleware_RecordsMetrics(t *testing.T) {
+	// Arrange
+	observeLogs(t)
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(LoggingMiddleware(LoggingConfig{}))
+	app.Get("/metrics-test/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/metrics-test-fail", func(c *fiber.Ctx) error { return errors.New("boom") })
+	app.Get("/metrics-test-conflict", func(c *fiber.Ctx) error { return fiber.ErrConflict })
+
+	okBefore := requestCount(t, http.MethodGet, "/metrics-test/:id", http.StatusOK)
+	failBefore := requestCount(t, http.MethodGet, "/metrics-test-fail", http.StatusInternalServerError)
+	conflictBefore := requestCount(t, http.MethodGet, "/metrics-test-conflict", http.StatusConflict)
+	unmatchedBefore := requestCount(t, http.MethodGet, "unmatched", http.StatusNotFound)
+
+	// Act
+	for _, path := range []string{"/metrics-test/1", "/metrics-test/2", "/metrics-test-fail", "/metrics-test-conflict", "/no-such-path"} {
+		_, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil))
+		assert.NoError(t, err)
+	}
+
+	// Assert
+	assert.Equal(t, okBefore+2, requestCount(t, http.MethodGet, "/metrics-test/:id", http.StatusOK))
+	assert.Equal(t, failBefore+1, requestCount(t, http.MethodGet, "/metrics-test-fail", http.StatusInternalServerError))
+	assert.Equal(t, conflictBefore+1, requestCount(t, http.MethodGet, "/metrics-test-conflict", http.StatusConflict))
+	assert.Equal(t, unmatchedBefore+1, requestCount(t, http.MethodGet, "unmatched", http.StatusNotFound))
+}
+
+func TestTimeoutMiddleware_DeadlineHeader(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(TimeoutMiddleware(5 * time.Second))
+	app.Get("/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	before := time.Now()
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	deadline, err := time.Parse(time.RFC3339Nano, resp.Header.Get(RequestDeadlineHeader))
+	if assert.NoError(t, err) {
+		assert.WithinDuration(t, before.Add(5*time.Second), deadline, time.Second)
+	}
+	assert.Empty(t, resp.Header.Get(RequestElapsedHeader))
+}
+
+func TestTimeoutMiddleware_UsesEarlierContextDeadline(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(ContextMiddleware(context.Background()))
+	app.Use(TimeoutMiddleware(time.Minute))
+	app.Get("/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+	req.Header.Set("X-Timeout-Duration", "2s")
+
+	// Act
+	before := time.Now()
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	deadline, err := time.Parse(time.RFC3339Nano, resp.Header.Get(RequestDeadlineHeader))
+	if assert.NoError(t, err) {
+		assert.WithinDuration(t, before.Add(2*time.Second), deadline, time.Second)
+	}
+}
+
+func TestTimeoutMiddleware_ReportsElapsedOnTimeout(t *testing.T) {
+	// Arrange
+	logs := observeLogs(t)
+	app := fiber.New()
+	app.Use(TimeoutMiddleware(20 * time.Millisecond))
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		<-c.UserContext().Done()
+		return c.Status(fiber.StatusGatewayTimeout).SendString("timed out")
+	})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/slow", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusGatewayTimeout, resp.StatusCode)
+	elapsed, err := strconv.Atoi(resp.Header.Get(RequestElapsedHeader))
+	if assert.NoError(t, err) {
+		assert.GreaterOrEqual(t, elapsed, 20)
+	}
+	assert.Len(t, logs.FilterMessage("Request exceeded its deadline").All(), 1)
+}
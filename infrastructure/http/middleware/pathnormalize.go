@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// PathNormalizationConfig controls how non-canonical request paths, such as //orders//123, are handled
+type PathNormalizationConfig struct {
+	// Redirect answers non-canonical paths with a redirect to the canonical one instead of
+	// routing them as if the canonical path had been requested
+	Redirect bool `mapstructure:"Redirect"`
+	// StripTrailingSlash also removes a trailing slash, so /orders/ becomes /orders
+	StripTrailingSlash bool `mapstructure:"StripTrailingSlash"`
+}
+
+// normalizePath collapses runs of slashes into one and, when stripTrailingSlash is set, drops a
+// trailing slash. The root path is always kept as "/".
+func normalizePath(path string, stripTrailingSlash bool) string {
+	if !strings.Contains(path, "//") && (!stripTrailingSlash || len(path) <= 1 || !strings.HasSuffix(path, "/")) {
+		return path
+	}
+
+	var b strings.Builder
+	b.Grow(len(path))
+	for i := 0; i < len(path); i++ {
+		if path[i] == '/' && i > 0 && path[i-1] == '/' {
+			continue
+		}
+		b.WriteByte(path[i])
+	}
+
+	normalized := b.String()
+	if stripTrailingSlash && len(normalized) > 1 {
+		normalized = strings.TrimSuffix(normalized, "/")
+	}
+	return normalized
+}
+
+// PathNormalizationMiddleware makes requests for non-canonical paths reach the route of the
+// canonical path, so //orders//123 is not a 404 and cache keys do not multiply. Depending on the
+// config the path is rewritten before routing or the client is redirected: 301 for GET and HEAD,
+// 308 for other methods so the method and body are kept. Register it before middleware that
+// matches on the path.
+func PathNormalizationMiddleware(config PathNormalizationConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		path := c.Path()
+		normalized := normalizePath(path, config.StripTrailingSlash)
+		if normalized == path {
+			return c.Next()
+		}
+
+		if config.Redirect {
+			location := normalized
+			if query := c.Request().URI().QueryString(); len(query) > 0 {
+				location += "?" + string(query)
+			}
+			status := fiber.StatusPermanentRedirect
+			if c.Method() == fiber.MethodGet || c.Method() == fiber.MethodHead {
+				status = fiber.StatusMovedPermanently
+			}
+			return c.Redirect(location, status)
+		}
+
+		c.Path(normalized)
+		return c.Next()
+	}
+}
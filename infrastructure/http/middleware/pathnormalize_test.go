@@ -0,0 +1,112 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newPathNormalizationApp(config PathNormalizationConfig) *fiber.App {
+	app := fiber.New(fiber.Config{StrictRouting: true})
+	app.Use(PathNormalizationMiddleware(config))
+	app.Get("/", func(c *fiber.Ctx) error { return c.SendString("root") })
+	app.Get("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendString("list") })
+	app.Get("/api/v1/orders/:id", func(c *fiber.Ctx) error { return c.SendString("order " + c.Params("id")) })
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+	return app
+}
+
+func TestNormalizePath(t *testing.T) {
+	cases := []struct {
+		path     string
+		strip    bool
+		wantPath string
+	}{
+		{path: "/api/v1/orders", strip: true, wantPath: "/api/v1/orders"},
+		{path: "//api//v1/orders///123", strip: true, wantPath: "/api/v1/orders/123"},
+		{path: "/api/v1/orders/", strip: true, wantPath: "/api/v1/orders"},
+		{path: "/api/v1/orders//", strip: true, wantPath: "/api/v1/orders"},
+		{path: "/api/v1/orders/", strip: false, wantPath: "/api/v1/orders/"},
+		{path: "/api/v1/orders//", strip: false, wantPath: "/api/v1/orders/"},
+		{path: "/", strip: true, wantPath: "/"},
+		{path: "//", strip: true, wantPath: "/"},
+	}
+
+	for _, tc := range cases {
+		assert.Equal(t, tc.wantPath, normalizePath(tc.path, tc.strip), tc.path)
+	}
+}
+
+func TestPathNormalizationMiddleware_Rewrite(t *testing.T) {
+	cases := []struct {
+		name     string
+		path     string
+		wantBody string
+	}{
+		{name: "double slashes", path: "//api//v1/orders//123", wantBody: "order 123"},
+		{name: "trailing slash", path: "/api/v1/orders/", wantBody: "list"},
+		{name: "root", path: "//", wantBody: "root"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			app := newPathNormalizationApp(PathNormalizationConfig{StripTrailingSlash: true})
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(http.MethodGet, tc.path, nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			body, err := io.ReadAll(resp.Body)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantBody, string(body))
+		})
+	}
+}
+
+func TestPathNormalizationMiddleware_KeepsTrailingSlashWhenNotStripping(t *testing.T) {
+	// Arrange
+	app := newPathNormalizationApp(PathNormalizationConfig{})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders/", nil))
+
+	// Assert: strict routing treats /api/v1/orders/ as a different route
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestPathNormalizationMiddleware_Redirect(t *testing.T) {
+	cases := []struct {
+		name         string
+		method       string
+		path         string
+		wantStatus   int
+		wantLocation string
+	}{
+		{name: "GET keeps the query", method: http.MethodGet, path: "//api//v1/orders/?page=2", wantStatus: http.StatusMovedPermanently, wantLocation: "/api/v1/orders?page=2"},
+		{name: "POST keeps the method", method: http.MethodPost, path: "/api//v1/orders", wantStatus: http.StatusPermanentRedirect, wantLocation: "/api/v1/orders"},
+		{name: "canonical path is served", method: http.MethodGet, path: "/api/v1/orders/123", wantStatus: http.StatusOK},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			app := newPathNormalizationApp(PathNormalizationConfig{Redirect: true, StripTrailingSlash: true})
+
+			// Act
+			resp, err := app.Test(httptest.NewRequest(tc.method, tc.path, nil))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.wantStatus, resp.StatusCode)
+			assert.Equal(t, tc.wantLocation, resp.Header.Get(fiber.HeaderLocation))
+		})
+	}
+}
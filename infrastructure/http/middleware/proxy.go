@@ -0,0 +1,94 @@
+package middleware
+
+import (
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+	"sync"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// trustedProxies are the proxies whose X-Forwarded-* headers are believed; empty trusts none
+var (
+	trustedProxiesMu sync.RWMutex
+	trustedProxies   []netip.Prefix
+)
+
+// SetTrustedProxies configures the proxies, given as IP addresses or CIDR ranges, whose
+// X-Forwarded-For, X-Forwarded-Proto and X-Forwarded-Host headers are believed. With none
+// configured the headers are ignored, since any client could set them.
+func SetTrustedProxies(proxies []string) error {
+	prefixes := make([]netip.Prefix, 0, len(proxies))
+	for _, proxy := range proxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if strings.Contains(proxy, "/") {
+			prefix, err := netip.ParsePrefix(proxy)
+			if err != nil {
+				return fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+			continue
+		}
+		addr, err := netip.ParseAddr(proxy)
+		if err != nil {
+			return fmt.Errorf("invalid trusted proxy %q: %w", proxy, err)
+		}
+		addr = addr.Unmap()
+		prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+	}
+
+	trustedProxiesMu.Lock()
+	defer trustedProxiesMu.Unlock()
+	trustedProxies = prefixes
+	return nil
+}
+
+// isTrustedProxy reports whether addr belongs to a configured trusted proxy
+func isTrustedProxy(addr netip.Addr) bool {
+	trustedProxiesMu.RLock()
+	defer trustedProxiesMu.RUnlock()
+
+	addr = addr.Unmap()
+	for _, prefix := range trustedProxies {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// FromTrustedProxy reports whether the request's connection comes from a trusted proxy, so its
+// forwarded headers can be believed
+func FromTrustedProxy(c *fiber.Ctx) bool {
+	addr, ok := netip.AddrFromSlice(c.Context().RemoteIP())
+	return ok && isTrustedProxy(addr)
+}
+
+// ClientIP returns the address of the client that made the request. Behind a trusted proxy it is
+// the rightmost X-Forwarded-For address that is not a trusted proxy itself: addresses left of it
+// were sent by the client and cannot be believed. Otherwise it is the connection's remote address.
+func ClientIP(c *fiber.Ctx) string {
+	remoteIP := c.Context().RemoteIP().String()
+	if !FromTrustedProxy(c) {
+		return remoteIP
+	}
+
+	hops := strings.Split(c.Get(fiber.HeaderXForwardedFor), ",")
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip := net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil {
+			// An address the trusted proxies did not write ends the chain that can be believed
+			break
+		}
+		addr, _ := netip.AddrFromSlice(ip)
+		if !isTrustedProxy(addr) {
+			return addr.Unmap().String()
+		}
+	}
+	return remoteIP
+}
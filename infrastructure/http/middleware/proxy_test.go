@@ -0,0 +1,68 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+// testPeerAddress is the remote address of requests sent with app.Test
+const testPeerAddress = "0.0.0.0"
+
+// useTrustedProxies trusts the given proxies for the duration of the test
+func useTrustedProxies(t *testing.T, proxies ...string) {
+	assert.NoError(t, SetTrustedProxies(proxies))
+	t.Cleanup(func() { _ = SetTrustedProxies(nil) })
+}
+
+func TestSetTrustedProxies_InvalidEntry(t *testing.T) {
+	// Act
+	err := SetTrustedProxies([]string{"10.0.0.0/8", "proxy.internal"})
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestClientIP(t *testing.T) {
+	cases := []struct {
+		name         string
+		proxies      []string
+		forwardedFor string
+		want         string
+	}{
+		{name: "no trusted proxies", forwardedFor: "198.51.100.7", want: testPeerAddress},
+		{name: "peer not trusted", proxies: []string{"10.0.0.0/8"}, forwardedFor: "198.51.100.7", want: testPeerAddress},
+		{name: "single hop", proxies: []string{testPeerAddress}, forwardedFor: "198.51.100.7", want: "198.51.100.7"},
+		{name: "rightmost untrusted hop", proxies: []string{testPeerAddress}, forwardedFor: "203.0.113.66, 198.51.100.7", want: "198.51.100.7"},
+		{name: "trusted hops skipped", proxies: []string{testPeerAddress, "10.0.0.0/8"}, forwardedFor: "203.0.113.66, 198.51.100.7, 10.1.2.3", want: "198.51.100.7"},
+		{name: "invalid hop stops the chain", proxies: []string{testPeerAddress, "10.0.0.0/8"}, forwardedFor: "198.51.100.7, unknown, 10.1.2.3", want: testPeerAddress},
+		{name: "missing header", proxies: []string{testPeerAddress}, want: testPeerAddress},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			useTrustedProxies(t, tc.proxies...)
+			app := fiber.New()
+			var got string
+			app.Get("/", func(c *fiber.Ctx) error {
+				got = ClientIP(c)
+				return nil
+			})
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tc.forwardedFor != "" {
+				req.Header.Set(fiber.HeaderXForwardedFor, tc.forwardedFor)
+			}
+
+			// Act
+			_, err := app.Test(req)
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tc.want, got)
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/clock"
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	RateLimitLimitHeader  = "X-RateLimit-Limit"
+	RateLimitPolicyHeader = "X-RateLimit-Policy"
+	RetryAfterHeader      = "Retry-After"
+
+	// rateLimitIdleTTL is how long an untouched bucket is kept before being purged
+	rateLimitIdleTTL = 5 * time.Minute
+)
+
+// RateLimitRule limits requests matching Method and Path to Rate requests per second per client IP.
+// Path segments starting with ":" match any single segment and a trailing "*" matches the rest.
+type RateLimitRule struct {
+	Method string  `mapstructure:"Method"`
+	Path   string  `mapstructure:"Path"`
+	Rate   float64 `mapstructure:"Rate"`
+	Burst  int     `mapstructure:"Burst"`
+}
+
+// RateLimitConfig holds the default rule and the per-route overrides
+type RateLimitConfig struct {
+	Enabled bool            `mapstructure:"Enabled"`
+	Default RateLimitRule   `mapstructure:"Default"`
+	Routes  []RateLimitRule `mapstructure:"Routes"`
+	// MaxConcurrentPerIP caps in-flight requests per client IP independently of Enabled, 0 disables
+	MaxConcurrentPerIP int `mapstructure:"MaxConcurrentPerIP"`
+}
+
+// name identifies the rule in the policy header, e.g. "POST /api/v1/orders"
+func (r RateLimitRule) name() string {
+	if r.Path == "" {
+		return "default"
+	}
+	method := strings.ToUpper(r.Method)
+	if method == "" {
+		method = "*"
+	}
+	return method + " " + r.Path
+}
+
+func (r RateLimitRule) burst() int {
+	if r.Burst > 0 {
+		return r.Burst
+	}
+	return int(math.Max(1, math.Ceil(r.Rate)))
+}
+
+// matches reports whether the rule applies to the given method and path
+func (r RateLimitRule) matches(method, path string) bool {
+	if r.Method != "" && !strings.EqualFold(r.Method, method) {
+		return false
+	}
+	return matchPathPattern(r.Path, path)
+}
+
+func matchPathPattern(pattern, path string) bool {
+	patternSegments := splitPath(pattern)
+	pathSegments := splitPath(path)
+
+	for i, segment := range patternSegments {
+		if segment == "*" {
+			return true
+		}
+		if i >= len(pathSegments) {
+			return false
+		}
+		if strings.HasPrefix(segment, ":") {
+			continue
+		}
+		if segment != pathSegments[i] {
+			return false
+		}
+	}
+	return len(patternSegments) == len(pathSegments)
+}
+
+func splitPath(path string) []string {
+	trimmed := strings.Trim(path, "/")
+	if trimmed == "" {
+		return nil
+	}
+	return strings.Split(trimmed, "/")
+}
+
+type tokenBucket struct {
+	tokens   float64
+	lastSeen time.Time
+}
+
+// rateLimiter keeps one token bucket per rule and client IP
+type rateLimiter struct {
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	lastPurge time.Time
+	clock     clock.Clock
+}
+
+func newRateLimiter() *rateLimiter {
+	return &rateLimiter{
+		buckets: make(map[string]*tokenBucket),
+		clock:   clock.New(),
+	}
+}
+
+// allow takes a token from the bucket and returns the wait time when none is left
+func (l *rateLimiter) allow(key string, rule RateLimitRule) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.clock.Now()
+	l.purge(now)
+
+	burst := float64(rule.burst())
+	bucket, ok := l.buckets[key]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, lastSeen: now}
+		l.buckets[key] = bucket
+	}
+
+	elapsed := now.Sub(bucket.lastSeen).Seconds()
+	bucket.tokens = math.Min(burst, bucket.tokens+elapsed*rule.Rate)
+	bucket.lastSeen = now
+
+	if bucket.tokens >= 1 {
+		bucket.tokens--
+		return true, 0
+	}
+
+	wait := time.Duration((1 - bucket.tokens) / rule.Rate * float64(time.Second))
+	return false, wait
+}
+
+func (l *rateLimiter) purge(now time.Time) {
+	if now.Sub(l.lastPurge) < rateLimitIdleTTL {
+		return
+	}
+	for key, bucket := range l.buckets {
+		if now.Sub(bucket.lastSeen) > rateLimitIdleTTL {
+			delete(l.buckets, key)
+		}
+	}
+	l.lastPurge = now
+}
+
+// RateLimitMiddleware limits requests per client IP using the first matching route rule,
+// falling back to the default rule. The applied rule is exposed in the X-RateLimit-Policy header.
+// Rejected requests get 429 with Retry-After and the request ID.
+func RateLimitMiddleware(config RateLimitConfig) fiber.Handler {
+	limiter := newRateLimiter()
+
+	return func(c *fiber.Ctx) error {
+		rule := config.Default
+		for _, routeRule := range config.Routes {
+			if routeRule.matches(c.Method(), c.Path()) {
+				rule = routeRule
+				break
+			}
+		}
+
+		if rule.Rate <= 0 {
+			return c.Next()
+		}
+
+		c.Set(RateLimitLimitHeader, strconv.FormatFloat(rule.Rate, 'f', -1, 64))
+		c.Set(RateLimitPolicyHeader, rule.name())
+
+		allowed, wait := limiter.allow(rule.name()+"|"+ClientIP(c), rule)
+		if !allowed {
+			requestID, _ := c.Locals("request_id").(string)
+			c.Set(RetryAfterHeader, strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+			return c.Status(fiber.StatusTooManyRequests).JSON(fiber.Map{
+				"message":    "Too many requests",
+				"request_id": requestID,
+			})
+		}
+
+		return c.Next()
+	}
+}
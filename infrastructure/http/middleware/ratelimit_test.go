@@ -0,0 +1,191 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/clock"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newRateLimitedApp(config RateLimitConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(RateLimitMiddleware(config))
+	app.Post("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusCreated) })
+	app.Get("/api/v1/orders/:id", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	return app
+}
+
+func TestRateLimitMiddleware_PerRouteLimits(t *testing.T) {
+	// Arrange
+	app := newRateLimitedApp(RateLimitConfig{
+		Enabled: true,
+		Default: RateLimitRule{Rate: 1, Burst: 1},
+		Routes: []RateLimitRule{
+			{Method: "POST", Path: "/api/v1/orders", Rate: 1, Burst: 2},
+			{Method: "GET", Path: "/api/v1/orders/:id", Rate: 100, Burst: 5},
+		},
+	})
+
+	// Act & Assert: writes are exhausted after the burst of 2
+	for i := 0; i < 2; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusCreated, resp.StatusCode)
+		assert.Equal(t, "POST /api/v1/orders", resp.Header.Get(RateLimitPolicyHeader))
+	}
+	resp, err := app.Test(httptest.NewRequest(http.MethodPost, "/api/v1/orders", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.NotEmpty(t, resp.Header.Get(RetryAfterHeader))
+
+	// Reads have their own, larger bucket
+	for i := 0; i < 5; i++ {
+		resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders/42", nil))
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, "GET /api/v1/orders/:id", resp.Header.Get(RateLimitPolicyHeader))
+		assert.Equal(t, "100", resp.Header.Get(RateLimitLimitHeader))
+	}
+}
+
+func TestRateLimitMiddleware_DefaultRule(t *testing.T) {
+	// Arrange
+	app := newRateLimitedApp(RateLimitConfig{
+		Enabled: true,
+		Default: RateLimitRule{Rate: 1, Burst: 1},
+		Routes: []RateLimitRule{
+			{Method: "POST", Path: "/api/v1/orders", Rate: 10},
+		},
+	})
+
+	// Act
+	first, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+	second, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, http.StatusOK, first.StatusCode)
+	assert.Equal(t, "default", first.Header.Get(RateLimitPolicyHeader))
+	assert.Equal(t, http.StatusTooManyRequests, second.StatusCode)
+}
+
+func TestMatchPathPattern(t *testing.T) {
+	assert.True(t, matchPathPattern("/api/v1/orders", "/api/v1/orders/"))
+	assert.True(t, matchPathPattern("/api/v1/orders/:id", "/api/v1/orders/7"))
+	assert.False(t, matchPathPattern("/api/v1/orders/:id", "/api/v1/orders"))
+	assert.False(t, matchPathPattern("/api/v1/orders/:id", "/api/v1/orders/7/status"))
+	assert.True(t, matchPathPattern("/api/v1/*", "/api/v1/orders/7/status"))
+}
+
+func TestRateLimitMiddleware_RejectionCarriesRequestID(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(RequestIDMiddleware())
+	app.Use(RateLimitMiddleware(RateLimitConfig{Enabled: true, Default: RateLimitRule{Rate: 0.5, Burst: 1}}))
+	app.Get("/healthz", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+
+	// Act
+	_, err := app.Test(httptest.NewRequest(http.MethodGet, "/healthz", nil))
+	assert.NoError(t, err)
+	req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+	req.Header.Set(RequestIDHeader, "req-429")
+	resp, err := app.Test(req)
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get(RetryAfterHeader))
+	assert.Equal(t, map[string]any{"message": "Too many requests", "request_id": "req-429"}, decodeBody(t, resp))
+}
+
+func TestRateLimitMiddleware_TrustedProxies(t *testing.T) {
+	forwardedRequest := func(forwardedFor string) *http.Request {
+		req := httptest.NewRequest(http.MethodGet, "/healthz", nil)
+		req.Header.Set(fiber.HeaderXForwardedFor, forwardedFor)
+		return req
+	}
+
+	cases := []struct {
+		name         string
+		proxies      []string
+		first        string
+		second       string
+		wantSecondOK bool
+	}{
+		{name: "separate buckets per forwarded client", proxies: []string{testPeerAddress}, first: "198.51.100.7", second: "203.0.113.9", wantSecondOK: true},
+		{name: "spoofed leftmost entries share the bucket", proxies: []string{testPeerAddress}, first: "198.51.100.1, 203.0.113.9", second: "198.51.100.2, 203.0.113.9"},
+		{name: "invalid forwarded addresses fall back to the peer", proxies: []string{testPeerAddress}, first: "unknown", second: "not-an-ip"},
+		{name: "header ignored from an untrusted peer", first: "198.51.100.7", second: "203.0.113.9"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			// Arrange
+			useTrustedProxies(t, tc.proxies...)
+			app := newRateLimitedApp(RateLimitConfig{
+				Enabled: true,
+				Default: RateLimitRule{Rate: 1, Burst: 1},
+			})
+
+			// Act
+			first, err := app.Test(forwardedRequest(tc.first))
+			assert.NoError(t, err)
+			second, err := app.Test(forwardedRequest(tc.second))
+			assert.NoError(t, err)
+
+			// Assert
+			assert.Equal(t, http.StatusOK, first.StatusCode)
+			assert.Equal(t, tc.wantSecondOK, second.StatusCode == http.StatusOK)
+		})
+	}
+}
+
+func TestRateLimiter_ConcurrentRequestsShareTheBurst(t *testing.T) {
+	// Arrange: a frozen clock so no token is refilled while the requests race
+	limiter := newRateLimiter()
+	limiter.clock = clock.NewMock(time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC))
+	rule := RateLimitRule{Rate: 1, Burst: 10}
+	var allowed atomic.Int64
+	var wg sync.WaitGroup
+
+	// Act
+	for i := 0; i < 100; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, _ := limiter.allow("default|198.51.100.7", rule); ok {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// Assert
+	assert.Equal(t, int64(10), allowed.Load())
+}
+
+func TestRateLimiter_PurgesIdleBuckets(t *testing.T) {
+	// Arrange
+	now := time.Date(2025, 3, 14, 9, 30, 0, 0, time.UTC)
+	mockClock := clock.NewMock(now)
+	limiter := newRateLimiter()
+	limiter.clock = mockClock
+	rule := RateLimitRule{Rate: 1, Burst: 1}
+	limiter.allow("default|198.51.100.7", rule)
+
+	// Act
+	mockClock.Advance(rateLimitIdleTTL + time.Second)
+	limiter.allow("default|203.0.113.9", rule)
+
+	// Assert
+	assert.Len(t, limiter.buckets, 1)
+	assert.Contains(t, limiter.buckets, "default|203.0.113.9")
+}
@@ -0,0 +1,213 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// APIVersionHeader carries the response format version on every response
+const APIVersionHeader = "X-API-Version"
+
+const (
+	// ResponseVersion1 is the original response shape, the handlers' bodies unchanged
+	ResponseVersion1 = 1
+	// ResponseVersion2 wraps every body in an envelope with api_version, data, meta, links or error
+	ResponseVersion2 = 2
+)
+
+// ResponseV2MediaType is the Accept value that opts in to version 2, and its response Content-Type
+const ResponseV2MediaType = "application/vnd.order.v2+json"
+
+// vendorMediaTypePattern matches the versioned media types of this API, e.g. application/vnd.order.v2+json
+var vendorMediaTypePattern = regexp.MustCompile(`application/vnd\.order\.v(\d+)\+json`)
+
+// negotiateResponseVersion picks the first supported version named in the Accept header. ok is
+// false when the header only names unsupported versions; without a versioned media type the
+// default applies.
+func negotiateResponseVersion(accept string, defaultVersion int) (version int, ok bool) {
+	matches := vendorMediaTypePattern.FindAllStringSubmatch(accept, -1)
+	if len(matches) == 0 {
+		return defaultVersion, true
+	}
+	for _, match := range matches {
+		if version, err := strconv.Atoi(match[1]); err == nil && (version == ResponseVersion1 || version == ResponseVersion2) {
+			return version, true
+		}
+	}
+	return defaultVersion, false
+}
+
+// ResponseVersionMiddleware selects the response shape from the Accept header and sets
+// X-API-Version on every response. Version 2 bodies, including errors rendered by the app's
+// ErrorHandler, are rewritten into the v2 envelope; version 1 responses pass through untouched.
+// Register it before middleware that writes responses so their bodies are reshaped too.
+func ResponseVersionMiddleware(defaultVersion int) fiber.Handler {
+	if defaultVersion != ResponseVersion2 {
+		defaultVersion = ResponseVersion1
+	}
+
+	return func(c *fiber.Ctx) error {
+		c.Vary(fiber.HeaderAccept)
+		version, ok := negotiateResponseVersion(c.Get(fiber.HeaderAccept), defaultVersion)
+		c.Set(APIVersionHeader, strconv.Itoa(version))
+
+		var err error
+		if ok {
+			if version == ResponseVersion2 {
+				useBaseETags(c)
+			}
+			err = c.Next()
+		} else {
+			err = c.Status(fiber.StatusNotAcceptable).JSON(fiber.Map{
+				"message": "Unsupported response version, accepted are application/vnd.order.v1+json and " + ResponseV2MediaType,
+			})
+		}
+		if version == ResponseVersion1 {
+			return err
+		}
+
+		// The error must be rendered now, otherwise Fiber would write it after the body is reshaped
+		if err != nil {
+			if handlerErr := c.App().Config().ErrorHandler(c, err); handlerErr != nil {
+				return handlerErr
+			}
+		}
+		return writeV2Envelope(c)
+	}
+}
+
+// v2ETagSuffix marks the ETags of version 2 responses: their bodies differ from version 1, so
+// a strong ETag must differ too
+const v2ETagSuffix = "-v2"
+
+// v2ETag returns the version 2 tag for a version 1 ETag
+func v2ETag(etag string) string {
+	if len(etag) < 2 || !strings.HasSuffix(etag, `"`) {
+		return etag
+	}
+	return etag[:len(etag)-1] + v2ETagSuffix + `"`
+}
+
+// baseETags maps the version 2 tags of an If-Match or If-None-Match header back to the tags the
+// handlers issue, leaving other tags unchanged
+func baseETags(header string) string {
+	tags := strings.Split(header, ",")
+	for i, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if base, ok := strings.CutSuffix(tag, v2ETagSuffix+`"`); ok {
+			tag = base + `"`
+		}
+		tags[i] = tag
+	}
+	return strings.Join(tags, ", ")
+}
+
+// useBaseETags rewrites the request's conditional headers so handlers compare the tags they issue
+func useBaseETags(c *fiber.Ctx) {
+	for _, header := range []string{fiber.HeaderIfMatch, fiber.HeaderIfNoneMatch} {
+		if value := c.Get(header); value != "" {
+			c.Request().Header.Set(header, baseETags(value))
+		}
+	}
+}
+
+// writeV2Envelope rewrites a JSON response body into the v2 envelope and marks its ETag as the
+// version 2 representation. Other content types, such as the Prometheus text format, are left
+// as they are.
+func writeV2Envelope(c *fiber.Ctx) error {
+	response := c.Response()
+	if !strings.HasPrefix(string(response.Header.ContentType()), fiber.MIMEApplicationJSON) || len(response.Body()) == 0 {
+		return nil
+	}
+
+	envelope, ok := v2Envelope(response.StatusCode(), response.Body())
+	if !ok {
+		return nil
+	}
+	response.SetBodyRaw(envelope)
+	response.Header.SetContentType(ResponseV2MediaType)
+	if etag := response.Header.Peek(fiber.HeaderETag); len(etag) > 0 {
+		response.Header.Set(fiber.HeaderETag, v2ETag(string(etag)))
+	}
+	return nil
+}
+
+// v2Envelope builds the version 2 body. Errors are moved under "error". Successful bodies put
+// their "data" under "data", keep "links", and move every other field, such as message or
+// pagination totals, under "meta"; bodies without "data" become the data themselves. Only the
+// top-level fields are parsed: values are copied byte for byte, so numbers such as money with 2
+// decimals stay exactly as the handler wrote them. ok is false when body is not valid JSON.
+func v2Envelope(status int, body []byte) (envelope []byte, ok bool) {
+	body = bytes.TrimSpace(body)
+	var object map[string]json.RawMessage
+	isObject := len(body) > 0 && body[0] == '{'
+	if isObject {
+		if err := json.Unmarshal(body, &object); err != nil {
+			return nil, false
+		}
+	} else if !json.Valid(body) {
+		return nil, false
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`{"api_version":"` + strconv.Itoa(ResponseVersion2) + `"`)
+	writeField := func(key string, value []byte) {
+		buf.WriteString(`,"` + key + `":`)
+		buf.Write(value)
+	}
+
+	data, hasData := object["data"]
+	switch {
+	case status >= fiber.StatusBadRequest && isObject:
+		writeField("error", body)
+	case status >= fiber.StatusBadRequest:
+		writeField("error", append(append([]byte(`{"message":`), body...), '}'))
+	case !hasData:
+		writeField("data", body)
+	default:
+		writeField("data", data)
+		if links, ok := object["links"]; ok {
+			writeField("links", links)
+		}
+		if meta := v2Meta(object); meta != nil {
+			writeField("meta", meta)
+		}
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), true
+}
+
+// v2Meta returns the fields of object other than data and links as a JSON object with sorted
+// keys, or nil when there are none
+func v2Meta(object map[string]json.RawMessage) []byte {
+	keys := make([]string, 0, len(object))
+	for key := range object {
+		if key != "data" && key != "links" {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) == 0 {
+		return nil
+	}
+	slices.Sort(keys)
+
+	var buf bytes.Buffer
+	buf.WriteByte('{')
+	for i, key := range keys {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		name, _ := json.Marshal(key)
+		buf.Write(name)
+		buf.WriteByte(':')
+		buf.Write(object[key])
+	}
+	buf.WriteByte('}')
+	return buf.Bytes()
+}
@@ -0,0 +1,230 @@
+package middleware
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newResponderApp(defaultVersion int) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: ErrorHandler})
+	app.Use(ResponseVersionMiddleware(defaultVersion))
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{
+			"data":  []fiber.Map{{"id": 1, "total_amount": json.RawMessage("100.50")}},
+			"total": 1,
+			"page":  1,
+		})
+	})
+	app.Post("/orders", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(fiber.Map{
+			"message": "Order created successfully",
+			"data":    fiber.Map{"id": 42},
+			"links":   fiber.Map{"self": "http://example.com/orders/42"},
+		})
+	})
+	app.Get("/healthz", func(c *fiber.Ctx) error {
+		return c.JSON(fiber.Map{"status": "OK"})
+	})
+	app.Get("/orders/:id", func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusUnprocessableEntity).JSON(fiber.Map{"message": "order is completed"})
+	})
+	app.Get("/tagged", func(c *fiber.Ctx) error {
+		c.Set(fiber.HeaderETag, `"abc"`)
+		return c.JSON(fiber.Map{"if_match": c.Get(fiber.HeaderIfMatch)})
+	})
+	app.Get("/metrics", func(c *fiber.Ctx) error {
+		return c.SendString("# HELP up\nup 1\n")
+	})
+	return app
+}
+
+func responderRequest(method, path, accept string) *http.Request {
+	req := httptest.NewRequest(method, path, nil)
+	if accept != "" {
+		req.Header.Set(fiber.HeaderAccept, accept)
+	}
+	return req
+}
+
+func decodeBody(t *testing.T, resp *http.Response) map[string]any {
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(resp.Body).Decode(&body))
+	return body
+}
+
+func TestResponseVersionMiddleware_DefaultShapeUnchanged(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodPost, "/orders", ""))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(APIVersionHeader))
+	assert.Equal(t, fiber.HeaderAccept, resp.Header.Get(fiber.HeaderVary))
+	assert.Equal(t, fiber.MIMEApplicationJSON, resp.Header.Get(fiber.HeaderContentType))
+	body := decodeBody(t, resp)
+	assert.Equal(t, "Order created successfully", body["message"])
+	assert.Equal(t, map[string]any{"id": float64(42)}, body["data"])
+	assert.NotContains(t, body, "api_version")
+}
+
+func TestResponseVersionMiddleware_V2Envelope(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodPost, "/orders", ResponseV2MediaType))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusCreated, resp.StatusCode)
+	assert.Equal(t, "2", resp.Header.Get(APIVersionHeader))
+	assert.Equal(t, ResponseV2MediaType, resp.Header.Get(fiber.HeaderContentType))
+	assert.Equal(t, map[string]any{
+		"api_version": "2",
+		"data":        map[string]any{"id": float64(42)},
+		"links":       map[string]any{"self": "http://example.com/orders/42"},
+		"meta":        map[string]any{"message": "Order created successfully"},
+	}, decodeBody(t, resp))
+}
+
+func TestResponseVersionMiddleware_V2KeepsNumbersAndMovesPagination(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodGet, "/orders", "application/json;q=0.5, "+ResponseV2MediaType))
+
+	// Assert
+	assert.NoError(t, err)
+	raw, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Contains(t, string(raw), `"total_amount":100.50`)
+	assert.Contains(t, string(raw), `"meta":{"page":1,"total":1}`)
+}
+
+func TestResponseVersionMiddleware_V2WithoutData(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodGet, "/healthz", ResponseV2MediaType))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]any{
+		"api_version": "2",
+		"data":        map[string]any{"status": "OK"},
+	}, decodeBody(t, resp))
+}
+
+func TestResponseVersionMiddleware_V2Errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus int
+		wantError  map[string]any
+	}{
+		{
+			name:       "handler error body",
+			path:       "/orders/7",
+			wantStatus: http.StatusUnprocessableEntity,
+			wantError:  map[string]any{"message": "order is completed"},
+		},
+		{
+			name:       "rendered by the error handler",
+			path:       "/missing",
+			wantStatus: http.StatusNotFound,
+			wantError:  map[string]any{"message": "Cannot GET /missing"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			app := newResponderApp(ResponseVersion1)
+
+			// Act
+			resp, err := app.Test(responderRequest(http.MethodGet, tt.path, ResponseV2MediaType))
+
+			// Assert
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			assert.Equal(t, map[string]any{"api_version": "2", "error": tt.wantError}, decodeBody(t, resp))
+		})
+	}
+}
+
+func TestResponseVersionMiddleware_V2LeavesOtherContentTypes(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodGet, "/metrics", ResponseV2MediaType))
+
+	// Assert
+	assert.NoError(t, err)
+	raw, err := io.ReadAll(resp.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, "# HELP up\nup 1\n", string(raw))
+}
+
+func TestResponseVersionMiddleware_ConfiguredDefaultV2(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion2)
+
+	// Act
+	defaulted, err := app.Test(responderRequest(http.MethodGet, "/healthz", ""))
+	assert.NoError(t, err)
+	optedOut, err := app.Test(responderRequest(http.MethodGet, "/healthz", "application/vnd.order.v1+json"))
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "2", defaulted.Header.Get(APIVersionHeader))
+	assert.Contains(t, decodeBody(t, defaulted), "api_version")
+	assert.Equal(t, "1", optedOut.Header.Get(APIVersionHeader))
+	assert.Equal(t, map[string]any{"status": "OK"}, decodeBody(t, optedOut))
+}
+
+func TestResponseVersionMiddleware_UnsupportedVersion(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+
+	// Act
+	resp, err := app.Test(responderRequest(http.MethodGet, "/healthz", "application/vnd.order.v3+json"))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotAcceptable, resp.StatusCode)
+	assert.Equal(t, "1", resp.Header.Get(APIVersionHeader))
+	assert.Equal(t, fiber.HeaderAccept, resp.Header.Get(fiber.HeaderVary))
+	assert.Contains(t, decodeBody(t, resp)["message"], "Unsupported response version")
+}
+
+func TestResponseVersionMiddleware_ETagPerRepresentation(t *testing.T) {
+	// Arrange
+	app := newResponderApp(ResponseVersion1)
+	v2Request := responderRequest(http.MethodGet, "/tagged", ResponseV2MediaType)
+	v2Request.Header.Set(fiber.HeaderIfMatch, `"abc-v2", W/"old"`)
+
+	// Act
+	v1, err := app.Test(responderRequest(http.MethodGet, "/tagged", ""))
+	assert.NoError(t, err)
+	v2, err := app.Test(v2Request)
+	assert.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, `"abc"`, v1.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, `"abc-v2"`, v2.Header.Get(fiber.HeaderETag))
+	assert.Equal(t, fiber.HeaderAccept, v2.Header.Get(fiber.HeaderVary))
+	assert.Equal(t, map[string]any{"if_match": `"abc", W/"old"`}, decodeBody(t, v2)["data"])
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/database"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ServerTimingHeader breaks request processing into phases for browser dev tools
+const ServerTimingHeader = "Server-Timing"
+
+// ServerTimingMiddleware sets Server-Timing with the time spent in database queries (db), the
+// rest of the processing after this middleware (handler) and their sum (total), in milliseconds.
+// It must run after ContextMiddleware, which replaces the request context.
+func ServerTimingMiddleware() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		start := time.Now()
+		ctx, timer := database.WithQueryTimer(c.UserContext())
+		c.SetUserContext(ctx)
+
+		err := c.Next()
+
+		total := time.Since(start)
+		db := timer.Elapsed()
+		c.Set(ServerTimingHeader, formatServerTiming(db, total-db, total))
+		return err
+	}
+}
+
+// formatServerTiming renders the phases as Server-Timing metrics with a dur parameter
+func formatServerTiming(db, handler, total time.Duration) string {
+	return fmt.Sprintf("db;dur=%s, handler;dur=%s, total;dur=%s", timingMillis(db), timingMillis(handler), timingMillis(total))
+}
+
+func timingMillis(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	return fmt.Sprintf("%.3f", float64(d)/float64(time.Millisecond))
+}
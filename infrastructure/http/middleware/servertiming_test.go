@@ -0,0 +1,61 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var serverTimingPattern = regexp.MustCompile(`^db;dur=(\d+\.\d{3}), handler;dur=(\d+\.\d{3}), total;dur=(\d+\.\d{3})$`)
+
+func TestServerTimingMiddleware_Header(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(ServerTimingMiddleware())
+	app.Get("/orders", func(c *fiber.Ctx) error {
+		time.Sleep(5 * time.Millisecond)
+		return c.SendStatus(fiber.StatusOK)
+	})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	header := resp.Header.Get(ServerTimingHeader)
+	match := serverTimingPattern.FindStringSubmatch(header)
+	if assert.NotNil(t, match, "unexpected Server-Timing %q", header) {
+		db, _ := strconv.ParseFloat(match[1], 64)
+		handler, _ := strconv.ParseFloat(match[2], 64)
+		total, _ := strconv.ParseFloat(match[3], 64)
+		assert.Zero(t, db)
+		assert.GreaterOrEqual(t, handler, 5.0)
+		assert.GreaterOrEqual(t, total, handler)
+	}
+}
+
+func TestServerTimingMiddleware_SetOnErrors(t *testing.T) {
+	// Arrange
+	app := fiber.New()
+	app.Use(ServerTimingMiddleware())
+	app.Get("/orders", func(c *fiber.Ctx) error { return fiber.ErrServiceUnavailable })
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/orders", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusServiceUnavailable, resp.StatusCode)
+	assert.Regexp(t, serverTimingPattern, resp.Header.Get(ServerTimingHeader))
+}
+
+func TestFormatServerTiming(t *testing.T) {
+	assert.Equal(t, "db;dur=12.500, handler;dur=0.250, total;dur=12.750", formatServerTiming(12500*time.Microsecond, 250*time.Microsecond, 12750*time.Microsecond))
+	assert.Equal(t, "db;dur=0.000, handler;dur=0.000, total;dur=1.000", formatServerTiming(0, -time.Millisecond, time.Millisecond))
+}
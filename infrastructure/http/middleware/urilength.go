@@ -0,0 +1,36 @@
+package middleware
+
+import (
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/gofiber/fiber/v2"
+)
+
+// URILengthConfig limits the size of the request URI. Zero disables a limit.
+type URILengthConfig struct {
+	// MaxURLLength is the maximum length of the path plus query string
+	MaxURLLength int `mapstructure:"MaxURLLength"`
+	// MaxQueryLength is the maximum length of the raw query string
+	MaxQueryLength int `mapstructure:"MaxQueryLength"`
+}
+
+// URILengthMiddleware rejects requests whose URL or query string exceeds the configured length with 414
+func URILengthMiddleware(config URILengthConfig) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		urlLength := len(c.OriginalURL())
+		queryLength := len(c.Request().URI().QueryString())
+
+		if (config.MaxURLLength > 0 && urlLength > config.MaxURLLength) ||
+			(config.MaxQueryLength > 0 && queryLength > config.MaxQueryLength) {
+			logger.LoggerWithRequestIDFromContext(c.UserContext()).Warn("Request URI too long",
+				"path", c.Path(),
+				"url_length", urlLength,
+				"query_length", queryLength,
+			)
+			return c.Status(fiber.StatusRequestURITooLong).JSON(fiber.Map{
+				"message": "Request URI too long",
+			})
+		}
+
+		return c.Next()
+	}
+}
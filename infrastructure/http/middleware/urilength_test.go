@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+func newURILengthApp(config URILengthConfig) *fiber.App {
+	app := fiber.New()
+	app.Use(URILengthMiddleware(config))
+	app.Get("/api/v1/orders", func(c *fiber.Ctx) error { return c.SendStatus(fiber.StatusOK) })
+	return app
+}
+
+func TestURILengthMiddleware_OversizedQuery(t *testing.T) {
+	// Arrange
+	app := newURILengthApp(URILengthConfig{MaxURLLength: 4096, MaxQueryLength: 64})
+	query := "fields=" + strings.Repeat("id,", 50)
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders?"+query, nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestURITooLong, resp.StatusCode)
+}
+
+func TestURILengthMiddleware_OversizedURL(t *testing.T) {
+	// Arrange
+	app := newURILengthApp(URILengthConfig{MaxURLLength: 32})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders?page=1&size=10&fields=id", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusRequestURITooLong, resp.StatusCode)
+}
+
+func TestURILengthMiddleware_WithinLimits(t *testing.T) {
+	// Arrange
+	app := newURILengthApp(URILengthConfig{MaxURLLength: 64, MaxQueryLength: 32})
+
+	// Act
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/api/v1/orders?page=1&size=10", nil))
+
+	// Assert
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
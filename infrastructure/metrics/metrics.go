@@ -0,0 +1,92 @@
+package metrics
+
+import (
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "order_management"
+
+// Registry holds every metric exposed at /metrics
+var Registry = prometheus.NewRegistry()
+
+var (
+	httpRequestsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests handled, by method, route path and status code.",
+	}, []string{"method", "path", "status"})
+
+	httpRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request duration in seconds, by method, route path and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "path", "status"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequestsTotal,
+		httpRequestDuration,
+		poolCollector{},
+	)
+}
+
+// ObserveRequest records one finished HTTP request. path must be the route pattern, such as
+// /api/v1/orders/:id, not the raw path, so every order does not get its own series.
+func ObserveRequest(method, path string, status int, duration time.Duration) {
+	statusLabel := strconv.Itoa(status)
+	httpRequestsTotal.WithLabelValues(method, path, statusLabel).Inc()
+	httpRequestDuration.WithLabelValues(method, path, statusLabel).Observe(duration.Seconds())
+}
+
+// PoolStatter is implemented by database pools that expose pgxpool statistics
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// pool is the database pool reported by the pool gauges, nil reports nothing
+var pool atomic.Pointer[PoolStatter]
+
+// SetPool sets the database pool whose connection counts are exported, nil stops exporting them
+func SetPool(p PoolStatter) {
+	if p == nil {
+		pool.Store(nil)
+		return
+	}
+	pool.Store(&p)
+}
+
+var (
+	poolAcquiredConnsDesc = prometheus.NewDesc(namespace+"_db_pool_acquired_conns", "Database connections currently in use.", nil, nil)
+	poolIdleConnsDesc     = prometheus.NewDesc(namespace+"_db_pool_idle_conns", "Idle database connections in the pool.", nil, nil)
+	poolTotalConnsDesc    = prometheus.NewDesc(namespace+"_db_pool_total_conns", "Database connections in the pool, in use, idle or being opened.", nil, nil)
+)
+
+// poolCollector reads the pool statistics at scrape time so the gauges are never stale
+type poolCollector struct{}
+
+func (poolCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- poolAcquiredConnsDesc
+	ch <- poolIdleConnsDesc
+	ch <- poolTotalConnsDesc
+}
+
+func (poolCollector) Collect(ch chan<- prometheus.Metric) {
+	p := pool.Load()
+	if p == nil {
+		return
+	}
+	stat := (*p).Stat()
+	ch <- prometheus.MustNewConstMetric(poolAcquiredConnsDesc, prometheus.GaugeValue, float64(stat.AcquiredConns()))
+	ch <- prometheus.MustNewConstMetric(poolIdleConnsDesc, prometheus.GaugeValue, float64(stat.IdleConns()))
+	ch <- prometheus.MustNewConstMetric(poolTotalConnsDesc, prometheus.GaugeValue, float64(stat.TotalConns()))
+}
@@ -0,0 +1,60 @@
+package readiness
+
+import (
+	"sync"
+	"time"
+)
+
+// HealthState is shared by the liveness and readiness probes. It remembers when the database was
+// last reached and since when the connection pool has had no connection available, so a pool
+// that is only briefly saturated does not take the instance out of the load balancer.
+type HealthState struct {
+	mu             sync.RWMutex
+	lastPing       time.Time
+	saturatedSince time.Time
+}
+
+func NewHealthState() *HealthState {
+	return &HealthState{}
+}
+
+// RecordPing notes a successful database ping at the given time
+func (s *HealthState) RecordPing(at time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if at.After(s.lastPing) {
+		s.lastPing = at
+	}
+}
+
+// LastSuccessfulPing returns when the database was last reached, ok is false when it never was
+func (s *HealthState) LastSuccessfulPing() (at time.Time, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	return s.lastPing, !s.lastPing.IsZero()
+}
+
+// ObservePool records whether the pool had a connection available at now and reports whether it
+// has been saturated for longer than grace
+func (s *HealthState) ObservePool(now time.Time, available bool, grace time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if available {
+		s.saturatedSince = time.Time{}
+		return false
+	}
+	if s.saturatedSince.IsZero() {
+		s.saturatedSince = now
+	}
+	return now.Sub(s.saturatedSince) > grace
+}
+
+var defaultHealthState = NewHealthState()
+
+// DefaultHealthState returns the application wide health state
+func DefaultHealthState() *HealthState {
+	return defaultHealthState
+}
@@ -0,0 +1,35 @@
+package readiness
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHealthState_LastSuccessfulPing(t *testing.T) {
+	state := NewHealthState()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	_, ok := state.LastSuccessfulPing()
+	assert.False(t, ok)
+
+	state.RecordPing(now)
+	state.RecordPing(now.Add(-time.Second))
+
+	at, ok := state.LastSuccessfulPing()
+	assert.True(t, ok)
+	assert.Equal(t, now, at)
+}
+
+func TestHealthState_ObservePool(t *testing.T) {
+	state := NewHealthState()
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	grace := 5 * time.Second
+
+	assert.False(t, state.ObservePool(now, false, grace), "saturation just started")
+	assert.False(t, state.ObservePool(now.Add(grace), false, grace), "still within the grace period")
+	assert.True(t, state.ObservePool(now.Add(grace+time.Second), false, grace), "saturated past the grace period")
+	assert.False(t, state.ObservePool(now.Add(grace+2*time.Second), true, grace), "a free connection resets it")
+	assert.False(t, state.ObservePool(now.Add(grace+3*time.Second), false, grace), "the grace period starts over")
+}
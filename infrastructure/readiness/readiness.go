@@ -0,0 +1,79 @@
+package readiness
+
+import (
+	"fmt"
+	"sync"
+)
+
+type State string
+
+const (
+	StateStarting State = "starting"
+	StateReady    State = "ready"
+	StateDraining State = "draining"
+	StateStopped  State = "stopped"
+)
+
+// allowedTransitions lists the states each state may move to
+var allowedTransitions = map[State][]State{
+	StateStarting: {StateReady, StateDraining, StateStopped},
+	StateReady:    {StateDraining, StateStopped},
+	StateDraining: {StateStopped},
+	StateStopped:  {},
+}
+
+// Tracker holds the readiness state of the application
+type Tracker struct {
+	mu    sync.RWMutex
+	state State
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{state: StateStarting}
+}
+
+// State returns the current state
+func (t *Tracker) State() State {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.state
+}
+
+// IsReady reports whether the application should receive traffic
+func (t *Tracker) IsReady() bool {
+	return t.State() == StateReady
+}
+
+// Transition moves to the next state, rejecting transitions that go backwards
+func (t *Tracker) Transition(next State) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.state == next {
+		return nil
+	}
+	for _, allowed := range allowedTransitions[t.state] {
+		if allowed == next {
+			t.state = next
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid readiness transition from %s to %s", t.state, next)
+}
+
+var defaultTracker = NewTracker()
+
+// Default returns the application wide tracker
+func Default() *Tracker {
+	return defaultTracker
+}
+
+// Transition moves the default tracker to the next state
+func Transition(next State) error {
+	return defaultTracker.Transition(next)
+}
+
+// Current returns the state of the default tracker
+func Current() State {
+	return defaultTracker.State()
+}
@@ -0,0 +1,54 @@
+package readiness
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker_StartsInStarting(t *testing.T) {
+	tracker := NewTracker()
+
+	assert.Equal(t, StateStarting, tracker.State())
+	assert.False(t, tracker.IsReady())
+}
+
+func TestTracker_FullLifecycle(t *testing.T) {
+	tracker := NewTracker()
+
+	assert.NoError(t, tracker.Transition(StateReady))
+	assert.True(t, tracker.IsReady())
+
+	assert.NoError(t, tracker.Transition(StateDraining))
+	assert.False(t, tracker.IsReady())
+	assert.Equal(t, StateDraining, tracker.State())
+
+	assert.NoError(t, tracker.Transition(StateStopped))
+	assert.Equal(t, StateStopped, tracker.State())
+}
+
+func TestTracker_DrainingBeforeReady(t *testing.T) {
+	tracker := NewTracker()
+
+	assert.NoError(t, tracker.Transition(StateDraining))
+	assert.Equal(t, StateDraining, tracker.State())
+}
+
+func TestTracker_RejectsBackwardTransitions(t *testing.T) {
+	tracker := NewTracker()
+	assert.NoError(t, tracker.Transition(StateReady))
+	assert.NoError(t, tracker.Transition(StateDraining))
+
+	err := tracker.Transition(StateReady)
+
+	assert.Error(t, err)
+	assert.Equal(t, StateDraining, tracker.State())
+}
+
+func TestTracker_SameStateIsNoop(t *testing.T) {
+	tracker := NewTracker()
+	assert.NoError(t, tracker.Transition(StateReady))
+
+	assert.NoError(t, tracker.Transition(StateReady))
+	assert.True(t, tracker.IsReady())
+}
@@ -0,0 +1,27 @@
+package buildinfo
+
+// Info describes the build of the running binary
+type Info struct {
+	Version string `json:"version"`
+	Commit  string `json:"commit"`
+}
+
+var current = Info{
+	Version: "dev",
+	Commit:  "unknown",
+}
+
+// Set records the build information, usually injected into main via ldflags
+func Set(version, commit string) {
+	if version != "" {
+		current.Version = version
+	}
+	if commit != "" {
+		current.Commit = commit
+	}
+}
+
+// Get returns the build information of the running binary
+func Get() Info {
+	return current
+}
@@ -0,0 +1,54 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock abstracts time.Now so time-dependent logic can be tested deterministically
+type Clock interface {
+	Now() time.Time
+}
+
+// RealClock returns the current wall clock time
+type RealClock struct{}
+
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// New returns the real clock
+func New() Clock {
+	return RealClock{}
+}
+
+// MockClock returns a controlled time, safe for concurrent use
+type MockClock struct {
+	mu  sync.RWMutex
+	now time.Time
+}
+
+// NewMock returns a MockClock fixed at the given time
+func NewMock(now time.Time) *MockClock {
+	return &MockClock{now: now}
+}
+
+func (c *MockClock) Now() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.now
+}
+
+// Set moves the clock to the given time
+func (c *MockClock) Set(now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = now
+}
+
+// Advance moves the clock forward by d
+func (c *MockClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
@@ -80,18 +80,23 @@ func WaitForContext(ctx context.Context, timeout time.Duration) error {
 	}
 }
 
-// ContextWithValues creates a context with multiple values
+// valueKey is the key type of values stored by ContextWithValues. Being unexported, its keys
+// cannot collide with plain string keys or keys set by other packages under the same name.
+type valueKey string
+
+// ContextWithValues creates a context with multiple values, read back with GetContextValue
 func ContextWithValues(parent context.Context, keyValues map[string]interface{}) context.Context {
 	ctx := parent
 	for key, value := range keyValues {
-		ctx = context.WithValue(ctx, key, value)
+		ctx = context.WithValue(ctx, valueKey(key), value)
 	}
 	return ctx
 }
 
-// GetContextValue safely retrieves a value from context
+// GetContextValue safely retrieves a value stored by ContextWithValues. Values stored by other
+// packages under the same name, or under a plain string key, are not returned.
 func GetContextValue(ctx context.Context, key string) (interface{}, bool) {
-	value := ctx.Value(key)
+	value := ctx.Value(valueKey(key))
 	return value, value != nil
 }
 
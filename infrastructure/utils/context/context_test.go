@@ -0,0 +1,49 @@
+package utils
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type otherKey string
+
+func TestContextWithValues_RoundTrip(t *testing.T) {
+	// Arrange
+	ctx := ContextWithValues(context.Background(), map[string]interface{}{
+		"tenant": "acme",
+		"retry":  3,
+	})
+
+	// Act
+	tenant, tenantOK := GetContextValue(ctx, "tenant")
+	retry, retryOK := GetContextValue(ctx, "retry")
+	_, missingOK := GetContextValue(ctx, "missing")
+
+	// Assert
+	assert.True(t, tenantOK)
+	assert.Equal(t, "acme", tenant)
+	assert.True(t, retryOK)
+	assert.Equal(t, 3, retry)
+	assert.False(t, missingOK)
+}
+
+func TestContextWithValues_DoesNotCollideWithOtherKeys(t *testing.T) {
+	// Arrange: the same name stored under a plain string key and another package's key type
+	//nolint:staticcheck // a plain string key is exactly what this test guards against
+	ctx := context.WithValue(context.Background(), "tenant", "plain")
+	ctx = context.WithValue(ctx, otherKey("tenant"), "other")
+
+	// Act
+	_, foundBefore := GetContextValue(ctx, "tenant")
+	ctx = ContextWithValues(ctx, map[string]interface{}{"tenant": "acme"})
+	tenant, found := GetContextValue(ctx, "tenant")
+
+	// Assert
+	assert.False(t, foundBefore)
+	assert.True(t, found)
+	assert.Equal(t, "acme", tenant)
+	assert.Equal(t, "plain", ctx.Value("tenant"))
+	assert.Equal(t, "other", ctx.Value(otherKey("tenant")))
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"path"
+
+	"go.uber.org/zap/zapcore"
+)
+
+const componentKey = "component"
+
+// knownComponents maps package directories to the subsystem name used in the component field
+var knownComponents = map[string]string{
+	"repositories": "repository",
+	"services":     "service",
+	"v1":           "handler",
+	"route":        "router",
+	"middleware":   "middleware",
+	"http":         "http",
+	"database":     "database",
+	"readiness":    "readiness",
+	"worker":       "worker",
+	"cmd":          "cmd",
+}
+
+// componentFor derives the component from the caller's package directory; unknown packages use
+// the directory name as is
+func componentFor(file string) string {
+	dir := path.Base(path.Dir(file))
+	if dir == "." || dir == "/" {
+		return ""
+	}
+	if component, ok := knownComponents[dir]; ok {
+		return component
+	}
+	return dir
+}
+
+// componentCore adds a component field derived from the caller to every entry. Loggers that already
+// carry a component, e.g. from WithComponent, keep theirs.
+type componentCore struct {
+	zapcore.Core
+	hasComponent bool
+}
+
+func newComponentCore(core zapcore.Core) zapcore.Core {
+	return &componentCore{Core: core}
+}
+
+func (c *componentCore) With(fields []zapcore.Field) zapcore.Core {
+	return &componentCore{
+		Core:         c.Core.With(fields),
+		hasComponent: c.hasComponent || hasComponentField(fields),
+	}
+}
+
+func (c *componentCore) Check(entry zapcore.Entry, checked *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(entry.Level) {
+		return checked.AddCore(entry, c)
+	}
+	return checked
+}
+
+func (c *componentCore) Write(entry zapcore.Entry, fields []zapcore.Field) error {
+	if !c.hasComponent && entry.Caller.Defined && !hasComponentField(fields) {
+		if component := componentFor(entry.Caller.File); component != "" {
+			fields = append(fields, zapcore.Field{Key: componentKey, Type: zapcore.StringType, String: component})
+		}
+	}
+	return c.Core.Write(entry, fields)
+}
+
+func hasComponentField(fields []zapcore.Field) bool {
+	for _, field := range fields {
+		if field.Key == componentKey {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestComponentFor(t *testing.T) {
+	tests := map[string]string{
+		"/src/app/application/repositories/order_repository.go": "repository",
+		"/src/app/application/services/order_service.go":        "service",
+		"/src/app/infrastructure/http/api/v1/order.go":          "handler",
+		"/src/app/infrastructure/http/middleware/middleware.go": "middleware",
+		"/src/app/infrastructure/worker/order_expiry.go":        "worker",
+		"/src/app/infrastructure/utils/clock/clock.go":          "clock",
+		"main.go": "",
+	}
+
+	for file, expected := range tests {
+		assert.Equal(t, expected, componentFor(file), file)
+	}
+}
+
+func TestComponentCore_AddsComponent(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zapcore.InfoLevel)
+	l := New(zap.New(newComponentCore(core), zap.AddCaller(), zap.AddCallerSkip(1)))
+
+	// Act
+	l.Info("derived")
+	l.WithComponent("order_expiry_worker").Info("explicit")
+	l.WithField("request_id", "abc").Info("with fields")
+
+	// Assert
+	entries := logs.All()
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "logger", entries[0].ContextMap()["component"])
+		assert.Equal(t, "order_expiry_worker", entries[1].ContextMap()["component"])
+		assert.Equal(t, "logger", entries[2].ContextMap()["component"])
+		assert.Equal(t, "abc", entries[2].ContextMap()["request_id"])
+	}
+}
+
+func TestComponentCore_RespectsLevel(t *testing.T) {
+	// Arrange
+	core, logs := observer.New(zapcore.WarnLevel)
+	l := New(zap.New(newComponentCore(core), zap.AddCaller(), zap.AddCallerSkip(1)))
+
+	// Act
+	l.Info("dropped")
+	l.Warn("kept")
+
+	// Assert
+	assert.Equal(t, 1, logs.Len())
+}
+
+func TestInitialize_AddComponentWithoutSource(t *testing.T) {
+	previous := GetDefault()
+	t.Cleanup(func() { SetDefault(previous) })
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.log")
+	err := Initialize(LoggerConfig{Level: "info", Format: "json", AddComponent: true, Output: path})
+	assert.NoError(t, err)
+
+	// Act
+	GetDefault().Info("component check")
+	_ = GetDefault().zap.Sync()
+
+	// Assert
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"component":"logger"`)
+	assert.NotContains(t, string(content), `"source"`)
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"os"
+
+	"golang.org/x/term"
+)
+
+// Log formats accepted in LoggerConfig.Format
+const (
+	FormatJSON    = "json"
+	FormatCompact = "compact"
+	// FormatAuto picks compact with color when the console output is a terminal and JSON otherwise
+	FormatAuto = "auto"
+)
+
+// isTerminal reports whether fd refers to a terminal; tests replace it
+var isTerminal = term.IsTerminal
+
+// resolveAutoFormat replaces FormatAuto with the concrete format for where the console output
+// goes. Output to a file is never a terminal. Other formats are returned unchanged.
+func resolveAutoFormat(config LoggerConfig) LoggerConfig {
+	if config.Format != FormatAuto {
+		return config
+	}
+
+	fd := -1
+	switch config.Output {
+	case "", "stdout":
+		fd = int(os.Stdout.Fd())
+	case "stderr":
+		fd = int(os.Stderr.Fd())
+	default:
+		// With file logging enabled the console output stays on stdout
+		if config.EnableFile {
+			fd = int(os.Stdout.Fd())
+		}
+	}
+
+	if fd >= 0 && isTerminal(fd) {
+		config.Format = FormatCompact
+		config.EnableColor = true
+	} else {
+		config.Format = FormatJSON
+		config.EnableColor = false
+	}
+	return config
+}
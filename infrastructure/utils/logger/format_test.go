@@ -0,0 +1,74 @@
+package logger
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// useTerminal makes isTerminal report terminal for every fd and records the fds it was asked about
+func useTerminal(t *testing.T, terminal bool) *[]int {
+	t.Helper()
+	previous := isTerminal
+	var checked []int
+	isTerminal = func(fd int) bool {
+		checked = append(checked, fd)
+		return terminal
+	}
+	t.Cleanup(func() { isTerminal = previous })
+	return &checked
+}
+
+func TestResolveAutoFormat(t *testing.T) {
+	tests := []struct {
+		name          string
+		config        LoggerConfig
+		terminal      bool
+		expected      string
+		expectedColor bool
+		expectedFd    []int
+	}{
+		{name: "stdout terminal", config: LoggerConfig{Format: FormatAuto}, terminal: true, expected: FormatCompact, expectedColor: true, expectedFd: []int{int(os.Stdout.Fd())}},
+		{name: "stdout piped", config: LoggerConfig{Format: FormatAuto, EnableColor: true}, terminal: false, expected: FormatJSON, expectedFd: []int{int(os.Stdout.Fd())}},
+		{name: "stderr terminal", config: LoggerConfig{Format: FormatAuto, Output: "stderr"}, terminal: true, expected: FormatCompact, expectedColor: true, expectedFd: []int{int(os.Stderr.Fd())}},
+		{name: "file output", config: LoggerConfig{Format: FormatAuto, Output: "./app.log"}, terminal: true, expected: FormatJSON},
+		{name: "explicit compact", config: LoggerConfig{Format: FormatCompact}, terminal: false, expected: FormatCompact},
+		{name: "explicit json", config: LoggerConfig{Format: FormatJSON, EnableColor: true}, terminal: true, expected: FormatJSON, expectedColor: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			checked := useTerminal(t, tt.terminal)
+
+			// Act
+			resolved := resolveAutoFormat(tt.config)
+
+			// Assert
+			assert.Equal(t, tt.expected, resolved.Format)
+			assert.Equal(t, tt.expectedColor, resolved.EnableColor)
+			assert.Equal(t, tt.expectedFd, *checked)
+		})
+	}
+}
+
+func TestInitialize_AutoFormatToFileWritesJSON(t *testing.T) {
+	// Arrange
+	previous := GetDefault()
+	t.Cleanup(func() { SetDefault(previous) })
+	useTerminal(t, true)
+
+	path := filepath.Join(t.TempDir(), "app.log")
+	assert.NoError(t, Initialize(LoggerConfig{Level: "info", Format: FormatAuto, Output: path}))
+
+	// Act
+	Info("auto format check")
+	_ = GetDefault().zap.Sync()
+
+	// Assert
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), `"msg":"auto format check"`)
+}
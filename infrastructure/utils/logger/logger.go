@@ -5,30 +5,39 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
 	"time"
 
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
+// Logger is safe for concurrent use. fields is never modified after construction;
+// WithFields always builds a new map, so shared loggers can be read without locking.
 type Logger struct {
 	zap    *zap.Logger
 	fields map[string]interface{}
 }
 
 type LoggerConfig struct {
-	Level       string `yaml:"Level" mapstructure:"Level"`
-	Format      string `yaml:"Format" mapstructure:"Format"` // "json" or "compact"
-	AddSource   bool   `yaml:"AddSource" mapstructure:"AddSource"`
-	TimeFormat  string `yaml:"TimeFormat" mapstructure:"TimeFormat"`
-	Output      string `yaml:"Output" mapstructure:"Output"`           // "stdout", "stderr", or file path (used when EnableFile is false)
-	EnableColor bool   `yaml:"EnableColor" mapstructure:"EnableColor"` // Enable colored output
-	EnableFile  bool   `yaml:"EnableFile" mapstructure:"EnableFile"`   // Enable file logging (writes to both console and file)
-	FilePath    string `yaml:"FilePath" mapstructure:"FilePath"`       // File path when EnableFile is true
+	Level     string `yaml:"Level" mapstructure:"Level"`
+	Format    string `yaml:"Format" mapstructure:"Format"` // "json", "compact" or "auto" (compact on a terminal, json otherwise)
+	AddSource bool   `yaml:"AddSource" mapstructure:"AddSource"`
+	// AddComponent tags entries with the caller's subsystem (repository, service, handler, ...)
+	AddComponent bool   `yaml:"AddComponent" mapstructure:"AddComponent"`
+	TimeFormat   string `yaml:"TimeFormat" mapstructure:"TimeFormat"`   // Go layout or preset (iso8601, rfc3339, epoch, ...); empty uses the format's default
+	Output       string `yaml:"Output" mapstructure:"Output"`           // "stdout", "stderr", or file path (used when EnableFile is false)
+	EnableColor  bool   `yaml:"EnableColor" mapstructure:"EnableColor"` // Enable colored output
+	EnableFile   bool   `yaml:"EnableFile" mapstructure:"EnableFile"`   // Enable file logging (writes to both console and file)
+	FilePath     string `yaml:"FilePath" mapstructure:"FilePath"`       // File path when EnableFile is true
+	// StaticFields are added to every entry, e.g. service, env and version for log aggregation
+	StaticFields map[string]string `yaml:"StaticFields" mapstructure:"StaticFields"`
 }
 
 var (
-	defaultLogger *Logger
+	defaultLogger atomic.Pointer[Logger]
 	contextKey    = &struct{ name string }{"logger"}
 )
 
@@ -38,6 +47,16 @@ func Initialize(config LoggerConfig) error {
 	if err != nil {
 		return err
 	}
+	config = resolveAutoFormat(config)
+
+	defaultTimeEncoder := zapcore.TimeEncoderOfLayout(compactTimeLayout)
+	if config.Format == "json" {
+		defaultTimeEncoder = zapcore.ISO8601TimeEncoder
+	}
+	encodeTime, err := timeEncoder(config.TimeFormat, defaultTimeEncoder)
+	if err != nil {
+		return err
+	}
 
 	// Create output writers
 	var writers []zapcore.WriteSyncer
@@ -88,7 +107,7 @@ func Initialize(config LoggerConfig) error {
 		encoderConfig.LevelKey = "level"
 		encoderConfig.MessageKey = "msg"
 		encoderConfig.CallerKey = "source"
-		encoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
+		encoderConfig.EncodeTime = encodeTime
 		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
 		encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
 	} else {
@@ -98,9 +117,7 @@ func Initialize(config LoggerConfig) error {
 		encoderConfig.LevelKey = "level"
 		encoderConfig.MessageKey = "msg"
 		encoderConfig.CallerKey = "source"
-		encoderConfig.EncodeTime = func(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
-			enc.AppendString(t.Format("2006-01-02T15:04:05.000-0700"))
-		}
+		encoderConfig.EncodeTime = encodeTime
 		encoderConfig.EncodeLevel = zapcore.LowercaseLevelEncoder
 		encoderConfig.EncodeCaller = zapcore.ShortCallerEncoder
 
@@ -109,6 +126,11 @@ func Initialize(config LoggerConfig) error {
 		}
 	}
 
+	// The component needs the caller even when the source itself is not logged
+	if !config.AddSource {
+		encoderConfig.CallerKey = zapcore.OmitKey
+	}
+
 	// Create encoder
 	var encoder zapcore.Encoder
 	if config.Format == "json" {
@@ -119,41 +141,75 @@ func Initialize(config LoggerConfig) error {
 
 	// Create core with proper caller skip
 	core := zapcore.NewCore(encoder, output, level)
+	if config.AddComponent {
+		core = newComponentCore(core)
+	}
 
-	var zapLogger *zap.Logger
-	if config.AddSource {
+	options := []zap.Option{zap.Fields(staticFields(config.StaticFields)...)}
+	if config.AddSource || config.AddComponent {
 		// Add caller with proper skip level to get real caller
-		zapLogger = zap.New(core, zap.AddCaller(), zap.AddCallerSkip(1))
-	} else {
-		zapLogger = zap.New(core)
+		options = append(options, zap.AddCaller(), zap.AddCallerSkip(1))
 	}
+	zapLogger := zap.New(core, options...)
 
-	defaultLogger = &Logger{
+	defaultLogger.Store(&Logger{
 		zap:    zapLogger,
 		fields: make(map[string]interface{}),
-	}
+	})
 
 	return nil
 }
 
+// staticFields converts the configured static fields to zap fields, sorted by key so every
+// entry lists them in the same order
+func staticFields(fields map[string]string) []zap.Field {
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	zapFields := make([]zap.Field, 0, len(keys))
+	for _, key := range keys {
+		zapFields = append(zapFields, zap.String(key, fields[key]))
+	}
+	return zapFields
+}
+
+// New wraps an existing zap logger
+func New(zapLogger *zap.Logger) *Logger {
+	return &Logger{
+		zap:    zapLogger,
+		fields: make(map[string]interface{}),
+	}
+}
+
+// SetDefault replaces the default logger instance
+func SetDefault(l *Logger) {
+	defaultLogger.Store(l)
+}
+
 // GetDefault returns the default logger instance
 func GetDefault() *Logger {
-	if defaultLogger == nil {
-		// Fallback to a basic logger if not initialized
-		config := zap.NewDevelopmentConfig()
-		config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
-		zapLogger, _ := config.Build(zap.AddCallerSkip(1))
-		defaultLogger = &Logger{
-			zap:    zapLogger,
-			fields: make(map[string]interface{}),
-		}
+	if current := defaultLogger.Load(); current != nil {
+		return current
 	}
-	return defaultLogger
+
+	// Fallback to a basic logger if not initialized
+	config := zap.NewDevelopmentConfig()
+	config.Level = zap.NewAtomicLevelAt(zap.InfoLevel)
+	zapLogger, _ := config.Build(zap.AddCallerSkip(1))
+	defaultLogger.CompareAndSwap(nil, &Logger{
+		zap:    zapLogger,
+		fields: make(map[string]interface{}),
+	})
+	return defaultLogger.Load()
 }
 
 // WithFields creates a new logger with additional fields
 func (l *Logger) WithFields(fields map[string]interface{}) *Logger {
-	newFields := make(map[string]interface{})
+	// Copy into a fresh map, l.fields is shared and must stay read-only
+	newFields := make(map[string]interface{}, len(l.fields)+len(fields))
 	for k, v := range l.fields {
 		newFields[k] = v
 	}
@@ -314,13 +370,33 @@ func RequestIDFromContext(ctx context.Context) string {
 	return ""
 }
 
-// LoggerWithRequestIDFromContext creates a logger with request ID from context
+// User ID context operations
+var userIDKey = &struct{ name string }{"user_id"}
+
+// WithUserIDToContext adds the authenticated user's ID to the context
+func WithUserIDToContext(ctx context.Context, userID string) context.Context {
+	return context.WithValue(ctx, userIDKey, userID)
+}
+
+// UserIDFromContext retrieves the authenticated user's ID from context
+func UserIDFromContext(ctx context.Context) string {
+	if userID, ok := ctx.Value(userIDKey).(string); ok {
+		return userID
+	}
+	return ""
+}
+
+// LoggerWithRequestIDFromContext creates a logger with the request ID, and the user ID of
+// authenticated requests, from context
 func LoggerWithRequestIDFromContext(ctx context.Context) *Logger {
-	requestID := RequestIDFromContext(ctx)
-	if requestID != "" {
-		return GetDefault().WithRequestID(requestID)
+	l := GetDefault()
+	if requestID := RequestIDFromContext(ctx); requestID != "" {
+		l = l.WithRequestID(requestID)
 	}
-	return GetDefault()
+	if userID := UserIDFromContext(ctx); userID != "" {
+		l = l.WithUserID(userID)
+	}
+	return l
 }
 
 // Convenience functions for default logger with proper caller information
@@ -385,6 +461,16 @@ func WithComponent(component string) *Logger {
 	return GetDefault().WithComponent(component)
 }
 
+// IsValidLevel reports whether level is a recognised log level; unknown levels fall back to info
+func IsValidLevel(level string) bool {
+	switch strings.ToLower(level) {
+	case "debug", "info", "warn", "warning", "error":
+		return true
+	default:
+		return false
+	}
+}
+
 // Helper functions
 func parseZapLogLevel(level string) (zapcore.Level, error) {
 	switch level {
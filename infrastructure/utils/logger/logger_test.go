@@ -0,0 +1,151 @@
+package logger
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// Run with -race to detect concurrent map access
+func TestLogger_WithFieldsConcurrent(t *testing.T) {
+	base := &Logger{
+		zap:    zap.NewNop(),
+		fields: map[string]interface{}{"service": "orders"},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				child := base.WithFields(map[string]interface{}{
+					"worker": worker,
+					"index":  j,
+				})
+				grandchild := child.WithField("step", fmt.Sprintf("%d-%d", worker, j))
+				grandchild.Info("hammering")
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[string]interface{}{"service": "orders"}, base.fields)
+}
+
+func TestLogger_WithFieldsDoesNotMutateParent(t *testing.T) {
+	base := &Logger{zap: zap.NewNop(), fields: map[string]interface{}{}}
+
+	child := base.WithField("request_id", "abc")
+
+	assert.Empty(t, base.fields)
+	assert.Equal(t, "abc", child.fields["request_id"])
+}
+
+func TestGetDefault_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+	loggers := make([]*Logger, 20)
+	for i := range loggers {
+		wg.Add(1)
+		go func(index int) {
+			defer wg.Done()
+			loggers[index] = GetDefault()
+		}(i)
+	}
+	wg.Wait()
+
+	for _, l := range loggers {
+		assert.Same(t, loggers[0], l)
+	}
+}
+
+func TestInitialize_TimeFormat(t *testing.T) {
+	previous := GetDefault()
+	t.Cleanup(func() { SetDefault(previous) })
+
+	tests := []struct {
+		name       string
+		format     string
+		timeFormat string
+		expected   *regexp.Regexp
+	}{
+		{name: "json epoch", format: "json", timeFormat: "epoch", expected: regexp.MustCompile(`"time":\d+\.\d+,`)},
+		{name: "json layout", format: "json", timeFormat: "2006/01/02 15:04", expected: regexp.MustCompile(`"time":"\d{4}/\d{2}/\d{2} \d{2}:\d{2}",`)},
+		{name: "compact layout", format: "compact", timeFormat: "2006/01/02 15:04", expected: regexp.MustCompile(`^\d{4}/\d{2}/\d{2} \d{2}:\d{2}`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Arrange
+			path := filepath.Join(t.TempDir(), "app.log")
+			err := Initialize(LoggerConfig{Level: "info", Format: tt.format, TimeFormat: tt.timeFormat, Output: path})
+			assert.NoError(t, err)
+
+			// Act
+			Info("timestamp check")
+			_ = GetDefault().zap.Sync()
+
+			// Assert
+			content, err := os.ReadFile(path)
+			assert.NoError(t, err)
+			assert.Regexp(t, tt.expected, string(content))
+		})
+	}
+}
+
+func TestInitialize_InvalidTimeFormat(t *testing.T) {
+	previous := GetDefault()
+	t.Cleanup(func() { SetDefault(previous) })
+
+	err := Initialize(LoggerConfig{Level: "info", Format: "json", TimeFormat: "hh:mm"})
+
+	assert.ErrorContains(t, err, "invalid log time format")
+	assert.Same(t, previous, GetDefault())
+}
+
+func TestInitialize_StaticFields(t *testing.T) {
+	previous := GetDefault()
+	t.Cleanup(func() { SetDefault(previous) })
+
+	// Arrange
+	path := filepath.Join(t.TempDir(), "app.log")
+	err := Initialize(LoggerConfig{
+		Level:        "info",
+		Format:       "json",
+		Output:       path,
+		StaticFields: map[string]string{"service": "order-management", "env": "staging", "version": "1.4.2"},
+	})
+	assert.NoError(t, err)
+
+	// Act
+	GetDefault().WithField("order_id", 7).Info("arbitrary entry")
+	_ = GetDefault().zap.Sync()
+
+	// Assert
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	var entry map[string]interface{}
+	assert.NoError(t, json.Unmarshal(content, &entry))
+	assert.Equal(t, "arbitrary entry", entry["msg"])
+	assert.Equal(t, "order-management", entry["service"])
+	assert.Equal(t, "staging", entry["env"])
+	assert.Equal(t, "1.4.2", entry["version"])
+	assert.Equal(t, float64(7), entry["order_id"])
+}
+
+func TestValidateTimeFormat(t *testing.T) {
+	for _, format := range []string{"", "iso8601", "RFC3339", "epoch_millis", time.Kitchen, "2006-01-02"} {
+		assert.NoError(t, ValidateTimeFormat(format), format)
+	}
+	for _, format := range []string{"hh:mm", "   ", "timestamp"} {
+		assert.Error(t, ValidateTimeFormat(format), format)
+	}
+}
@@ -0,0 +1,46 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// compactTimeLayout is the compact encoder's timestamp layout when TimeFormat is empty
+const compactTimeLayout = "2006-01-02T15:04:05.000-0700"
+
+// namedTimeEncoders are the TimeFormat values that select a preset instead of a Go layout
+var namedTimeEncoders = map[string]zapcore.TimeEncoder{
+	"iso8601":      zapcore.ISO8601TimeEncoder,
+	"rfc3339":      zapcore.RFC3339TimeEncoder,
+	"rfc3339nano":  zapcore.RFC3339NanoTimeEncoder,
+	"epoch":        zapcore.EpochTimeEncoder,
+	"epoch_millis": zapcore.EpochMillisTimeEncoder,
+	"epoch_nanos":  zapcore.EpochNanosTimeEncoder,
+}
+
+// ValidateTimeFormat reports whether format is a preset name or a Go time layout
+func ValidateTimeFormat(format string) error {
+	_, err := timeEncoder(format, zapcore.ISO8601TimeEncoder)
+	return err
+}
+
+// timeEncoder resolves LoggerConfig.TimeFormat: empty selects fallback, a preset name
+// (iso8601, rfc3339, rfc3339nano, epoch, epoch_millis, epoch_nanos) selects that encoder,
+// anything else is used as a Go reference-time layout.
+func timeEncoder(format string, fallback zapcore.TimeEncoder) (zapcore.TimeEncoder, error) {
+	if format == "" {
+		return fallback, nil
+	}
+	if enc, ok := namedTimeEncoders[strings.ToLower(format)]; ok {
+		return enc, nil
+	}
+
+	// A layout without any reference-time element formats to itself and would log a constant
+	if strings.TrimSpace(format) == "" || time.Date(2001, 2, 3, 4, 5, 6, 0, time.UTC).Format(format) == format {
+		return nil, fmt.Errorf("invalid log time format %q: expected a preset or a Go layout such as %q", format, time.RFC3339)
+	}
+	return zapcore.TimeEncoderOfLayout(format), nil
+}
@@ -0,0 +1,45 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Testzyler/order-management-go/application/domain"
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+)
+
+// OrderExpiryConfig controls how often stale pending orders are cancelled
+type OrderExpiryConfig struct {
+	Enabled  bool          `mapstructure:"Enabled"`
+	Interval time.Duration `mapstructure:"Interval"`
+	MaxAge   time.Duration `mapstructure:"MaxAge"`
+}
+
+// RunOrderExpiryWorker periodically cancels pending orders older than MaxAge.
+// It blocks until the context is cancelled.
+func RunOrderExpiryWorker(ctx context.Context, service domain.OrderService, config OrderExpiryConfig) {
+	workerLogger := logger.WithComponent("order_expiry_worker")
+	workerLogger.Info("Starting order expiry worker", "interval", config.Interval.String(), "max_age", config.MaxAge.String())
+
+	ticker := time.NewTicker(config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			workerLogger.Info("Order expiry worker stopped")
+			return
+		case <-ticker.C:
+			expired, err := service.ExpirePendingOrders(ctx, config.MaxAge)
+			if err != nil {
+				workerLogger.WithError(err).Error("Failed to expire pending orders")
+				continue
+			}
+			if expired > 0 {
+				workerLogger.Info("Expired stale pending orders", "count", expired)
+			} else {
+				workerLogger.Debug("No stale pending orders to expire")
+			}
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package worker
+
+import (
+	"context"
+	"time"
+
+	"github.com/Testzyler/order-management-go/infrastructure/utils/logger"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PoolStatter is implemented by database pools that expose pgxpool statistics
+type PoolStatter interface {
+	Stat() *pgxpool.Stat
+}
+
+// RunPoolStatsLogger logs the pool statistics every interval.
+// It blocks until the context is cancelled.
+func RunPoolStatsLogger(ctx context.Context, pool PoolStatter, interval time.Duration) {
+	statsLogger := logger.WithComponent("pool_stats")
+	statsLogger.Info("Starting pool stats logger", "interval", interval.String())
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			statsLogger.Info("Pool stats logger stopped")
+			return
+		case <-ticker.C:
+			logPoolStats(statsLogger, pool.Stat())
+		}
+	}
+}
+
+// logPoolStats writes one structured log entry with the connection and acquire counters
+func logPoolStats(statsLogger *logger.Logger, stat *pgxpool.Stat) {
+	statsLogger.Info("Database pool stats",
+		"total_conns", stat.TotalConns(),
+		"acquired_conns", stat.AcquiredConns(),
+		"idle_conns", stat.IdleConns(),
+		"constructing_conns", stat.ConstructingConns(),
+		"max_conns", stat.MaxConns(),
+		"acquire_count", stat.AcquireCount(),
+		"empty_acquire_count", stat.EmptyAcquireCount(),
+		"canceled_acquire_count", stat.CanceledAcquireCount(),
+		"acquire_duration_ms", stat.AcquireDuration().Milliseconds(),
+	)
+}
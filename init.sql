@@ -1,14 +1,29 @@
 CREATE SCHEMA IF NOT EXISTS store;
+CREATE TABLE
+    store.schema_migrations (
+        version BIGINT PRIMARY KEY,
+        dirty BOOLEAN NOT NULL DEFAULT FALSE
+    );
+
 CREATE TABLE
     store.orders (
         id SERIAL PRIMARY KEY,
-        customer_name VARCHAR(100),
+        public_id UUID NOT NULL UNIQUE DEFAULT gen_random_uuid (), -- exposed as the order ID when Order.IDStrategy is uuid
+        order_number VARCHAR(32) NOT NULL UNIQUE,
+        customer_name TEXT, -- holds "enc:v<version>:<base64>" ciphertext when Security.EncryptPII is on
         total_amount DECIMAL(10, 2),
         status VARCHAR(50),
         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+        updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+        user_id VARCHAR(255) -- JWT subject of the creator, NULL for orders created without Auth
     );
 
+-- Supports incremental sync (GET /orders?updated_since=...)
+CREATE INDEX idx_orders_updated_at ON store.orders (updated_at, id);
+CREATE INDEX idx_orders_created_at ON store.orders (created_at DESC, id DESC);
+-- Supports listing a user's own orders (GET /orders?mine=true)
+CREATE INDEX idx_orders_user_id ON store.orders (user_id, created_at DESC, id DESC);
+
 CREATE TABLE
     store.order_items (
         id SERIAL PRIMARY KEY,
@@ -16,6 +31,46 @@ CREATE TABLE
         product_name VARCHAR(100),
         quantity INT,
         price DECIMAL(10, 2),
+        status VARCHAR(20) NOT NULL DEFAULT 'pending',
+        shipped_quantity INT NOT NULL DEFAULT 0,
         created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
         updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
-    );
\ No newline at end of file
+    );
+
+CREATE TABLE
+    store.order_status_history (
+        id SERIAL PRIMARY KEY,
+        order_id INT REFERENCES store.orders (id) ON DELETE CASCADE,
+        from_status VARCHAR(50),
+        to_status VARCHAR(50),
+        reason VARCHAR(100),
+        changed_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE TABLE
+    store.fulfillments (
+        id SERIAL PRIMARY KEY,
+        order_id INT NOT NULL REFERENCES store.orders (id) ON DELETE CASCADE,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE TABLE
+    store.fulfillment_items (
+        fulfillment_id INT NOT NULL REFERENCES store.fulfillments (id) ON DELETE CASCADE,
+        order_item_id INT NOT NULL REFERENCES store.order_items (id) ON DELETE CASCADE,
+        quantity INT NOT NULL CHECK (quantity > 0),
+        PRIMARY KEY (fulfillment_id, order_item_id)
+    );
+
+CREATE TABLE
+    store.order_notes (
+        id SERIAL PRIMARY KEY,
+        order_id INT NOT NULL REFERENCES store.orders (id) ON DELETE CASCADE,
+        author VARCHAR(100) NOT NULL,
+        text VARCHAR(2000) NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX idx_order_notes_order_id ON store.order_notes (order_id, created_at, id);
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (7, FALSE);
\ No newline at end of file
@@ -6,7 +6,15 @@ import (
 	"github.com/Testzyler/order-management-go/cmd"
 )
 
+// Build information, injected at build time with
+// -ldflags "-X main.version=<version> -X main.commit=<commit>"
+var (
+	version = "dev"
+	commit  = "unknown"
+)
+
 func main() {
 	runtime.GOMAXPROCS(runtime.NumCPU())
+	cmd.SetBuildInfo(version, commit)
 	cmd.Execute()
 }
@@ -0,0 +1,20 @@
+-- Adds orders.order_number to databases created before it existed. Existing orders get a number
+-- in the usual ORD-YYYYMMDD-XXXXXX format, from their creation date and their ID spelled in the
+-- 32 letter order number alphabet, so the backfilled numbers are unique.
+BEGIN;
+
+ALTER TABLE store.orders ADD COLUMN IF NOT EXISTS order_number VARCHAR(32);
+
+UPDATE store.orders
+SET order_number = 'ORD-' || to_char(COALESCE(created_at, CURRENT_TIMESTAMP), 'YYYYMMDD') || '-' || (
+        SELECT string_agg(substr('ABCDEFGHJKLMNPQRSTUVWXYZ23456789', ((id >> (5 * (5 - n))) & 31) + 1, 1), '' ORDER BY n)
+        FROM generate_series(0, 5) AS n
+    )
+WHERE order_number IS NULL;
+
+ALTER TABLE store.orders ALTER COLUMN order_number SET NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS orders_order_number_key ON store.orders (order_number);
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (2, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;
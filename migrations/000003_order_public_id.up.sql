@@ -0,0 +1,10 @@
+-- Adds orders.public_id, the order ID exposed when Order.IDStrategy is uuid. Adding the column
+-- with a volatile default gives every existing order its own UUID.
+BEGIN;
+
+ALTER TABLE store.orders ADD COLUMN IF NOT EXISTS public_id UUID NOT NULL DEFAULT gen_random_uuid ();
+CREATE UNIQUE INDEX IF NOT EXISTS orders_public_id_key ON store.orders (public_id);
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (3, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,25 @@
+-- Adds the item status and shipped quantity, and the fulfillment tables recording shipments.
+-- Existing items start as pending with nothing shipped.
+BEGIN;
+
+ALTER TABLE store.order_items ADD COLUMN IF NOT EXISTS status VARCHAR(20) NOT NULL DEFAULT 'pending';
+ALTER TABLE store.order_items ADD COLUMN IF NOT EXISTS shipped_quantity INT NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS
+    store.fulfillments (
+        id SERIAL PRIMARY KEY,
+        order_id INT NOT NULL REFERENCES store.orders (id) ON DELETE CASCADE,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE TABLE IF NOT EXISTS
+    store.fulfillment_items (
+        fulfillment_id INT NOT NULL REFERENCES store.fulfillments (id) ON DELETE CASCADE,
+        order_item_id INT NOT NULL REFERENCES store.order_items (id) ON DELETE CASCADE,
+        quantity INT NOT NULL CHECK (quantity > 0),
+        PRIMARY KEY (fulfillment_id, order_item_id)
+    );
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (4, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,17 @@
+-- Adds the order_notes table behind /orders/{order_id}/notes
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS
+    store.order_notes (
+        id SERIAL PRIMARY KEY,
+        order_id INT NOT NULL REFERENCES store.orders (id) ON DELETE CASCADE,
+        author VARCHAR(100) NOT NULL,
+        text VARCHAR(2000) NOT NULL,
+        created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+    );
+
+CREATE INDEX IF NOT EXISTS idx_order_notes_order_id ON store.order_notes (order_id, created_at, id);
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (5, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;
@@ -0,0 +1,9 @@
+-- Widens orders.customer_name to TEXT: with Security.EncryptPII it holds "enc:v<version>:<base64>"
+-- ciphertext, longer than the name itself
+BEGIN;
+
+ALTER TABLE store.orders ALTER COLUMN customer_name TYPE TEXT;
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (6, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;
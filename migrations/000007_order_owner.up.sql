@@ -0,0 +1,10 @@
+-- Adds orders.user_id, the owner of orders created by an authenticated request. Existing orders
+-- keep a NULL owner.
+BEGIN;
+
+ALTER TABLE store.orders ADD COLUMN IF NOT EXISTS user_id VARCHAR(255);
+CREATE INDEX IF NOT EXISTS idx_orders_user_id ON store.orders (user_id, created_at DESC, id DESC);
+
+INSERT INTO store.schema_migrations (version, dirty) VALUES (7, FALSE) ON CONFLICT (version) DO NOTHING;
+
+COMMIT;